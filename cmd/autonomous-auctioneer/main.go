@@ -108,6 +108,7 @@ func mainImpl() int {
 	})
 
 	timeboost.EnsureBidValidatorExposedViaRPC(&stackConf)
+	timeboost.EnsureAuctioneerAdminExposedViaAuthRPC(&stackConf)
 
 	if err := startMetrics(nodeConfig); err != nil {
 		log.Error("Error starting metrics", "error", err)
@@ -131,7 +132,25 @@ func mainImpl() int {
 			log.Error("Error creating new auctioneer", "error", err)
 			return 1
 		}
+		stack, err := node.New(&stackConf)
+		if err != nil {
+			flag.Usage()
+			log.Crit("failed to initialize geth stack", "err", err)
+		}
+		if liveNodeConfig.Get().AuctioneerServer.Preflight {
+			if err := auctioneer.Preflight(ctx); err != nil {
+				log.Error("Auctioneer preflight failed", "err", err)
+				return 1
+			}
+		}
+		auctioneer.RegisterAPIs(stack)
+		err = stack.Start()
+		if err != nil {
+			fatalErrChan <- fmt.Errorf("error starting stack: %w", err)
+		}
+		defer stack.Close()
 		auctioneer.Start(ctx)
+		defer auctioneer.StopAndWait()
 	} else if nodeConfig.BidValidator.Enable {
 		log.Info("Running Arbitrum express lane bid validator", "revision", vcsRevision, "vcs.time", vcsTime)
 		stack, err := node.New(&stackConf)
@@ -158,6 +177,7 @@ func mainImpl() int {
 		}
 		defer stack.Close()
 		bidValidator.Start(ctx)
+		defer bidValidator.StopAndWait()
 	}
 
 	liveNodeConfig.Start(ctx)
@@ -172,8 +192,8 @@ func mainImpl() int {
 		log.Error("shutting down due to fatal error", "err", err)
 		defer log.Error("shut down due to fatal error", "err", err)
 		exitCode = 1
-	case <-sigint:
-		log.Info("shutting down because of sigint")
+	case sig := <-sigint:
+		log.Info("shutting down because of signal", "signal", sig)
 	}
 	// cause future ctrl+c's to panic
 	close(sigint)
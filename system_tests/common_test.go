@@ -1515,6 +1515,18 @@ func CheckEqual[T any](t *testing.T, want T, got T, printables ...interface{}) {
 	}
 }
 
+// RequireGasWithin fails the test if the L2 gas used by the receipt (excluding
+// the L1 component) is further than tolerancePercent from expected.
+func RequireGasWithin(t *testing.T, receipt *types.Receipt, expected uint64, tolerancePercent uint64) {
+	t.Helper()
+	got := receipt.GasUsed - receipt.GasUsedForL1
+	bound := arbmath.SaturatingUMul(expected, tolerancePercent) / 100
+	if !arbmath.Within(got, expected, bound) {
+		diff := int64(got) - int64(expected) // #nosec G115
+		Fatal(t, "gas used ", got, " not within ", tolerancePercent, "% of expected ", expected, " (allowed ±", bound, ", diff ", diff, ")")
+	}
+}
+
 func Create2ndNodeWithConfig(
 	t *testing.T,
 	ctx context.Context,
@@ -7,6 +7,7 @@ package arbtest
 import (
 	"encoding/binary"
 	"encoding/json"
+	"fmt"
 	"math/big"
 	"strings"
 	"testing"
@@ -16,6 +17,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/node"
 
 	"github.com/offchainlabs/nitro/arbos/util"
 	"github.com/offchainlabs/nitro/arbutil"
@@ -23,7 +25,10 @@ import (
 	"github.com/offchainlabs/nitro/solgen/go/precompilesgen"
 	"github.com/offchainlabs/nitro/util/arbmath"
 	"github.com/offchainlabs/nitro/util/colors"
+	"github.com/offchainlabs/nitro/util/rpcclient"
 	"github.com/offchainlabs/nitro/util/testhelpers"
+	"github.com/offchainlabs/nitro/validator"
+	validatorclient "github.com/offchainlabs/nitro/validator/client"
 )
 
 func blockIsEmpty(block *types.Block) bool {
@@ -105,6 +110,103 @@ func validateBlockRange(
 	}
 }
 
+// validateBlockRangeWithDiagnostics validates the given blocks like
+// validateBlockRange, but on failure reports where the validator diverged
+// from the executor for every mismatching block, rather than only that it did.
+// Validation only reports the end state it computed, not the gas it used, so
+// gas is not compared on its own: the block hash commits to the gas used in
+// the header, and a gas divergence fails validation as a block hash mismatch.
+func validateBlockRangeWithDiagnostics(
+	t *testing.T, blocks []uint64, builder *NodeBuilder,
+) {
+	t.Helper()
+	ctx := builder.ctx
+
+	waitForSequencer(t, builder, arbmath.MaxInt(blocks...))
+
+	var mismatches []string
+	wasmModuleRoot := currentRootModule(t)
+	for _, block := range blocks {
+		now := time.Now()
+		correct, end, err := builder.L2.ConsensusNode.StatelessBlockValidator.ValidateResult(
+			ctx, arbutil.MessageIndex(block), false, wasmModuleRoot,
+		)
+		Require(t, err, "block", block)
+		passed := formatTime(time.Since(now))
+		if correct {
+			colors.PrintMint("yay!! we validated block ", block, " in ", passed)
+			continue
+		}
+		colors.PrintRed("failed to validate block ", block, " in ", passed)
+		mismatches = append(mismatches, describeValidationMismatch(t, builder, block, end))
+	}
+	if len(mismatches) > 0 {
+		Fatal(t, "validation diverged from execution:\n", strings.Join(mismatches, "\n"))
+	}
+}
+
+// describeValidationMismatch explains how the end state computed by the
+// validator for a block differs from the one the executor produced.
+func describeValidationMismatch(
+	t *testing.T, builder *NodeBuilder, block uint64, validated *validator.GoGlobalState,
+) string {
+	t.Helper()
+	ctx := builder.ctx
+	entry, err := builder.L2.ConsensusNode.StatelessBlockValidator.CreateReadyValidationEntry(ctx, arbutil.MessageIndex(block))
+	Require(t, err, "block", block)
+	header, err := builder.L2.Client.HeaderByNumber(ctx, new(big.Int).SetUint64(block))
+	Require(t, err, "block", block)
+
+	expected := entry.End
+	var diffs []string
+	if validated.BlockHash != expected.BlockHash {
+		diffs = append(diffs, fmt.Sprintf("block hash: executor %v, validator %v", expected.BlockHash, validated.BlockHash))
+	}
+	if validated.SendRoot != expected.SendRoot {
+		diffs = append(diffs, fmt.Sprintf("send root: executor %v, validator %v", expected.SendRoot, validated.SendRoot))
+	}
+	if validated.Batch != expected.Batch || validated.PosInBatch != expected.PosInBatch {
+		diffs = append(diffs, fmt.Sprintf("inbox position: executor %v/%v, validator %v/%v", expected.Batch, expected.PosInBatch, validated.Batch, validated.PosInBatch))
+	}
+	return fmt.Sprintf(
+		"block %v (executor state root %v, tx root %v, %v gas used, validated from %v): %v",
+		block, header.Root, header.TxHash, header.GasUsed, entry.Start, strings.Join(diffs, "; "),
+	)
+}
+
+// validateBlockOnStack validates a single block directly against the validation
+// node running on valStack, rather than the node's configured validation servers,
+// and returns the end state computed by that validation node. It fails the test
+// if the end state differs from the executor's.
+func validateBlockOnStack(
+	t *testing.T, block uint64, builder *NodeBuilder, valStack *node.Node,
+) validator.GoGlobalState {
+	t.Helper()
+	ctx := builder.ctx
+
+	waitForSequencer(t, builder, block)
+
+	client := validatorclient.NewExecutionClient(StaticFetcherFrom(t, &rpcclient.TestClientConfig), valStack)
+	Require(t, client.Start(ctx))
+	defer client.StopAndWait()
+
+	entry, err := builder.L2.ConsensusNode.StatelessBlockValidator.CreateReadyValidationEntry(ctx, arbutil.MessageIndex(block))
+	Require(t, err, "block", block)
+	input, err := entry.ToInput(client.StylusArchs())
+	Require(t, err, "block", block)
+
+	now := time.Now()
+	end, err := client.Launch(input, currentRootModule(t)).Await(ctx)
+	Require(t, err, "block", block)
+	passed := formatTime(time.Since(now))
+	if end != entry.End {
+		colors.PrintRed("failed to validate block ", block, " on ", client.Name(), " in ", passed)
+		Fatal(t, "validation on ", client.Name(), " disagrees with the executor for block ", block, ": got ", end, ", want ", entry.End)
+	}
+	colors.PrintMint("validated block ", block, " on ", client.Name(), " in ", passed)
+	return end
+}
+
 func TestProgramEvmData(t *testing.T) {
 	t.Parallel()
 	testEvmData(t, true)
@@ -7,6 +7,10 @@ package arbtest
 
 import (
 	"testing"
+
+	"github.com/ethereum/go-ethereum/node"
+
+	"github.com/offchainlabs/nitro/validator"
 )
 
 // used in program test
@@ -21,3 +25,16 @@ func validateBlockRange(
 	builder *NodeBuilder,
 ) {
 }
+
+// used in storage trie test
+func validateBlockRangeWithDiagnostics(
+	t *testing.T, blocks []uint64, builder *NodeBuilder,
+) {
+}
+
+// used in storage trie test
+func validateBlockOnStack(
+	t *testing.T, block uint64, builder *NodeBuilder, valStack *node.Node,
+) validator.GoGlobalState {
+	return validator.GoGlobalState{}
+}
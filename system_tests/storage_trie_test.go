@@ -10,9 +10,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/node"
 
+	"github.com/offchainlabs/nitro/solgen/go/mocksgen"
 	"github.com/offchainlabs/nitro/util/arbmath"
+	"github.com/offchainlabs/nitro/validator"
 	"github.com/offchainlabs/nitro/validator/valnode"
 )
 
@@ -33,53 +38,137 @@ func TestStorageTrie(t *testing.T) {
 	builder.nodeConfig.ParentChainReader.Enable = true
 	builder.nodeConfig.ParentChainReader.OldHeaderTimeout = 10 * time.Minute
 
-	valConf := valnode.TestValidationConfig
-	valConf.UseJit = true
-	_, valStack := createTestValidationNode(t, ctx, &valConf)
-	configByValidationNode(builder.nodeConfig, valStack)
+	// Run both a JIT and an arbitrator validation node, so that the same
+	// blocks can be cross-checked between the two execution paths.
+	jitConf := valnode.TestValidationConfig
+	jitConf.UseJit = true
+	_, jitStack := createTestValidationNode(t, ctx, &jitConf)
+	arbConf := valnode.TestValidationConfig
+	arbConf.UseJit = false
+	_, arbStack := createTestValidationNode(t, ctx, &arbConf)
+	configByValidationNode(builder.nodeConfig, jitStack)
 
 	cleanup := builder.Build(t)
 	defer cleanup()
 
-	ownerTxOpts := builder.L2Info.GetDefaultTransactOpts("Owner", ctx)
-	_, bigMap := builder.L2.DeployBigMap(t, ownerTxOpts)
-
-	// Store enough values to use just over 32M gas
-	toAdd := big.NewInt(1420)
-	// In the first transaction, don't clear any values.
-	toClear := big.NewInt(0)
+	// Storing 1420 values uses just over 32M gas, and gas used should scale
+	// linearly with the number of values stored. Each case stores into a fresh
+	// map, and smaller cases allow more tolerance for the fixed cost of a tx.
+	const gasPerValue = 32_000_000 / storageTrieValues
+	var bigMap *mocksgen.BigMap
+	var receipt *types.Receipt
+	for _, tc := range []struct {
+		name             string
+		values           int64
+		tolerancePercent uint64
+	}{
+		{name: "small", values: 100, tolerancePercent: 5},
+		{name: "medium", values: 500, tolerancePercent: 3},
+		{name: "large", values: storageTrieValues, tolerancePercent: 2},
+	} {
+		_, bigMap, receipt = storeBigMapValues(t, ctx, builder, tc.values)
+		t.Logf("%s: stored %d values using %d gas", tc.name, tc.values, receipt.GasUsed-receipt.GasUsedForL1)
+		RequireGasWithin(t, receipt, gasPerValue*uint64(tc.values), tc.tolerancePercent)
+	}
+	// The rest of the test continues with the largest map.
+	tx1BlockNum := receipt.BlockNumber.Uint64()
 
-	userTxOpts := builder.L2Info.GetDefaultTransactOpts("Faucet", ctx)
-	tx, err := bigMap.ClearAndAddValues(&userTxOpts, toClear, toAdd)
-	Require(t, err)
+	receipt = reshapeBigMapValues(t, ctx, builder, bigMap, storageTrieValues)
+	tx2BlockNum := receipt.BlockNumber.Uint64()
 
-	receipt, err := builder.L2.EnsureTxSucceeded(tx)
-	Require(t, err)
-	tx1BlockNum := receipt.BlockNumber.Uint64()
+	if tx2BlockNum <= tx1BlockNum {
+		t.Errorf("Expected tx2BlockNum > tx1BlockNum: %d <= %d", tx2BlockNum, tx1BlockNum)
+	} else {
+		t.Logf("tx2BlockNum > tx1BlockNum: %d > %d", tx2BlockNum, tx1BlockNum)
+	}
 
-	want := uint64(20_000_000)
-	got := receipt.GasUsed - receipt.GasUsedForL1
-	if got < want {
-		t.Errorf("Want at least GasUsed: %d: got: %d", want, got)
+	// Ensures that the validator gets the same results as the executor, over
+	// the blocks storing and reshaping the largest map
+	blocks := []uint64{}
+	for block := tx1BlockNum; block <= tx2BlockNum; block++ {
+		blocks = append(blocks, block)
 	}
+	validateBlockRangeWithDiagnostics(t, blocks, builder)
+
+	// Ensures that the JIT and arbitrator validators agree on the same blocks
+	results := make(map[bool][]validator.GoGlobalState)
+	for _, tc := range []struct {
+		name  string
+		jit   bool
+		stack *node.Node
+	}{
+		{name: "jit", jit: true, stack: jitStack},
+		{name: "arbitrator", jit: false, stack: arbStack},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, block := range blocks {
+				results[tc.jit] = append(results[tc.jit], validateBlockOnStack(t, block, builder, tc.stack))
+			}
+		})
+	}
+	if len(results[true]) != len(blocks) || len(results[false]) != len(blocks) {
+		Fatal(t, "JIT and arbitrator validation did not both complete for blocks ", tx1BlockNum, " to ", tx2BlockNum)
+	}
+	for i, block := range blocks {
+		if results[true][i] != results[false][i] {
+			Fatal(t, "JIT and arbitrator validation disagree for block ", block, ": jit ", results[true][i], ", arbitrator ", results[false][i])
+		}
+	}
+}
 
-	// Clear about 75% of them, and add another 10%
-	toClear = arbmath.BigDiv(arbmath.BigMul(toAdd, big.NewInt(75)), big.NewInt(100))
-	toAdd = arbmath.BigDiv(arbmath.BigMul(toAdd, big.NewInt(10)), big.NewInt(100))
+// The number of values the storage trie workload stores before reshaping them.
+const storageTrieValues = 1420
 
-	tx, err = bigMap.ClearAndAddValues(&userTxOpts, toClear, toAdd)
+// storeBigMapValues deploys a fresh BigMap and stores values into it, without
+// clearing any.
+func storeBigMapValues(t *testing.T, ctx context.Context, builder *NodeBuilder, values int64) (common.Address, *mocksgen.BigMap, *types.Receipt) {
+	t.Helper()
+	ownerTxOpts := builder.L2Info.GetDefaultTransactOpts("Owner", ctx)
+	userTxOpts := builder.L2Info.GetDefaultTransactOpts("Faucet", ctx)
+	addr, bigMap := builder.L2.DeployBigMap(t, ownerTxOpts)
+	tx, err := bigMap.ClearAndAddValues(&userTxOpts, big.NewInt(0), big.NewInt(values))
+	Require(t, err)
+	receipt, err := builder.L2.EnsureTxSucceeded(tx)
 	Require(t, err)
+	return addr, bigMap, receipt
+}
 
-	receipt, err = builder.L2.EnsureTxSucceeded(tx)
+// reshapeBigMapValues clears about 75% of the values stored in a BigMap, and
+// adds another 10%.
+func reshapeBigMapValues(t *testing.T, ctx context.Context, builder *NodeBuilder, bigMap *mocksgen.BigMap, stored int64) *types.Receipt {
+	t.Helper()
+	userTxOpts := builder.L2Info.GetDefaultTransactOpts("Faucet", ctx)
+	toClear := arbmath.BigDiv(arbmath.BigMul(big.NewInt(stored), big.NewInt(75)), big.NewInt(100))
+	toAdd := arbmath.BigDiv(arbmath.BigMul(big.NewInt(stored), big.NewInt(10)), big.NewInt(100))
+	tx, err := bigMap.ClearAndAddValues(&userTxOpts, toClear, toAdd)
 	Require(t, err)
-	tx2BlockNum := receipt.BlockNumber.Uint64()
+	receipt, err := builder.L2.EnsureTxSucceeded(tx)
+	Require(t, err)
+	return receipt
+}
 
-	if tx2BlockNum <= tx1BlockNum {
-		t.Errorf("Expected tx2BlockNum > tx1BlockNum: %d <= %d", tx2BlockNum, tx1BlockNum)
-	} else {
-		t.Logf("tx2BlockNum > tx1BlockNum: %d > %d", tx2BlockNum, tx1BlockNum)
-	}
+// TestStorageTrieSchemes runs the storage trie workload under both state
+// schemes, which must build byte-identical storage tries. Only the storage
+// root of the workload is compared, the state roots of independent chains
+// differ anyway, e.g. by the timestamps of their blocks.
+func TestStorageTrieSchemes(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-	// Ensures that the validator gets the same results as the executor
-	validateBlockRange(t, []uint64{receipt.BlockNumber.Uint64()}, true, builder)
+	roots := make(map[string]common.Hash)
+	for _, scheme := range []string{rawdb.HashScheme, rawdb.PathScheme} {
+		builder := NewNodeBuilder(ctx).DefaultConfig(t, false)
+		builder.execConfig.Caching.StateScheme = scheme
+		cleanup := builder.Build(t)
+
+		addr, bigMap, _ := storeBigMapValues(t, ctx, builder, storageTrieValues)
+		reshapeBigMapValues(t, ctx, builder, bigMap, storageTrieValues)
+		roots[scheme] = getStorageRootHash(t, builder.L2.ExecNode, addr)
+		t.Logf("%s: storage root %v", scheme, roots[scheme])
+		cleanup()
+	}
+	if roots[rawdb.HashScheme] != roots[rawdb.PathScheme] {
+		Fatal(t, "Storage roots differ between schemes: hash ", roots[rawdb.HashScheme], ", path ", roots[rawdb.PathScheme])
+	}
 }
@@ -0,0 +1,105 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"time"
+)
+
+// ClosingDurationForRound returns the auction closing duration of a round, to
+// override that of the auction contract, e.g. with a longer closing period for
+// rounds of anticipated high demand. Returning zero keeps that of the contract,
+// as do durations leaving no time to bid on the round.
+type ClosingDurationForRound func(round uint64) time.Duration
+
+// WithClosingDurationForRound overrides the auction closing duration round by
+// round, moving when the auctioneer stops caching bids for a round and starts
+// resolving it. The bid validators must be given the same override, see
+// WithValidatorClosingDurationForRound, or they keep accepting bids the
+// auctioneer drops as late. The contract still enforces its own closing
+// duration, so resolutions are held until the auction is closed on the
+// contract as well, as the contract rejects those sent earlier. By default,
+// every round closes as the contract says.
+func WithClosingDurationForRound(closingDuration ClosingDurationForRound) AuctioneerServerOpt {
+	return func(a *AuctioneerServer) {
+		a.closingDurationForRound = closingDuration
+	}
+}
+
+// WithValidatorClosingDurationForRound overrides the auction closing duration
+// round by round, moving when the bid validator stops accepting bids for a
+// round. It must match the override of the auctioneer, see
+// WithClosingDurationForRound.
+func WithValidatorClosingDurationForRound(closingDuration ClosingDurationForRound) BidValidatorOpt {
+	return func(bv *BidValidator) {
+		bv.closingDurationForRound = closingDuration
+	}
+}
+
+// auctionClosingFor returns the auction closing duration of the round under
+// the given round timing, overridden if valid.
+func auctionClosingFor(info RoundTimingInfo, closingDuration ClosingDurationForRound, round uint64) time.Duration {
+	if closingDuration == nil {
+		return info.AuctionClosing
+	}
+	if override := closingDuration(round); override > 0 && override < info.Round {
+		return override
+	}
+	return info.AuctionClosing
+}
+
+// auctionClosing returns the auction closing duration of the round.
+func (a *AuctioneerServer) auctionClosing(round uint64) time.Duration {
+	return auctionClosingFor(a.getRoundTimingInfo(), a.closingDurationForRound, round)
+}
+
+// resolvableAt returns the earliest time the round can be resolved at, once
+// its auction closed both for the auctioneer and on the contract.
+func (a *AuctioneerServer) resolvableAt(round uint64) time.Time {
+	info := a.getRoundTimingInfo()
+	return info.roundStartTime(round).Add(-min(a.auctionClosing(round), info.AuctionClosing))
+}
+
+// waitUntilResolvable waits until the round can be resolved, which it may not
+// be when the auctioneer closes it, if the closing duration of the round is
+// overridden to be longer than that of the contract.
+func (a *AuctioneerServer) waitUntilResolvable(ctx context.Context, round uint64) {
+	wait := time.Until(a.resolvableAt(round))
+	if wait <= 0 {
+		return
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
+
+// isAuctionClosedAt reports whether the auction of the upcoming round as of
+// currentTime is closed under the given round timing, overridden if valid.
+func isAuctionClosedAt(info RoundTimingInfo, closingDuration ClosingDurationForRound, currentTime time.Time) bool {
+	info.AuctionClosing = auctionClosingFor(info, closingDuration, info.RoundNumberAt(currentTime)+1)
+	return info.isAuctionRoundClosedAt(currentTime)
+}
+
+// newAuctionCloseTickerFor returns a ticker ticking when the auction of each
+// round closes under the given round timing, overridden if valid, see
+// tickAtAuctionClose.
+func newAuctionCloseTickerFor(info RoundTimingInfo, closingDuration ClosingDurationForRound) *roundTicker {
+	ticker := newRoundTicker(info)
+	if closingDuration != nil {
+		ticker.auctionClosing = func(round uint64) time.Duration {
+			return auctionClosingFor(info, closingDuration, round)
+		}
+	}
+	return ticker
+}
+
+// newAuctionCloseTicker returns a ticker ticking when the auction of each
+// round closes for the auctioneer.
+func (a *AuctioneerServer) newAuctionCloseTicker() *roundTicker {
+	return newAuctionCloseTickerFor(a.getRoundTimingInfo(), a.closingDurationForRound)
+}
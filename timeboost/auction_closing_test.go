@@ -0,0 +1,61 @@
+package timeboost
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvableAt(t *testing.T) {
+	t.Parallel()
+	a := &AuctioneerServer{roundTimingInfo: RoundTimingInfo{
+		Offset:         time.Unix(1_700_000_000, 0),
+		Round:          time.Minute,
+		AuctionClosing: 15 * time.Second,
+	}}
+	roundStart := a.roundTimingInfo.roundStartTime(2)
+	require.Equal(t, roundStart.Add(-15*time.Second), a.resolvableAt(2))
+
+	overrides := map[uint64]time.Duration{
+		// Closed earlier, but resolved once the contract closes the auction.
+		2: 30 * time.Second,
+		// Closed later, and resolved then.
+		3: 5 * time.Second,
+		// No time left to bid, ignored.
+		4: time.Minute,
+	}
+	WithClosingDurationForRound(func(round uint64) time.Duration { return overrides[round] })(a)
+	require.Equal(t, 30*time.Second, a.auctionClosing(2))
+	require.Equal(t, roundStart.Add(-15*time.Second), a.resolvableAt(2))
+	require.Equal(t, 5*time.Second, a.auctionClosing(3))
+	require.Equal(t, a.roundTimingInfo.roundStartTime(3).Add(-5*time.Second), a.resolvableAt(3))
+	require.Equal(t, 15*time.Second, a.auctionClosing(4))
+	require.Equal(t, 15*time.Second, a.auctionClosing(5))
+}
+
+func TestIsAuctionClosedAt(t *testing.T) {
+	t.Parallel()
+	info := RoundTimingInfo{
+		Offset:         time.Unix(1_700_000_000, 0),
+		Round:          time.Minute,
+		AuctionClosing: 15 * time.Second,
+	}
+	// 40 seconds into round 1, bidding on round 2.
+	now := info.roundStartTime(1).Add(40 * time.Second)
+	require.False(t, isAuctionClosedAt(info, nil, now))
+
+	// A longer closing period of round 2 closes it before the contract does.
+	longer := func(round uint64) time.Duration {
+		if round == 2 {
+			return 30 * time.Second
+		}
+		return 0
+	}
+	require.True(t, isAuctionClosedAt(info, longer, now))
+	require.False(t, isAuctionClosedAt(info, longer, info.roundStartTime(2).Add(40*time.Second)))
+
+	bv := &BidValidator{roundTimingInfo: info}
+	WithValidatorClosingDurationForRound(longer)(bv)
+	require.ErrorContains(t, bv.lateBidError(&Bid{Round: 2}, now), "closed 10s ago")
+}
@@ -0,0 +1,186 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/offchainlabs/nitro/solgen/go/express_lane_auctiongen"
+)
+
+// auctionContractSwap is a migration to a new auction contract that is waiting
+// for the current round to be resolved.
+type auctionContractSwap struct {
+	addr            common.Address
+	binding         *express_lane_auctiongen.ExpressLaneAuction
+	domainSeparator [32]byte
+	version         AuctionContractVersion
+}
+
+// SetAuctionContract migrates the auctioneer to the auction contract deployed
+// at addr, e.g. during a contract upgrade, without restarting it. The new
+// contract is checked as the current one was at startup: its version must be
+// supported, and the resolution signers must be granted its auctioneer role.
+// It must also run on the same round schedule as the current one, and the swap
+// only takes effect at the next round boundary, once the upcoming round was
+// resolved with the current contract. Swaps are rejected while an auction is
+// closed and awaiting resolution. A later call replaces an earlier pending
+// swap.
+//
+// The bid validators must be migrated separately. Until they are, the bids
+// they accept for the previous contract are dropped when they reach the
+// auctioneer, as the new contract would reject them.
+func (a *AuctioneerServer) SetAuctionContract(ctx context.Context, addr common.Address) error {
+	sequencerRpc, _, err := a.endpointManager.GetSequencerRPC(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get sequencer RPC: %w", err)
+	}
+	client := newSequencerClient(sequencerRpc)
+	binding, err := express_lane_auctiongen.NewExpressLaneAuction(addr, client)
+	if err != nil {
+		return fmt.Errorf("binding auction contract %s: %w", addr.Hex(), err)
+	}
+	return a.setAuctionContract(ctx, addr, binding, client)
+}
+
+func (a *AuctioneerServer) setAuctionContract(ctx context.Context, addr common.Address, binding *express_lane_auctiongen.ExpressLaneAuction, reader ContractCodeReader) error {
+	if binding == nil {
+		return errors.New("auction contract binding cannot be nil")
+	}
+	currentRoundTimingInfo := a.getRoundTimingInfo()
+	if currentRoundTimingInfo.isAuctionRoundClosed() {
+		// The upcoming round is being resolved against the current contract.
+		return fmt.Errorf("auction for round %d is closed and awaiting resolution, retry after the round boundary", a.UpcomingRound())
+	}
+	version, err := detectAuctionContractVersion(ctx, reader, addr)
+	if err != nil {
+		return err
+	}
+	if !a.observerMode && !a.skipAuctioneerRoleCheck {
+		if err := checkAuctioneerRole(ctx, reader, binding, addr, a.resolutionSignerAddresses()); err != nil {
+			return err
+		}
+	}
+	domainSeparator, err := binding.DomainSeparator(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return fmt.Errorf("reading domain separator of new auction contract: %w", err)
+	}
+	rawRoundTimingInfo, err := binding.RoundTimingInfo(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return fmt.Errorf("reading round timing info of new auction contract: %w", err)
+	}
+	roundTimingInfo, err := NewRoundTimingInfo(rawRoundTimingInfo)
+	if err != nil {
+		return fmt.Errorf("invalid round timing info of new auction contract: %w", err)
+	}
+	if !roundTimingInfo.equal(&currentRoundTimingInfo) {
+		// Switching schedules would leave the current round half resolved.
+		return fmt.Errorf("new auction contract round timing %+v differs from current %+v", *roundTimingInfo, currentRoundTimingInfo)
+	}
+
+	a.auctionContractLock.Lock()
+	defer a.auctionContractLock.Unlock()
+	if a.pendingContractSwap != nil {
+		log.Warn("Replacing pending auction contract migration", "previous", a.pendingContractSwap.addr, "new", addr)
+	}
+	a.pendingContractSwap = &auctionContractSwap{
+		addr:            addr,
+		binding:         binding,
+		domainSeparator: domainSeparator,
+		version:         version,
+	}
+	log.Info("Auction contract migration scheduled for the next round boundary", "from", a.auctionContractAddr, "to", addr, "version", version, "upcomingRound", a.UpcomingRound())
+	return nil
+}
+
+// SetAuctionContract is exposed as auctioneeradmin_setAuctionContract, see
+// AuctioneerServer.SetAuctionContract.
+func (api *AuctioneerAdminAPI) SetAuctionContract(ctx context.Context, addr common.Address) error {
+	return api.auctioneer.SetAuctionContract(ctx, addr)
+}
+
+// applyPendingContractSwap performs a pending auction contract migration. It
+// must only be called from the resolution thread, after the upcoming round
+// was resolved and the bid cache cleared.
+func (a *AuctioneerServer) applyPendingContractSwap() {
+	a.auctionContractLock.Lock()
+	defer a.auctionContractLock.Unlock()
+	swap := a.pendingContractSwap
+	if swap == nil {
+		return
+	}
+	a.pendingContractSwap = nil
+	previous := a.auctionContractAddr
+	log.Info("Migrating to new auction contract", "from", previous, "to", swap.addr, "nextRound", a.UpcomingRound()+1)
+	a.auctionContract = swap.binding
+	a.auctionContractAddr = swap.addr
+	a.auctionContractDomainSeparator = swap.domainSeparator
+	a.auctionContractVersion = swap.version
+	a.bidCache.setDomainSeparator(swap.domainSeparator)
+	// The bid validators aren't migrated with the auctioneer, bids they
+	// accepted for the next round under the previous contract can't be
+	// resolved on this one.
+	if dropped := a.bidCache.removeForOtherContract(swap.addr); dropped > 0 {
+		a.getMetrics().wrongContractBids.Inc(int64(dropped))
+		log.Warn("Dropped bids cached for the previous auction contract", "previous", previous, "bids", dropped)
+	}
+}
+
+func (a *AuctioneerServer) getAuctionContract() *express_lane_auctiongen.ExpressLaneAuction {
+	a.auctionContractLock.RLock()
+	defer a.auctionContractLock.RUnlock()
+	return a.auctionContract
+}
+
+// auctionContractSnapshot is the auction contract the auctioneer resolves on,
+// read at once, so that an attempt at resolving a round uses a single contract
+// throughout even if the contract is migrated in the meantime.
+type auctionContractSnapshot struct {
+	binding         *express_lane_auctiongen.ExpressLaneAuction
+	addr            common.Address
+	domainSeparator [32]byte
+}
+
+func (a *AuctioneerServer) getAuctionContractSnapshot() auctionContractSnapshot {
+	a.auctionContractLock.RLock()
+	defer a.auctionContractLock.RUnlock()
+	return auctionContractSnapshot{
+		binding:         a.auctionContract,
+		addr:            a.auctionContractAddr,
+		domainSeparator: a.auctionContractDomainSeparator,
+	}
+}
+
+// rebindAuctionContract binds the auction contract of the snapshot to a new
+// sequencer client. The auctioneer's binding is only replaced if the contract
+// wasn't migrated since the snapshot was taken.
+func (a *AuctioneerServer) rebindAuctionContract(contract *auctionContractSnapshot, client AuctioneerClient) error {
+	binding, err := express_lane_auctiongen.NewExpressLaneAuction(contract.addr, client)
+	if err != nil {
+		return err
+	}
+	contract.binding = binding
+	a.auctionContractLock.Lock()
+	defer a.auctionContractLock.Unlock()
+	if a.auctionContractAddr == contract.addr {
+		a.auctionContract = binding
+	}
+	return nil
+}
+
+// isForAuctionContract reports whether a bid received from the bid validators
+// was signed for the auction contract the auctioneer currently resolves on.
+// Bid validators aren't migrated with the auctioneer, see SetAuctionContract,
+// and keep accepting bids for the previous contract until they are.
+func (a *AuctioneerServer) isForAuctionContract(bid *ValidatedBid) bool {
+	a.auctionContractLock.RLock()
+	defer a.auctionContractLock.RUnlock()
+	return bid.AuctionContractAddress == a.auctionContractAddr
+}
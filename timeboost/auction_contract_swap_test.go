@@ -0,0 +1,147 @@
+package timeboost
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/offchainlabs/nitro/solgen/go/express_lane_auctiongen"
+)
+
+func TestSetAuctionContract(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	auctionAbi, err := express_lane_auctiongen.ExpressLaneAuctionMetaData.GetAbi()
+	require.NoError(t, err)
+	roundTimingInfoMethod := auctionAbi.Methods["roundTimingInfo"]
+	hasRoleMethod := auctionAbi.Methods["hasRole"]
+	newAuthorizedBinding := func(t *testing.T, addr common.Address, timing express_lane_auctiongen.RoundTimingInfo, authorized bool) *express_lane_auctiongen.ExpressLaneAuction {
+		result, err := roundTimingInfoMethod.Outputs.Pack(timing.OffsetTimestamp, timing.RoundDurationSeconds, timing.AuctionClosingSeconds, timing.ReserveSubmissionSeconds)
+		require.NoError(t, err)
+		hasRole, err := hasRoleMethod.Outputs.Pack(authorized)
+		require.NoError(t, err)
+		client := &fakeAuctioneerClient{callResults: map[[4]byte][]byte{
+			[4]byte(roundTimingInfoMethod.ID): result,
+			[4]byte(hasRoleMethod.ID):         hasRole,
+		}}
+		binding, err := express_lane_auctiongen.NewExpressLaneAuction(addr, client)
+		require.NoError(t, err)
+		return binding
+	}
+	newBinding := func(t *testing.T, addr common.Address, timing express_lane_auctiongen.RoundTimingInfo) *express_lane_auctiongen.ExpressLaneAuction {
+		return newAuthorizedBinding(t, addr, timing, true)
+	}
+	newServer := func(offset time.Time) *AuctioneerServer {
+		return &AuctioneerServer{
+			txOpts:              &bind.TransactOpts{From: common.Address{'a'}},
+			auctionContractAddr: common.HexToAddress("0x1234"),
+			bidCache:            newBidCache([32]byte{}),
+			roundTimingInfo: RoundTimingInfo{
+				Offset:            offset,
+				Round:             time.Minute,
+				AuctionClosing:    15 * time.Second,
+				ReserveSubmission: 15 * time.Second,
+			},
+		}
+	}
+	offset := time.Unix(time.Now().Unix(), 0)
+	timing := express_lane_auctiongen.RoundTimingInfo{
+		OffsetTimestamp:          offset.Unix(),
+		RoundDurationSeconds:     60,
+		AuctionClosingSeconds:    15,
+		ReserveSubmissionSeconds: 15,
+	}
+	newAddr := common.HexToAddress("0x5678")
+	reader := &fakeContractCodeReader{code: map[common.Address][]byte{
+		newAddr: dispatcherCode(t, "resolveMultiBidAuction", "resolveSingleBidAuction", "AUCTIONEER_ROLE", "hasRole"),
+	}}
+
+	t.Run("SwapsAtRoundBoundary", func(t *testing.T) {
+		t.Parallel()
+		a := newServer(offset)
+		binding := newBinding(t, newAddr, timing)
+		require.NoError(t, a.setAuctionContract(ctx, newAddr, binding, reader))
+		require.Equal(t, common.HexToAddress("0x1234"), a.auctionContractAddr)
+
+		a.applyPendingContractSwap()
+		require.Equal(t, newAddr, a.auctionContractAddr)
+		require.Equal(t, binding, a.getAuctionContract())
+		require.Equal(t, [32]byte(common.MaxHash), a.auctionContractDomainSeparator)
+		require.Equal(t, [32]byte(common.MaxHash), a.bidCache.auctionContractDomainSeparator)
+		require.Equal(t, AuctionContractV1, a.auctionContractVersion)
+		require.Nil(t, a.pendingContractSwap)
+	})
+	t.Run("DropsBidsForOtherContract", func(t *testing.T) {
+		t.Parallel()
+		a := newServer(offset)
+		bid := func(controller string, auctionContractAddr common.Address) *ValidatedBid {
+			return &ValidatedBid{
+				ChainId:                big.NewInt(1),
+				AuctionContractAddress: auctionContractAddr,
+				ExpressLaneController:  common.HexToAddress(controller),
+				Bidder:                 common.HexToAddress(controller),
+				Round:                  1,
+				Amount:                 big.NewInt(10),
+			}
+		}
+		a.receiveValidatedBid(bid("0xa", common.HexToAddress("0x1234")).ToJson())
+		a.receiveValidatedBid(bid("0xb", newAddr).ToJson())
+		require.Equal(t, 1, a.bidCache.size())
+		require.Contains(t, a.bidCache.bidsByExpressLaneControllerAddr, common.HexToAddress("0xa"))
+
+		// Once migrated, the bids cached for the previous contract are dropped.
+		require.NoError(t, a.setAuctionContract(ctx, newAddr, newBinding(t, newAddr, timing), reader))
+		a.applyPendingContractSwap()
+		require.Zero(t, a.bidCache.size())
+		a.receiveValidatedBid(bid("0xa", common.HexToAddress("0x1234")).ToJson())
+		a.receiveValidatedBid(bid("0xb", newAddr).ToJson())
+		require.Equal(t, 1, a.bidCache.size())
+		require.Contains(t, a.bidCache.bidsByExpressLaneControllerAddr, common.HexToAddress("0xb"))
+	})
+	t.Run("NilBinding", func(t *testing.T) {
+		t.Parallel()
+		require.Error(t, newServer(offset).setAuctionContract(ctx, newAddr, nil, reader))
+	})
+	t.Run("DifferentTiming", func(t *testing.T) {
+		t.Parallel()
+		other := timing
+		other.RoundDurationSeconds = 120
+		a := newServer(offset)
+		require.ErrorContains(t, a.setAuctionContract(ctx, newAddr, newBinding(t, newAddr, other), reader), "round timing")
+		require.Nil(t, a.pendingContractSwap)
+	})
+	t.Run("UnsupportedVersion", func(t *testing.T) {
+		t.Parallel()
+		unsupported := &fakeContractCodeReader{code: map[common.Address][]byte{newAddr: dispatcherCode(t, "resolveSingleBidAuction")}}
+		a := newServer(offset)
+		require.ErrorContains(t, a.setAuctionContract(ctx, newAddr, newBinding(t, newAddr, timing), unsupported), "unsupported version")
+		require.Nil(t, a.pendingContractSwap)
+	})
+	t.Run("UnauthorizedSigner", func(t *testing.T) {
+		t.Parallel()
+		a := newServer(offset)
+		require.ErrorIs(t, a.setAuctionContract(ctx, newAddr, newAuthorizedBinding(t, newAddr, timing, false), reader), errUnauthorizedAuctioneer)
+		require.Nil(t, a.pendingContractSwap)
+
+		// Unless the role check is skipped, as at startup.
+		a.skipAuctioneerRoleCheck = true
+		require.NoError(t, a.setAuctionContract(ctx, newAddr, newAuthorizedBinding(t, newAddr, timing, false), reader))
+	})
+	t.Run("AuctionClosed", func(t *testing.T) {
+		t.Parallel()
+		// 50 seconds into the round, the auction closed 5 seconds ago.
+		closedOffset := offset.Add(-50 * time.Second)
+		closedTiming := timing
+		closedTiming.OffsetTimestamp = closedOffset.Unix()
+		a := newServer(closedOffset)
+		require.ErrorContains(t, a.setAuctionContract(ctx, newAddr, newBinding(t, newAddr, closedTiming), reader), "awaiting resolution")
+		require.Nil(t, a.pendingContractSwap)
+	})
+}
@@ -0,0 +1,116 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/offchainlabs/nitro/solgen/go/express_lane_auctiongen"
+)
+
+// AuctionContractVersion identifies the set of resolution methods a deployed
+// ExpressLaneAuction contract exposes. The contract has no version getter, so
+// the version is detected from the method selectors in its bytecode.
+type AuctionContractVersion uint8
+
+const (
+	// AuctionContractV1 resolves rounds through resolveMultiBidAuction and
+	// resolveSingleBidAuction, as called by resolveAuctionWithClient.
+	AuctionContractV1 AuctionContractVersion = iota + 1
+)
+
+// auctionContractVersions lists the supported versions, newest first, along
+// with the methods the resolution call path of each version relies on.
+var auctionContractVersions = []struct {
+	version AuctionContractVersion
+	methods []string
+}{
+	{
+		version: AuctionContractV1,
+		methods: []string{"resolveMultiBidAuction", "resolveSingleBidAuction"},
+	},
+}
+
+// eip1967ImplementationSlot is the storage slot an EIP-1967 proxy, which the
+// auction contract is deployed behind, keeps its implementation address in.
+var eip1967ImplementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+
+// ContractCodeReader reads the code and storage of deployed contracts.
+type ContractCodeReader interface {
+	CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error)
+	StorageAt(ctx context.Context, contract common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error)
+}
+
+// detectAuctionContractVersion probes the auction contract deployed at addr,
+// following an EIP-1967 proxy to its implementation, for the newest supported
+// version whose resolution methods it implements. Deployments implementing
+// none of them are rejected, rather than having every resolution revert.
+func detectAuctionContractVersion(ctx context.Context, reader ContractCodeReader, addr common.Address) (AuctionContractVersion, error) {
+	code, err := readAuctionContractCode(ctx, reader, addr)
+	if err != nil {
+		return 0, err
+	}
+	contractAbi, err := express_lane_auctiongen.ExpressLaneAuctionMetaData.GetAbi()
+	if err != nil {
+		return 0, err
+	}
+	var missing string
+	for _, candidate := range auctionContractVersions {
+		missing = ""
+		for _, name := range candidate.methods {
+			method, ok := contractAbi.Methods[name]
+			if !ok || !codeContainsSelector(code, method.ID) {
+				missing = name
+				break
+			}
+		}
+		if missing == "" {
+			log.Info("Detected auction contract version", "address", addr, "version", candidate.version)
+			return candidate.version, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported version of auction contract %s, it does not implement %s", addr, missing)
+}
+
+// readAuctionContractCode reads the code of the auction contract deployed at
+// addr, or of its implementation if it is deployed behind an EIP-1967 proxy.
+func readAuctionContractCode(ctx context.Context, reader ContractCodeReader, addr common.Address) ([]byte, error) {
+	code, err := reader.CodeAt(ctx, addr, nil)
+	if err != nil {
+		return nil, fmt.Errorf("reading code of auction contract %s: %w", addr, err)
+	}
+	if len(code) == 0 {
+		return nil, fmt.Errorf("no auction contract deployed at %s", addr)
+	}
+	slot, err := reader.StorageAt(ctx, addr, eip1967ImplementationSlot, nil)
+	if err != nil {
+		return nil, fmt.Errorf("reading implementation slot of auction contract %s: %w", addr, err)
+	}
+	if implementation := common.BytesToAddress(slot); implementation != (common.Address{}) {
+		code, err = reader.CodeAt(ctx, implementation, nil)
+		if err != nil {
+			return nil, fmt.Errorf("reading code of auction contract implementation %s: %w", implementation, err)
+		}
+	}
+	return code, nil
+}
+
+// codeContainsSelector reports whether the code pushes the method selector,
+// as the function dispatcher of a Solidity contract does for every external
+// method. Leading zero bytes of the selector are dropped by the compiler.
+func codeContainsSelector(code []byte, selector []byte) bool {
+	trimmed := bytes.TrimLeft(selector, "\x00")
+	if len(trimmed) == 0 {
+		return false
+	}
+	push := append([]byte{byte(vm.PUSH1) + byte(len(trimmed)-1)}, trimmed...)
+	return bytes.Contains(code, push)
+}
@@ -0,0 +1,84 @@
+package timeboost
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+
+	"github.com/offchainlabs/nitro/solgen/go/express_lane_auctiongen"
+)
+
+type fakeContractCodeReader struct {
+	code    map[common.Address][]byte
+	storage map[common.Address]map[common.Hash][]byte
+}
+
+func (r *fakeContractCodeReader) CodeAt(_ context.Context, contract common.Address, _ *big.Int) ([]byte, error) {
+	return r.code[contract], nil
+}
+
+func (r *fakeContractCodeReader) StorageAt(_ context.Context, contract common.Address, key common.Hash, _ *big.Int) ([]byte, error) {
+	if value, ok := r.storage[contract][key]; ok {
+		return value, nil
+	}
+	return make([]byte, 32), nil
+}
+
+// dispatcherCode mimics a function dispatcher pushing the selectors of the methods.
+func dispatcherCode(t *testing.T, methods ...string) []byte {
+	contractAbi, err := express_lane_auctiongen.ExpressLaneAuctionMetaData.GetAbi()
+	require.NoError(t, err)
+	code := []byte{byte(vm.PUSH1), 0x80, byte(vm.PUSH1), 0x40, byte(vm.MSTORE)}
+	for _, name := range methods {
+		method, ok := contractAbi.Methods[name]
+		require.True(t, ok, name)
+		code = append(code, byte(vm.DUP1), byte(vm.PUSH4))
+		code = append(code, method.ID...)
+		code = append(code, byte(vm.EQ))
+	}
+	return code
+}
+
+func TestDetectAuctionContractVersion(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	addr := common.HexToAddress("0x1234")
+	implementation := common.HexToAddress("0x5678")
+	v1 := dispatcherCode(t, "resolveMultiBidAuction", "resolveSingleBidAuction", "roundTimingInfo")
+
+	version, err := detectAuctionContractVersion(ctx, &fakeContractCodeReader{code: map[common.Address][]byte{addr: v1}}, addr)
+	require.NoError(t, err)
+	require.Equal(t, AuctionContractV1, version)
+
+	// Behind a proxy, the implementation is probed.
+	proxied := &fakeContractCodeReader{
+		code: map[common.Address][]byte{addr: dispatcherCode(t), implementation: v1},
+		storage: map[common.Address]map[common.Hash][]byte{
+			addr: {eip1967ImplementationSlot: common.LeftPadBytes(implementation.Bytes(), 32)},
+		},
+	}
+	version, err = detectAuctionContractVersion(ctx, proxied, addr)
+	require.NoError(t, err)
+	require.Equal(t, AuctionContractV1, version)
+
+	unsupported := &fakeContractCodeReader{code: map[common.Address][]byte{addr: dispatcherCode(t, "resolveSingleBidAuction")}}
+	_, err = detectAuctionContractVersion(ctx, unsupported, addr)
+	require.ErrorContains(t, err, "does not implement resolveMultiBidAuction")
+
+	_, err = detectAuctionContractVersion(ctx, &fakeContractCodeReader{}, addr)
+	require.ErrorContains(t, err, "no auction contract deployed")
+}
+
+func TestCodeContainsSelector(t *testing.T) {
+	t.Parallel()
+	require.True(t, codeContainsSelector([]byte{byte(vm.PUSH4), 1, 2, 3, 4}, []byte{1, 2, 3, 4}))
+	require.False(t, codeContainsSelector([]byte{byte(vm.PUSH3), 1, 2, 3, 4}, []byte{1, 2, 3, 4}))
+	// Selectors with leading zero bytes are pushed with fewer bytes.
+	require.True(t, codeContainsSelector([]byte{byte(vm.PUSH3), 2, 3, 4}, []byte{0, 2, 3, 4}))
+	require.False(t, codeContainsSelector([]byte{byte(vm.PUSH4), 1, 2, 3, 4}, []byte{0, 0, 0, 0}))
+}
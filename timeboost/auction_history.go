@@ -0,0 +1,110 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// RoundOutcome is the structured outcome of an auction round, as opposed to
+// the raw bids received for it.
+type RoundOutcome struct {
+	Round  uint64      `json:"round"`
+	Status RoundStatus `json:"status"`
+	// Winner is the express lane controller named by the winning bid.
+	Winner       common.Address `json:"winner"`
+	WinnerBidder common.Address `json:"winnerBidder"`
+	FirstPrice   *hexutil.Big   `json:"firstPrice"`
+	// SecondPrice is nil for rounds resolved with a single bid.
+	SecondPrice *hexutil.Big `json:"secondPrice,omitempty"`
+	NumBids     uint64       `json:"numBids"`
+	TxHash      common.Hash  `json:"txHash"`
+	Time        time.Time    `json:"time"`
+	// ClearingPrice is the price the winner pays, see ClearingPrice.
+	// It is nil if unknown.
+	ClearingPrice *hexutil.Big `json:"clearingPrice,omitempty"`
+	// BaseFee and EffectiveGasPrice are the parent chain fees a resolution was
+	// sent and mined at, see ResolutionResult. They are nil if unknown.
+	BaseFee           *hexutil.Big `json:"baseFee,omitempty"`
+	EffectiveGasPrice *hexutil.Big `json:"effectiveGasPrice,omitempty"`
+	// Revenue is what the auction earned from the round, see
+	// CommittedValue, and zero unless it was resolved. It is nil if
+	// unknown.
+	Revenue *hexutil.Big `json:"revenue,omitempty"`
+}
+
+// AuctionHistoryStore is an append-only store of round outcomes.
+type AuctionHistoryStore interface {
+	AppendRoundOutcome(outcome *RoundOutcome) error
+	// HistorySince returns the outcomes of every round from the given round
+	// on, in the order they were appended.
+	HistorySince(round uint64) ([]*RoundOutcome, error)
+}
+
+// WithAuctionHistory records round outcomes to the given store instead of the
+// auctioneer's database.
+func WithAuctionHistory(store AuctionHistoryStore) AuctioneerServerOpt {
+	return func(a *AuctioneerServer) {
+		a.history = store
+	}
+}
+
+var errNoAuctionHistory = errors.New("auction history is not enabled")
+
+// HistorySince returns the outcomes of the rounds from the given round on.
+func (a *AuctioneerServer) HistorySince(round uint64) ([]*RoundOutcome, error) {
+	if a.history == nil {
+		return nil, errNoAuctionHistory
+	}
+	return a.history.HistorySince(round)
+}
+
+// recordRoundOutcome appends the outcome of a resolution attempt to the
+// auction history, and as the status of the round. Failing to append to the
+// history doesn't affect the resolution.
+func (a *AuctioneerServer) recordRoundOutcome(round uint64, status RoundStatus, result *auctionResult, numBids uint64, txHash common.Hash, fees *resolutionFees) {
+	a.setRoundStatus(round, status)
+	if a.history == nil {
+		return
+	}
+	outcome := &RoundOutcome{
+		Round:   round,
+		Status:  status,
+		NumBids: numBids,
+		TxHash:  txHash,
+		Time:    time.Now(),
+	}
+	if first := result.firstPlace; first != nil {
+		outcome.Winner = first.ExpressLaneController
+		outcome.WinnerBidder = first.Bidder
+		outcome.FirstPrice = (*hexutil.Big)(new(big.Int).Set(first.Amount))
+	}
+	if second := result.secondPlace; second != nil {
+		outcome.SecondPrice = (*hexutil.Big)(new(big.Int).Set(second.Amount))
+	}
+	if clearingPrice := a.clearingPrice(result); clearingPrice != nil {
+		outcome.ClearingPrice = (*hexutil.Big)(clearingPrice)
+	}
+	outcome.BaseFee, outcome.EffectiveGasPrice = fees.hexFees()
+	if revenue := a.roundRevenue(status, result); revenue != nil {
+		outcome.Revenue = (*hexutil.Big)(revenue)
+	}
+	if err := a.history.AppendRoundOutcome(outcome); err != nil {
+		log.Error("Could not record round outcome in auction history", "round", round, "status", status, "err", err)
+	}
+}
+
+type AuctioneerHistoryAPI struct {
+	auctioneer *AuctioneerServer
+}
+
+func (api *AuctioneerHistoryAPI) HistorySince(round hexutil.Uint64) ([]*RoundOutcome, error) {
+	return api.auctioneer.HistorySince(uint64(round))
+}
@@ -0,0 +1,54 @@
+package timeboost
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestAuctionHistory(t *testing.T) {
+	t.Parallel()
+	db, err := NewDatabase(t.TempDir())
+	require.NoError(t, err)
+	a := &AuctioneerServer{}
+	_, err = a.HistorySince(0)
+	require.ErrorIs(t, err, errNoAuctionHistory)
+	WithAuctionHistory(db)(a)
+
+	bid := func(controller string, amount int64) *ValidatedBid {
+		return &ValidatedBid{
+			ExpressLaneController: common.HexToAddress(controller),
+			Bidder:                common.HexToAddress(controller),
+			Amount:                big.NewInt(amount),
+		}
+	}
+	a.recordRoundOutcome(1, RoundStatusResolved, &auctionResult{firstPlace: bid("0x1", 20), secondPlace: bid("0x2", 10)}, 3, common.Hash{1}, nil)
+	a.recordRoundOutcome(2, RoundStatusCancelled, &auctionResult{firstPlace: bid("0x2", 5)}, 1, common.Hash{2}, nil)
+	a.recordRoundOutcome(3, RoundStatusResolved, &auctionResult{firstPlace: bid("0x1", 7), reservePrice: big.NewInt(4)}, 1, common.Hash{3}, nil)
+
+	history, err := a.HistorySince(2)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, uint64(2), history[0].Round)
+	require.Equal(t, RoundStatusCancelled, history[0].Status)
+	require.Equal(t, common.HexToAddress("0x2"), history[0].Winner)
+	require.Equal(t, (*hexutil.Big)(big.NewInt(5)), history[0].FirstPrice)
+	require.Nil(t, history[0].SecondPrice)
+	// The reserve price it was resolved at is unknown.
+	require.Nil(t, history[0].ClearingPrice)
+	require.Equal(t, common.Hash{2}, history[0].TxHash)
+	require.Equal(t, uint64(3), history[1].Round)
+	require.Equal(t, (*hexutil.Big)(big.NewInt(4)), history[1].ClearingPrice)
+
+	history, err = a.HistorySince(0)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	require.Equal(t, (*hexutil.Big)(big.NewInt(10)), history[0].SecondPrice)
+	require.Equal(t, (*hexutil.Big)(big.NewInt(10)), history[0].ClearingPrice)
+	require.Equal(t, uint64(3), history[0].NumBids)
+	require.False(t, history[0].Time.IsZero())
+}
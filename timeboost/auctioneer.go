@@ -7,10 +7,14 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/crypto/sha3"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -19,9 +23,9 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
 
 	"github.com/offchainlabs/nitro/cmd/genericconf"
-	"github.com/offchainlabs/nitro/cmd/util"
 	"github.com/offchainlabs/nitro/pubsub"
 	"github.com/offchainlabs/nitro/solgen/go/express_lane_auctiongen"
 	"github.com/offchainlabs/nitro/util/redisutil"
@@ -40,10 +44,13 @@ const (
 var (
 	receivedBidsCounter  = metrics.NewRegisteredCounter("arb/auctioneer/bids/received", nil)
 	validatedBidsCounter = metrics.NewRegisteredCounter("arb/auctioneer/bids/validated", nil)
-	FirstBidValueGauge   = metrics.NewRegisteredGauge("arb/auctioneer/bids/firstbidvalue", nil)
-	SecondBidValueGauge  = metrics.NewRegisteredGauge("arb/auctioneer/bids/secondbidvalue", nil)
+	// The bid value gauges of auctioneers created without WithMetricsRegistry.
+	FirstBidValueGauge  = defaultAuctioneerMetrics.firstBidValue
+	SecondBidValueGauge = defaultAuctioneerMetrics.secondBidValue
 )
 
+var errResolutionGasTooHigh = errors.New("auction resolution gas exceeds maximum")
+
 func init() {
 	hash := sha3.NewLegacyKeccak256()
 	hash.Write([]byte("TIMEBOOST_BID"))
@@ -59,6 +66,7 @@ type AuctioneerServerConfig struct {
 	// Timeout on polling for existence of each redis stream.
 	StreamTimeout             time.Duration            `koanf:"stream-timeout"`
 	Wallet                    genericconf.WalletConfig `koanf:"wallet"`
+	ExternalSigner            ExternalSignerConfig     `koanf:"external-signer"`
 	SequencerEndpoint         string                   `koanf:"sequencer-endpoint"`
 	SequencerJWTPath          string                   `koanf:"sequencer-jwt-path"`
 	UseRedisCoordinator       bool                     `koanf:"use-redis-coordinator"`
@@ -67,6 +75,27 @@ type AuctioneerServerConfig struct {
 	DbDirectory               string                   `koanf:"db-directory"`
 	AuctionResolutionWaitTime time.Duration            `koanf:"auction-resolution-wait-time"`
 	S3Storage                 S3StorageServiceConfig   `koanf:"s3-storage"`
+	HealthcheckAddr           string                   `koanf:"healthcheck-addr"`
+	MinBidsToResolve          uint64                   `koanf:"min-bids-to-resolve"`
+	ResolutionTxType          string                   `koanf:"resolution-tx-type"`
+	ResolutionConfirmations   uint64                   `koanf:"resolution-confirmations"`
+	TimingRefreshInterval     time.Duration            `koanf:"timing-refresh-interval"`
+	MinSignerBalanceGwei      uint64                   `koanf:"min-signer-balance-gwei"`
+	MaxCachedBids             int                      `koanf:"max-cached-bids"`
+	MaxResolutionGas          uint64                   `koanf:"max-resolution-gas"`
+	SequencerBlockTime        time.Duration            `koanf:"sequencer-block-time"`
+	FailureAlertThreshold     uint64                   `koanf:"failure-alert-threshold"`
+	Preflight                 bool                     `koanf:"preflight"`
+	MinedTimeout              time.Duration            `koanf:"mined-timeout"`
+	MinedTimeoutAction        string                   `koanf:"mined-timeout-action"`
+	BidderBlacklistThreshold  uint64                   `koanf:"bidder-blacklist-threshold"`
+	BidderBlacklistDuration   time.Duration            `koanf:"bidder-blacklist-duration"`
+	BidsDrainTimeout          time.Duration            `koanf:"bids-drain-timeout"`
+	SkipAuctioneerRoleCheck   bool                     `koanf:"skip-auctioneer-role-check"`
+	PollJitter                float64                  `koanf:"poll-jitter"`
+	MaxHeadLag                time.Duration            `koanf:"max-head-lag"`
+	StaleHeadRejects          string                   `koanf:"stale-head-rejects"`
+	MaxClockDrift             time.Duration            `koanf:"max-clock-drift"`
 }
 
 var DefaultAuctioneerServerConfig = AuctioneerServerConfig{
@@ -76,6 +105,20 @@ var DefaultAuctioneerServerConfig = AuctioneerServerConfig{
 	StreamTimeout:             10 * time.Minute,
 	AuctionResolutionWaitTime: 2 * time.Second,
 	S3Storage:                 DefaultS3StorageServiceConfig,
+	MinBidsToResolve:          1,
+	ResolutionTxType:          ResolutionTxTypeAuto,
+	ResolutionConfirmations:   1,
+	TimingRefreshInterval:     time.Minute,
+	MaxCachedBids:             10_000,
+	MaxResolutionGas:          10_000_000,
+	SequencerBlockTime:        250 * time.Millisecond,
+	FailureAlertThreshold:     3,
+	MinedTimeout:              5 * time.Second,
+	MinedTimeoutAction:        MinedTimeoutReprice,
+	BidderBlacklistDuration:   time.Hour,
+	BidsDrainTimeout:          500 * time.Millisecond,
+	PollJitter:                DefaultPollJitter,
+	StaleHeadRejects:          StaleHeadRejectAll,
 }
 
 var TestAuctioneerServerConfig = AuctioneerServerConfig{
@@ -84,6 +127,20 @@ var TestAuctioneerServerConfig = AuctioneerServerConfig{
 	ConsumerConfig:            pubsub.TestConsumerConfig,
 	StreamTimeout:             time.Minute,
 	AuctionResolutionWaitTime: 2 * time.Second,
+	MinBidsToResolve:          1,
+	ResolutionTxType:          ResolutionTxTypeAuto,
+	ResolutionConfirmations:   1,
+	TimingRefreshInterval:     time.Minute,
+	MaxCachedBids:             10_000,
+	MaxResolutionGas:          10_000_000,
+	SequencerBlockTime:        250 * time.Millisecond,
+	FailureAlertThreshold:     3,
+	MinedTimeout:              5 * time.Second,
+	MinedTimeoutAction:        MinedTimeoutReprice,
+	BidderBlacklistDuration:   time.Hour,
+	BidsDrainTimeout:          500 * time.Millisecond,
+	PollJitter:                DefaultPollJitter,
+	StaleHeadRejects:          StaleHeadRejectAll,
 }
 
 func AuctioneerServerConfigAddOptions(prefix string, f *pflag.FlagSet) {
@@ -92,14 +149,113 @@ func AuctioneerServerConfigAddOptions(prefix string, f *pflag.FlagSet) {
 	pubsub.ConsumerConfigAddOptions(prefix+".consumer-config", f)
 	f.Duration(prefix+".stream-timeout", DefaultAuctioneerServerConfig.StreamTimeout, "Timeout on polling for existence of redis streams")
 	genericconf.WalletConfigAddOptions(prefix+".wallet", f, "wallet for auctioneer server")
+	ExternalSignerConfigAddOptions(prefix+".external-signer", f)
 	f.String(prefix+".sequencer-endpoint", DefaultAuctioneerServerConfig.SequencerEndpoint, "sequencer RPC endpoint")
 	f.String(prefix+".sequencer-jwt-path", DefaultAuctioneerServerConfig.SequencerJWTPath, "sequencer jwt file path")
 	f.Bool(prefix+".use-redis-coordinator", DefaultAuctioneerServerConfig.UseRedisCoordinator, "use redis coordinator to find active sequencer")
 	f.String(prefix+".redis-coordinator-url", DefaultAuctioneerServerConfig.RedisCoordinatorURL, "redis coordinator url for finding active sequencer")
 	f.String(prefix+".auction-contract-address", DefaultAuctioneerServerConfig.AuctionContractAddress, "express lane auction contract address")
 	f.String(prefix+".db-directory", DefaultAuctioneerServerConfig.DbDirectory, "path to database directory for persisting validated bids in a sqlite file")
-	f.Duration(prefix+".auction-resolution-wait-time", DefaultAuctioneerServerConfig.AuctionResolutionWaitTime, "wait time after auction closing before resolving the auction")
+	f.Duration(prefix+".auction-resolution-wait-time", DefaultAuctioneerServerConfig.AuctionResolutionWaitTime, "wait time after auction closing before resolving the auction, so that bids validated right before the close are included; the resolution transaction must be mined in the rest of the auction closing period")
 	S3StorageServiceConfigAddOptions(prefix+".s3-storage", f)
+	f.String(prefix+".healthcheck-addr", DefaultAuctioneerServerConfig.HealthcheckAddr, "if non-empty, launch an HTTP service binding to this address that serves /healthz and /readyz probes")
+	f.Uint64(prefix+".min-bids-to-resolve", DefaultAuctioneerServerConfig.MinBidsToResolve, "minimum number of valid bids required to resolve a round, rounds with fewer bids are not resolved")
+	f.String(prefix+".resolution-tx-type", DefaultAuctioneerServerConfig.ResolutionTxType, "type of auction resolution transactions, one of auto, legacy or dynamic-fee; auto uses dynamic fee transactions if the chain has a base fee")
+	f.Duration(prefix+".timing-refresh-interval", DefaultAuctioneerServerConfig.TimingRefreshInterval, "how often to re-read the round timing of the auction contract, changes take effect at the next round boundary; 0 disables refreshing")
+	f.Uint64(prefix+".min-signer-balance-gwei", DefaultAuctioneerServerConfig.MinSignerBalanceGwei, "if non-zero, the readiness check fails while the balance of the resolution transaction signer is below this amount in gwei")
+	f.Int(prefix+".max-cached-bids", DefaultAuctioneerServerConfig.MaxCachedBids, "maximum number of bids, one per express lane controller, held for a round; once reached only bids outbidding the lowest cached bid are kept (0 = unbounded)")
+	f.Uint64(prefix+".max-resolution-gas", DefaultAuctioneerServerConfig.MaxResolutionGas, "resolutions whose transaction would use more gas than this are aborted instead of sent (0 = unbounded)")
+	f.Duration(prefix+".sequencer-block-time", DefaultAuctioneerServerConfig.SequencerBlockTime, "time between blocks produced by the sequencer, used to estimate the blocks a round covers; should match the max block speed of the sequencer")
+	f.Uint64(prefix+".failure-alert-threshold", DefaultAuctioneerServerConfig.FailureAlertThreshold, "number of rounds in a row whose resolution failed after which a critical alert is logged and reported (0 = never)")
+	f.Duration(prefix+".mined-timeout", DefaultAuctioneerServerConfig.MinedTimeout, "how long to wait for a resolution transaction to be mined before acting on it as stuck (0 = wait until the round starts)")
+	f.String(prefix+".mined-timeout-action", DefaultAuctioneerServerConfig.MinedTimeoutAction, "what to do with a resolution transaction not mined in time, reprice to replace it with one of doubled fees while its round hasn't started, or abort to give up on the round")
+	f.Uint64(prefix+".bidder-blacklist-threshold", DefaultAuctioneerServerConfig.BidderBlacklistThreshold, "number of rounds whose resolution reverted with a bidder's winning bid after which its bids are dropped for the blacklist duration (0 = never)")
+	f.Duration(prefix+".bidder-blacklist-duration", DefaultAuctioneerServerConfig.BidderBlacklistDuration, "how long bidders are blacklisted for once they reach the bidder blacklist threshold")
+	f.Duration(prefix+".bids-drain-timeout", DefaultAuctioneerServerConfig.BidsDrainTimeout, "how long resolving a round may spend caching bids received from the bid validators but not yet processed, bids still queued after it are left out of the round (0 = leave them all out)")
+	f.Float64(prefix+".poll-jitter", DefaultAuctioneerServerConfig.PollJitter, "randomize the intervals of the round timing refresh and sequencer health check by up to this fraction either way, to spread the RPC load of several auctioneers (0 = disabled, at most 0.5)")
+	f.Duration(prefix+".max-head-lag", DefaultAuctioneerServerConfig.MaxHeadLag, "if non-zero, the auctioneer is degraded while the latest block of the chain is older than this, e.g. while the node it reads is syncing: its readiness check fails and it refuses what stale-head-rejects says until the chain catches up")
+	f.String(prefix+".stale-head-rejects", DefaultAuctioneerServerConfig.StaleHeadRejects, "what the auctioneer refuses while the chain head is stale, one of resolutions, bids or all")
+	f.Duration(prefix+".max-clock-drift", DefaultAuctioneerServerConfig.MaxClockDrift, "if non-zero, the auctioneer enters a safe mode while its local clock is further than this behind the latest block timestamp: it stops resolving rounds and its readiness check fails until the drift is corrected; a local clock ahead of the chain looks like a stale head and is covered by max-head-lag")
+	f.Bool(prefix+".skip-auctioneer-role-check", DefaultAuctioneerServerConfig.SkipAuctioneerRoleCheck, "skip checking at startup and on auction contract migrations that the resolution signers are granted the auctioneer role on the auction contract")
+	f.Bool(prefix+".preflight", DefaultAuctioneerServerConfig.Preflight, "check the auction contract, round timing, signer funds and reserve price once before starting, and refuse to start if any check fails")
+	f.Uint64(prefix+".resolution-confirmations", DefaultAuctioneerServerConfig.ResolutionConfirmations, "number of blocks, including its own, a resolution transaction must be buried under before the round is considered resolved; resolution transactions reorged out before that are resubmitted")
+}
+
+// GasPricingStrategy fills in the gas fee fields of the transaction options used
+// to resolve an auction. It is invoked once per resolution with the current base
+// fee of the chain and the time left until the round being resolved starts,
+// which lets operators bid more aggressively as the deadline approaches.
+type GasPricingStrategy func(opts *bind.TransactOpts, baseFee *big.Int, timeTilRound time.Duration) error
+
+const (
+	ResolutionTxTypeAuto       = "auto"
+	ResolutionTxTypeLegacy     = "legacy"
+	ResolutionTxTypeDynamicFee = "dynamic-fee"
+)
+
+// applyResolutionTxType makes sure the transaction options produce a resolution
+// transaction of a type the chain supports. Chains without a base fee do not
+// support EIP-1559, so in auto mode dynamic fee caps are dropped for them, and
+// a legacy gas price is used instead.
+func applyResolutionTxType(ctx context.Context, opts *bind.TransactOpts, txType string, header *types.Header, client AuctioneerClient) error {
+	legacy := false
+	switch txType {
+	case ResolutionTxTypeLegacy:
+		legacy = true
+	case ResolutionTxTypeDynamicFee:
+		if header.BaseFee == nil {
+			return errors.New("dynamic fee resolution transactions requested, but chain has no base fee")
+		}
+		if opts.GasPrice != nil {
+			return errors.New("dynamic fee resolution transactions requested, but a legacy gas price is set")
+		}
+	case ResolutionTxTypeAuto, "":
+		legacy = header.BaseFee == nil
+	default:
+		return fmt.Errorf("invalid resolution tx type %q", txType)
+	}
+	if !legacy {
+		return nil
+	}
+	// The bindings build a legacy transaction whenever a gas price is set.
+	opts.GasFeeCap = nil
+	opts.GasTipCap = nil
+	if opts.GasPrice == nil {
+		gasPrice, err := client.SuggestGasPrice(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to suggest gas price for legacy resolution transaction: %w", err)
+		}
+		opts.GasPrice = gasPrice
+	}
+	return nil
+}
+
+// AuctioneerServerOpt configures optional behavior of an AuctioneerServer
+// that cannot be expressed through its config.
+type AuctioneerServerOpt func(*AuctioneerServer)
+
+// WithGasPricingStrategy sets the strategy used to price auction resolution
+// transactions. By default, the transaction options of the wallet are used as-is.
+func WithGasPricingStrategy(strategy GasPricingStrategy) AuctioneerServerOpt {
+	return func(a *AuctioneerServer) {
+		a.gasPricingStrategy = strategy
+	}
+}
+
+// AuctioneerClient is everything the auctioneer needs from the sequencer it
+// resolves auctions on. Keeping this surface small allows auction resolution
+// to be exercised against a fake client in tests.
+type AuctioneerClient interface {
+	// ContractBackend backs the auction contract bindings. Resolution
+	// transactions are built through it, but never sent through it.
+	bind.ContractBackend
+	// BalanceAt is used to check the signer can pay for resolution transactions.
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	// TransactionReceipt is used to wait for resolution transactions to be mined.
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	// SubmitAuctionResolutionTransaction hands a signed resolution transaction
+	// to the sequencer, which gives it priority over the regular tx queue.
+	SubmitAuctionResolutionTransaction(ctx context.Context, tx *types.Transaction) error
 }
 
 // AuctioneerServer is a struct that represents an autonomous auctioneer.
@@ -113,6 +269,7 @@ type AuctioneerServer struct {
 	auctionContract                *express_lane_auctiongen.ExpressLaneAuction
 	auctionContractAddr            common.Address
 	auctionContractDomainSeparator [32]byte
+	auctionContractVersion         AuctionContractVersion
 	bidsReceiver                   chan *JsonValidatedBid
 	bidCache                       *bidCache
 	roundTimingInfo                RoundTimingInfo
@@ -120,11 +277,87 @@ type AuctioneerServer struct {
 	auctionResolutionWaitTime      time.Duration
 	database                       *SqliteDatabase
 	s3StorageService               *S3StorageService
+	gasPricingStrategy             GasPricingStrategy
+	resolutionListeners            []*resolutionListener
+	resultPublisher                *resultPublisher
+	auditor                        *bidAuditor
+	bidRecorderPath                string
+	bidRecorder                    *bidRecorder
+	roundReceiptDir                string
+	healthcheckAddr                string
+	lastResolutionTime             atomic.Int64
+	resolutionLock                 sync.Mutex
+	minBidsToResolve               uint64
+	resolutionTxType               string
+	resolutionConfirmations        uint64
+	inFlightLock                   sync.Mutex
+	inFlight                       *inFlightResolution
+	sequencerChanged               atomic.Bool
+	auctionContractLock            sync.RWMutex
+	pendingContractSwap            *auctionContractSwap
+	roundTimingLock                sync.RWMutex
+	pendingRoundTiming             *RoundTimingInfo
+	roundTimingRefreshInterval     time.Duration
+	signers                        []*bind.TransactOpts
+	signerBalancesLock             sync.Mutex
+	signerBalances                 map[common.Address]*big.Int
+	minSignerBalance               *big.Int
+	history                        AuctionHistoryStore
+	sequencerHealth                SequencerHealthSource
+	roundStatusesLock              sync.RWMutex
+	roundStatuses                  map[uint64]RoundStatus
+	observerMode                   bool
+	resolutionSubmitter            ResolutionSubmitter
+	maxResolutionGas               uint64
+	paused                         atomic.Bool
+	metrics                        *auctioneerMetrics
+	tracer                         trace.Tracer
+	roundStates                    roundStateMachine
+	singleBidFallback              SingleBidFallback
+	revertPolicy                   ResolutionRevertPolicy
+	sequencerBlockTime             time.Duration
+	failureAlertThreshold          uint64
+	failureAlerter                 ResolutionFailureAlerter
+	consecutiveFailures            uint64
+	lastFailedRound                uint64
+	minedTimeout                   time.Duration
+	minedTimeoutAction             string
+	receiptPollInterval            time.Duration
+	bidBackpressure                BidBackpressure
+	bidQueueTimeout                time.Duration
+	bidderBlacklist                bidderBlacklist
+	bidsDrainTimeout               time.Duration
+	pollJitter                     float64
+	maxHeadLag                     time.Duration
+	staleHeadRejects               string
+	skipAuctioneerRoleCheck        bool
+	maxClockDrift                  time.Duration
+	resolutionAccessList           ResolutionAccessList
+	closingDurationForRound        ClosingDurationForRound
+	belowReserve                   belowReserveTally
+	resolutionSnapshot             resolutionSnapshot
+	// Latest head lag measured and whether it exceeded the max head lag.
+	headLag   atomic.Int64
+	headStale atomic.Bool
+	// Whether the latest head lag put the local clock further behind the chain
+	// than the max clock drift.
+	clockDriftExceeded atomic.Bool
 }
 
 // NewAuctioneerServer creates a new autonomous auctioneer struct.
-func NewAuctioneerServer(ctx context.Context, configFetcher AuctioneerServerConfigFetcher) (*AuctioneerServer, error) {
+func NewAuctioneerServer(ctx context.Context, configFetcher AuctioneerServerConfigFetcher, opts ...AuctioneerServerOpt) (*AuctioneerServer, error) {
+	if configFetcher == nil {
+		return nil, errors.New("auctioneer config fetcher is nil")
+	}
+	for i, opt := range opts {
+		if opt == nil {
+			return nil, fmt.Errorf("auctioneer option %d is nil", i)
+		}
+	}
 	cfg := configFetcher()
+	if cfg == nil {
+		return nil, errors.New("auctioneer config is nil")
+	}
 	if cfg.RedisURL == "" {
 		return nil, fmt.Errorf("redis url cannot be empty")
 	}
@@ -134,6 +367,27 @@ func NewAuctioneerServer(ctx context.Context, configFetcher AuctioneerServerConf
 	if cfg.DbDirectory == "" {
 		return nil, errors.New("database directory is empty")
 	}
+	switch cfg.ResolutionTxType {
+	case ResolutionTxTypeAuto, ResolutionTxTypeLegacy, ResolutionTxTypeDynamicFee, "":
+	default:
+		return nil, fmt.Errorf("invalid resolution tx type %q", cfg.ResolutionTxType)
+	}
+	if err := validateMinedTimeoutAction(cfg.MinedTimeoutAction); err != nil {
+		return nil, err
+	}
+	if err := validatePollJitter(cfg.PollJitter); err != nil {
+		return nil, err
+	}
+	if err := validateStaleHeadRejects(cfg.StaleHeadRejects); err != nil {
+		return nil, err
+	}
+	if err := validateMaxClockDrift(cfg.MaxClockDrift); err != nil {
+		return nil, err
+	}
+	staleHeadRejects := cfg.StaleHeadRejects
+	if staleHeadRejects == "" {
+		staleHeadRejects = StaleHeadRejectAll
+	}
 	database, err := NewDatabase(cfg.DbDirectory)
 	if err != nil {
 		return nil, err
@@ -170,17 +424,23 @@ func NewAuctioneerServer(ctx context.Context, configFetcher AuctioneerServerConf
 	if err != nil {
 		return nil, err
 	}
+	if rpcClient == nil {
+		return nil, errors.New("sequencer endpoint manager returned no rpc client")
+	}
 	sequencerClient := ethclient.NewClient(rpcClient)
 
 	chainId, err := sequencerClient.ChainID(ctx)
 	if err != nil {
 		return nil, err
 	}
-	txOpts, _, err := util.OpenWallet("auctioneer-server", &cfg.Wallet, chainId)
-	if err != nil {
-		return nil, errors.Wrap(err, "opening wallet")
+	if err := validateChainId(chainId); err != nil {
+		return nil, err
 	}
 	auctionContract, err := express_lane_auctiongen.NewExpressLaneAuction(auctionContractAddr, sequencerClient)
+	if err != nil {
+		return nil, fmt.Errorf("binding auction contract %s: %w", auctionContractAddr.Hex(), err)
+	}
+	auctionContractVersion, err := detectAuctionContractVersion(ctx, sequencerClient, auctionContractAddr)
 	if err != nil {
 		return nil, err
 	}
@@ -201,8 +461,7 @@ func NewAuctioneerServer(ctx context.Context, configFetcher AuctioneerServerConf
 	if err = roundTimingInfo.ValidateResolutionWaitTime(cfg.AuctionResolutionWaitTime); err != nil {
 		return nil, err
 	}
-	return &AuctioneerServer{
-		txOpts:                         txOpts,
+	a := &AuctioneerServer{
 		endpointManager:                endpointManager,
 		chainId:                        chainId,
 		database:                       database,
@@ -211,11 +470,62 @@ func NewAuctioneerServer(ctx context.Context, configFetcher AuctioneerServerConf
 		auctionContract:                auctionContract,
 		auctionContractAddr:            auctionContractAddr,
 		auctionContractDomainSeparator: domainSeparator,
+		auctionContractVersion:         auctionContractVersion,
 		bidsReceiver:                   make(chan *JsonValidatedBid, 100_000), // TODO(Terence): Is 100k enough? Make this configurable?
 		bidCache:                       newBidCache(domainSeparator),
 		roundTimingInfo:                *roundTimingInfo,
 		auctionResolutionWaitTime:      cfg.AuctionResolutionWaitTime,
-	}, nil
+		healthcheckAddr:                cfg.HealthcheckAddr,
+		minBidsToResolve:               cfg.MinBidsToResolve,
+		resolutionTxType:               cfg.ResolutionTxType,
+		resolutionConfirmations:        cfg.ResolutionConfirmations,
+		roundTimingRefreshInterval:     cfg.TimingRefreshInterval,
+		history:                        database,
+		maxResolutionGas:               cfg.MaxResolutionGas,
+		sequencerBlockTime:             cfg.SequencerBlockTime,
+		failureAlertThreshold:          cfg.FailureAlertThreshold,
+		minedTimeout:                   cfg.MinedTimeout,
+		minedTimeoutAction:             cfg.MinedTimeoutAction,
+		bidderBlacklist:                newBidderBlacklist(cfg.BidderBlacklistThreshold, cfg.BidderBlacklistDuration),
+		bidsDrainTimeout:               cfg.BidsDrainTimeout,
+		pollJitter:                     cfg.PollJitter,
+		maxHeadLag:                     cfg.MaxHeadLag,
+		staleHeadRejects:               staleHeadRejects,
+		skipAuctioneerRoleCheck:        cfg.SkipAuctioneerRoleCheck,
+		maxClockDrift:                  cfg.MaxClockDrift,
+	}
+	a.bidCache.maxBids = cfg.MaxCachedBids
+	if cfg.MinSignerBalanceGwei > 0 {
+		a.minSignerBalance = new(big.Int).Mul(new(big.Int).SetUint64(cfg.MinSignerBalanceGwei), big.NewInt(params.GWei))
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	a.bidCache.metrics = a.getMetrics()
+	if err = a.validateOptions(cfg.DbDirectory); err != nil {
+		return nil, err
+	}
+	if !a.observerMode {
+		a.txOpts, err = openResolutionTransactOpts(cfg, chainId)
+		if err != nil {
+			return nil, err
+		}
+		if a.txOpts == nil || a.txOpts.Signer == nil {
+			return nil, errors.New("no resolution transact opts, either configure a wallet or an external signer or enable observer mode")
+		}
+		if !a.skipAuctioneerRoleCheck {
+			if err = checkAuctioneerRole(ctx, sequencerClient, auctionContract, auctionContractAddr, a.resolutionSignerAddresses()); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if a.bidRecorderPath != "" {
+		a.bidRecorder, err = newBidRecorder(a.bidRecorderPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
 }
 
 func (a *AuctioneerServer) Start(ctx_in context.Context) {
@@ -265,7 +575,10 @@ func (a *AuctioneerServer) Start(ctx_in context.Context) {
 			}
 			// Forward the message over a channel for processing elsewhere in
 			// another thread, so as to not block this consumption thread.
-			a.bidsReceiver <- req.Value
+			// Dropped bids are acked all the same, see WithBidBackpressure.
+			if !a.enqueueBid(ctx, req.Value) && ctx.Err() != nil {
+				return 0
+			}
 
 			// We received the message, then we ack with a nil error.
 			if err := a.consumer.SetResult(ctx, req.ID, nil); err != nil {
@@ -292,17 +605,45 @@ func (a *AuctioneerServer) Start(ctx_in context.Context) {
 		}
 	})
 
+	a.startResolutionListeners()
+	a.startResultPublisher()
+	a.startAuditSink()
+	// Give the first round a full grace period before readiness checks fail.
+	a.lastResolutionTime.Store(time.Now().UnixNano())
+	if a.healthcheckAddr != "" {
+		a.StopWaiter.LaunchThread(func(ctx context.Context) {
+			a.launchHealthcheckServer(ctx, a.healthcheckAddr)
+		})
+	}
+
+	// Sequencer health check thread, cancels in-flight resolutions on unhealthy sequencers.
+	a.StopWaiter.CallIteratively(a.checkSequencerHealth)
+
+	// Head lag thread, degrades the auctioneer while the chain head is stale,
+	// and keeps it in safe mode while its clock is behind the chain.
+	if a.maxHeadLag > 0 || a.maxClockDrift > 0 {
+		a.StopWaiter.CallIteratively(a.pollHeadLag)
+	}
+
+	// Round timing refresh thread.
+	if a.roundTimingRefreshInterval > 0 {
+		a.StopWaiter.CallIteratively(func(ctx context.Context) time.Duration {
+			a.refreshRoundTiming(ctx)
+			return jitter(a.roundTimingRefreshInterval, a.pollJitter)
+		})
+	}
+
 	// Bid receiver thread.
 	a.StopWaiter.LaunchThread(func(ctx context.Context) {
 		for {
 			select {
 			case bid := <-a.bidsReceiver:
-				log.Info("Consumed validated bid", "bidder", bid.Bidder, "amount", bid.Amount, "round", bid.Round)
-				a.bidCache.add(JsonValidatedBidToGo(bid))
-				// Persist the validated bid to the database as a non-blocking operation.
-				go a.persistValidatedBid(bid)
+				a.consumeValidatedBid(bid)
 			case <-ctx.Done():
 				log.Info("Context done while waiting redis streams to be ready, failed to start")
+				if a.bidRecorder != nil {
+					a.bidRecorder.close()
+				}
 				return
 			}
 		}
@@ -310,52 +651,266 @@ func (a *AuctioneerServer) Start(ctx_in context.Context) {
 
 	// Auction resolution thread.
 	a.StopWaiter.LaunchThread(func(ctx context.Context) {
-		ticker := newRoundTicker(a.roundTimingInfo)
-		go ticker.tickAtAuctionClose()
+		ticker := a.newAuctionCloseTicker()
+		a.StopWaiter.LaunchThread(ticker.tickAtAuctionClose)
+		defer func() { close(ticker.done) }()
 		for {
 			select {
 			case <-ctx.Done():
 				log.Error("Context closed, autonomous auctioneer shutting down")
 				return
 			case auctionClosingTime := <-ticker.c:
-				log.Info("New auction closing time reached", "closingTime", auctionClosingTime, "totalBids", a.bidCache.size())
+				bidsByRound := a.bidCache.sizeByRound()
+				totalBids, distinctBidders := a.bidCache.sizeAndDistinctBidders()
+				log.Info("New auction closing time reached", "closingTime", auctionClosingTime, "totalBids", totalBids, "distinctBidders", distinctBidders, "bidsByRound", bidsByRound)
+				upcomingRound := a.UpcomingRound()
+				for round, numBids := range bidsByRound {
+					if round != upcomingRound {
+						// Most likely validated for a round that was resolved before they arrived.
+						log.Warn("Bid cache holds bids for a round other than the one being resolved", "round", round, "bids", numBids, "upcomingRound", upcomingRound)
+					}
+				}
+				a.markRoundClosing(upcomingRound)
+				roundCtx, span := a.getTracer().Start(ctx, "timeboost.resolveRound", trace.WithAttributes(attribute.Int64("timeboost.round", int64(upcomingRound))))
 				time.Sleep(a.auctionResolutionWaitTime)
-				if err := a.resolveAuction(ctx); err != nil {
+				a.waitUntilResolvable(roundCtx, upcomingRound)
+				_, err := a.resolveRound(roundCtx)
+				if errors.Is(err, errRoundAlreadyResolved) {
+					log.Info("Auction round was already resolved manually", "round", upcomingRound)
+					err = nil
+				} else if errors.Is(err, errAuctioneerPaused) {
+					log.Info("Skipping auction resolution, auctioneer is paused", "round", upcomingRound, "bids", a.bidCache.size())
+					err = nil
+				} else if err != nil {
 					log.Error("Could not resolve auction for round", "error", err)
 				}
-				// Clear the bid cache.
-				a.bidCache = newBidCache(a.auctionContractDomainSeparator)
+				endSpan(span, err)
+				a.closeRound(upcomingRound)
+				a.applyPendingContractSwap()
+				if a.applyPendingRoundTiming() {
+					close(ticker.done)
+					ticker = a.newAuctionCloseTicker()
+					a.StopWaiter.LaunchThread(ticker.tickAtAuctionClose)
+				}
 			}
 		}
 	})
 }
 
+// StopAndWait stops the auctioneer, draining any consumed bids that were not
+// yet processed. Those bids have already been acknowledged on the redis stream,
+// so they are persisted to the database rather than dropped.
+func (a *AuctioneerServer) StopAndWait() {
+	a.StopWaiter.StopAndWait()
+	for drained := false; !drained; {
+		select {
+		case bid := <-a.bidsReceiver:
+			a.persistValidatedBid(bid)
+		default:
+			drained = true
+		}
+	}
+	if a.consumer != nil {
+		a.consumer.StopAndWait()
+	}
+	if a.s3StorageService != nil {
+		a.s3StorageService.StopAndWait()
+	}
+}
+
+// CurrentRound returns the round that is live as of now.
+func (a *AuctioneerServer) CurrentRound() uint64 {
+	roundTimingInfo := a.getRoundTimingInfo()
+	return roundTimingInfo.RoundNumber()
+}
+
+// UpcomingRound returns the round currently being auctioned.
+func (a *AuctioneerServer) UpcomingRound() uint64 {
+	return a.CurrentRound() + 1
+}
+
+// TimeUntilClose returns the time left until bidding on the upcoming round
+// closes, or zero if it already has.
+func (a *AuctioneerServer) TimeUntilClose() time.Duration {
+	roundTimingInfo := a.getRoundTimingInfo()
+	return max(0, roundTimingInfo.TimeTilNextRound()-roundTimingInfo.AuctionClosing)
+}
+
+// dropDuplicateBidder falls back to a single bid resolution with the higher
+// bid if both top bids were placed by the same bidder, which the contract may
+// reject. The bid cache should never let that happen, this is a safety net.
+// It reports whether the lower bid was dropped.
+func dropDuplicateBidder(round uint64, result *auctionResult) bool {
+	first, second := result.firstPlace, result.secondPlace
+	if first == nil || second == nil || first.Bidder != second.Bidder {
+		return false
+	}
+	log.Warn("Top two bids were placed by the same bidder, resolving with the higher bid only", "round", round, "bidder", first.Bidder, "firstAmount", first.Amount.String(), "secondAmount", second.Amount.String(), "firstCorrelationId", first.CorrelationId, "secondCorrelationId", second.CorrelationId)
+	result.secondPlace = nil
+	return true
+}
+
+// hasWorthlessBid reports whether either of the bids an auction would be
+// resolved with has a zero or negative amount.
+func hasWorthlessBid(result *auctionResult) bool {
+	for _, bid := range []*ValidatedBid{result.firstPlace, result.secondPlace} {
+		if bid != nil && (bid.Amount == nil || bid.Amount.Sign() <= 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// fundedTopTwoBids returns the top two bids in the cache whose bidders can
+// still pay for them. A bidder could have withdrawn its deposit since its bid
+// was validated, which would revert the resolution transaction, so the deposit
+// of each top bidder is rechecked right before resolving. Bidders that can no
+// longer cover their bid are evicted, promoting the next best bid in their place.
+func (a *AuctioneerServer) fundedTopTwoBids(ctx context.Context, round uint64, balanceCheckerFn func(opts *bind.CallOpts, account common.Address) (*big.Int, error)) *auctionResult {
+	checked := make(map[common.Address]bool)
+	for {
+		result := a.bidCache.topTwoBids()
+		evicted := false
+		for place, bid := range []*ValidatedBid{result.firstPlace, result.secondPlace} {
+			if bid == nil || checked[bid.Bidder] {
+				continue
+			}
+			balance, err := balanceCheckerFn(&bind.CallOpts{Context: ctx}, bid.Bidder)
+			if err != nil {
+				// The bid was funded when it was validated, keep it rather than
+				// dropping it on a transient error.
+				log.Warn("Could not recheck bidder deposit before resolution", "round", round, "bidder", bid.Bidder, "err", err)
+				checked[bid.Bidder] = true
+				continue
+			}
+			if balance.Cmp(bid.Amount) < 0 {
+				log.Info("Promoting next bid, bidder deposit no longer covers its bid", "round", round, "place", place+1, "bidder", bid.Bidder, "balance", balance.String(), "amount", bid.Amount.String(), "correlationId", bid.CorrelationId)
+				if a.auditor != nil {
+					a.auditor.reject(bid, AuditReasonUnfunded)
+				}
+				a.bidCache.remove(bid.Bidder)
+				evicted = true
+				break
+			}
+			checked[bid.Bidder] = true
+		}
+		if !evicted {
+			return result
+		}
+	}
+}
+
 // Resolves the auction by calling the smart contract with the top two bids.
-func (a *AuctioneerServer) resolveAuction(ctx context.Context) error {
-	upcomingRound := a.roundTimingInfo.RoundNumber() + 1
-	result := a.bidCache.topTwoBids()
+func (a *AuctioneerServer) resolveAuction(ctx context.Context) (*ResolutionResult, error) {
+	sequencerRpc, newRpc, err := a.endpointManager.GetSequencerRPC(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sequencer RPC: %w", err)
+	}
+	return a.resolveAuctionWithClient(ctx, newSequencerClient(sequencerRpc), newRpc || a.sequencerChanged.Swap(false))
+}
+
+// resolveAuctionWithClient resolves the upcoming round through the given client.
+// If newClient is set, the auction contract bindings are first recreated on top of it.
+// The result describes what became of the round, and is nil if resolving it failed.
+// A resolution reverting is handled according to the resolution revert policy.
+func (a *AuctioneerServer) resolveAuctionWithClient(ctx context.Context, client AuctioneerClient, newClient bool) (*ResolutionResult, error) {
+	for promoted := 0; ; promoted++ {
+		resolution, err := a.resolveAuctionAttempt(ctx, client, newClient)
+		var revert *resolutionRevertError
+		if !errors.As(err, &revert) || !a.handleResolutionRevert(revert, promoted) {
+			return resolution, err
+		}
+		// The next best bids are picked, and snapshotted, anew.
+		a.resolutionSnapshot.reset()
+		newClient = false
+	}
+}
+
+// resolveAuctionAttempt makes a single attempt at resolving the upcoming round
+// with its best bids, see resolveAuctionWithClient.
+func (a *AuctioneerServer) resolveAuctionAttempt(ctx context.Context, client AuctioneerClient, newClient bool) (*ResolutionResult, error) {
+	// Read once, as ResolveNow runs this concurrently with round timing
+	// refreshes and auction contract migrations.
+	roundTimingInfo := a.getRoundTimingInfo()
+	contract := a.getAuctionContractSnapshot()
+	upcomingRound := roundTimingInfo.RoundNumber() + 1
+	numBids := uint64(a.bidCache.size())
+	if numBids > 0 && numBids < a.minBidsToResolve {
+		log.Info("Not enough bids received to resolve auction, skipping round", "round", upcomingRound, "bids", numBids, "minBidsToResolve", a.minBidsToResolve)
+		a.setRoundStatus(upcomingRound, RoundStatusSkipped)
+		return newResolutionResult(upcomingRound, RoundStatusSkipped, nil), nil
+	}
+	if a.maxClockDrift > 0 || (a.maxHeadLag > 0 && a.staleHeadRejects != StaleHeadRejectBids) {
+		// Checked anew, as deposits are about to be read through this client,
+		// and resolving at the wrong time is worse than not resolving.
+		err := a.checkHeadLag(ctx, client)
+		if errors.Is(err, errStaleChainHead) && a.rejectsOnStaleHead(StaleHeadRejectResolutions) {
+			log.Warn("Not resolving auction against a stale chain head", "round", upcomingRound, "err", err)
+			return nil, err
+		}
+		if err := a.clockDriftError(); err != nil {
+			log.Error("Not resolving auction in safe mode", "round", upcomingRound, "err", err)
+			return nil, err
+		}
+	}
+	if newClient {
+		if err := a.rebindAuctionContract(&contract, client); err != nil {
+			return nil, fmt.Errorf("failed to recreate ExpressLaneAuction conctract bindings with new sequencer endpoint: %w", err)
+		}
+	}
+	result := a.resolutionBids(ctx, upcomingRound)
+	if hasWorthlessBid(result) {
+		// Bid validation never lets such a bid through, this is a safety net
+		// against giving away the express lane for nothing.
+		log.Error("Not resolving auction with a bid of no value", "round", upcomingRound)
+		a.setRoundStatus(upcomingRound, RoundStatusNoBids)
+		return newResolutionResult(upcomingRound, RoundStatusNoBids, nil), nil
+	}
+	if a.skipCollapsedRound(result, numBids) {
+		log.Info("Only one eligible bid left out of several, skipping round", "round", upcomingRound, "bids", numBids, "singleBidFallback", a.singleBidFallback)
+		a.setRoundStatus(upcomingRound, RoundStatusSkipped)
+		return newResolutionResult(upcomingRound, RoundStatusSkipped, nil), nil
+	}
+	a.readReservePrice(ctx, upcomingRound, result)
+	if a.observerMode {
+		if !a.observeResolution(upcomingRound, result, numBids) {
+			return newResolutionResult(upcomingRound, RoundStatusNoBids, nil), nil
+		}
+		observed := newResolutionResult(upcomingRound, RoundStatusObserved, result)
+		observed.ClearingPrice = a.clearingPrice(result)
+		return observed, nil
+	}
 	first := result.firstPlace
 	second := result.secondPlace
+
 	var tx *types.Transaction
 	var err error
-	opts := copyTxOpts(a.txOpts)
+	signer := a.resolutionSigner(upcomingRound)
+	opts := copyTxOpts(signer)
 	opts.NoSend = true
+	// Calls made building the transaction are aborted with the resolution.
+	opts.Context = ctx
 
-	sequencerRpc, newRpc, err := a.endpointManager.GetSequencerRPC(ctx)
+	header, err := client.HeaderByNumber(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("failed to get sequencer RPC: %w", err)
+		return nil, fmt.Errorf("failed to get latest header for gas pricing: %w", err)
 	}
-
-	if newRpc {
-		a.auctionContract, err = express_lane_auctiongen.NewExpressLaneAuction(a.auctionContractAddr, ethclient.NewClient(sequencerRpc))
-		if err != nil {
-			return fmt.Errorf("failed to recreate ExpressLaneAuction conctract bindings with new sequencer endpoint: %w", err)
+	if a.gasPricingStrategy != nil {
+		if err := a.gasPricingStrategy(opts, header.BaseFee, roundTimingInfo.TimeTilNextRound()); err != nil {
+			return nil, fmt.Errorf("gas pricing strategy failed: %w", err)
 		}
 	}
+	if err := applyResolutionTxType(ctx, opts, a.resolutionTxType, header, client); err != nil {
+		return nil, err
+	}
 
 	switch {
 	case first != nil && second != nil: // Both bids are present
-		tx, err = a.auctionContract.ResolveMultiBidAuction(
+		if err := checkMultiBidOrder(first, second, contract.domainSeparator); err != nil {
+			log.Error("Not resolving auction with bids in wrong order", "round", upcomingRound, "error", err)
+			return nil, err
+		}
+		tx, err = contract.binding.ResolveMultiBidAuction(
 			opts,
 			express_lane_auctiongen.Bid{
 				ExpressLaneController: first.ExpressLaneController,
@@ -368,12 +923,12 @@ func (a *AuctioneerServer) resolveAuction(ctx context.Context) error {
 				Signature:             second.Signature,
 			},
 		)
-		FirstBidValueGauge.Update(first.Amount.Int64())
-		SecondBidValueGauge.Update(second.Amount.Int64())
-		log.Info("Resolving auction with two bids", "round", upcomingRound)
+		a.getMetrics().firstBidValue.Update(first.Amount.Int64())
+		a.getMetrics().secondBidValue.Update(second.Amount.Int64())
+		log.Info("Resolving auction with two bids", "round", upcomingRound, "firstCorrelationId", first.CorrelationId, "secondCorrelationId", second.CorrelationId)
 
 	case first != nil: // Single bid is present
-		tx, err = a.auctionContract.ResolveSingleBidAuction(
+		tx, err = contract.binding.ResolveSingleBidAuction(
 			opts,
 			express_lane_auctiongen.Bid{
 				ExpressLaneController: first.ExpressLaneController,
@@ -381,33 +936,136 @@ func (a *AuctioneerServer) resolveAuction(ctx context.Context) error {
 				Signature:             first.Signature,
 			},
 		)
-		FirstBidValueGauge.Update(first.Amount.Int64())
-		log.Info("Resolving auction with single bid", "round", upcomingRound)
+		a.getMetrics().firstBidValue.Update(first.Amount.Int64())
+		log.Info("Resolving auction with single bid", "round", upcomingRound, "correlationId", first.CorrelationId)
 
 	case second == nil: // No bids received
 		log.Info("No bids received for auction resolution", "round", upcomingRound)
-		return nil
+		if numBids == 0 {
+			a.warnAllBelowReserve(upcomingRound)
+		}
+		a.setRoundStatus(upcomingRound, RoundStatusNoBids)
+		return newResolutionResult(upcomingRound, RoundStatusNoBids, nil), nil
 	}
 	if err != nil {
+		if isRoundAlreadyResolvedError(err) {
+			return a.resolvedElsewhere(upcomingRound, err), nil
+		}
 		log.Error("Error resolving auction", "error", err)
-		return err
+		if isRevertError(err) {
+			a.attributeResolutionFailure(upcomingRound, result, err)
+			return nil, &resolutionRevertError{round: upcomingRound, winner: first.Bidder, err: err}
+		}
+		return nil, err
+	}
+	tx, err = a.attachResolutionAccessList(signer, upcomingRound, first, second, tx)
+	if err != nil {
+		return nil, err
+	}
+	if a.maxResolutionGas > 0 && tx.Gas() > a.maxResolutionGas {
+		// Protects the signer's funds from pathological gas estimates.
+		return nil, fmt.Errorf("%w: round %d, gas %d, max %d", errResolutionGasTooHigh, upcomingRound, tx.Gas(), a.maxResolutionGas)
+	}
+	if err := a.checkResolutionTx(upcomingRound, tx, first, second); err != nil {
+		return nil, err
+	}
+
+	if err := a.checkResolutionSimulation(ctx, client, upcomingRound, result, signer.From, tx); err != nil {
+		if errors.Is(err, errRoundResolvedOnChain) {
+			return a.resolvedElsewhere(upcomingRound, err), nil
+		}
+		return nil, err
+	}
+	if err := a.checkSignerFunds(ctx, client, signer.From, tx); err != nil {
+		return nil, err
 	}
 
-	roundEndTime := a.roundTimingInfo.TimeOfNextRound()
+	roundEndTime := roundTimingInfo.TimeOfNextRound()
 	retryInterval := 1 * time.Second
 
+	defer a.takeInFlightResolution()
+	var outOfFunds error
+	var tooLate bool
+	var confirmed *types.Receipt
+	var submittedAt, confirmedAt time.Time
+	var notMined error
+	var reverted error
+	// The resolution, followed by those repricing it.
+	submitted := []*types.Transaction{tx}
 	if err := retryUntil(ctx, func() error {
-		if err := sequencerRpc.CallContext(ctx, nil, "auctioneer_submitAuctionResolutionTransaction", tx); err != nil {
+		if a.resolutionCancelled() {
+			// Stop retrying, the resolution was replaced by a cancellation.
+			return nil
+		}
+		if a.tooLateToResolve(upcomingRound) {
+			// The contract only accepts resolutions before the round starts.
+			tooLate = true
+			return nil
+		}
+		if err := a.submitResolution(ctx, client, tx); err != nil {
+			if isInsufficientFundsError(err) {
+				// Retrying won't help until the signer is topped up.
+				outOfFunds = a.signerOutOfFunds(signer.From, fmt.Errorf("%w: %w", errSignerOutOfFunds, err))
+				return nil
+			}
 			log.Error("Error submitting auction resolution to sequencer endpoint", "error", err)
-			return err
+			if len(submitted) == 1 {
+				return err
+			}
+			// The transaction it replaces may have been mined in the meantime.
+		}
+		if submittedAt.IsZero() {
+			submittedAt = time.Now()
 		}
 
-		// Wait for the transaction to be mined
-		receipt, err := bind.WaitMined(ctx, ethclient.NewClient(sequencerRpc), tx)
+		// Wait for the transaction to be mined, unless it gets cancelled
+		// because the sequencer went unhealthy in the meantime.
+		waitCtx, stopWaiting := context.WithCancel(ctx)
+		defer stopWaiting()
+		a.setInFlightResolution(&inFlightResolution{
+			round:       upcomingRound,
+			tx:          tx,
+			signer:      signer,
+			client:      client,
+			stopWaiting: stopWaiting,
+		})
+		minedTx, receipt, err := a.waitMined(waitCtx, client, submitted)
+		if errors.Is(err, errResolutionNotMined) {
+			a.getMetrics().minedTimeouts.Inc(1)
+			if a.minedTimeoutAction == MinedTimeoutAbort {
+				notMined = err
+				return nil
+			}
+			log.Warn("Auction resolution not mined in time, repricing it", "round", upcomingRound, "error", err)
+			repriced, err := a.repriceResolution(signer, tx)
+			if err != nil {
+				notMined = fmt.Errorf("%w: %w", errResolutionNotMined, err)
+				return nil
+			}
+			tx = repriced
+			submitted = append(submitted, tx)
+			// Retried until the round starts, as any other failed attempt.
+			return errResolutionNotMined
+		}
 		if err != nil {
 			log.Error("Error waiting for transaction to be mined", "error", err)
 			return err
 		}
+		tx = minedTx
+		if receipt != nil && receipt.Status == types.ReceiptStatusSuccessful {
+			// Returning an error resubmits the transaction if it was reorged out.
+			receipt, err = a.waitForConfirmations(waitCtx, client, tx, receipt)
+			if err != nil {
+				log.Error("Error waiting for auction resolution confirmations", "txHash", tx.Hash().Hex(), "error", err)
+				return err
+			}
+		}
+
+		if receipt != nil && receipt.Status == types.ReceiptStatusFailed {
+			// Its nonce is used up, resubmitting it can only fail.
+			reverted = fmt.Errorf("%w: round %d, txHash %s", errResolutionReverted, upcomingRound, tx.Hash().Hex())
+			return nil
+		}
 
 		// Check if the transaction was successful
 		if tx == nil || receipt == nil || receipt.Status != types.ReceiptStatusSuccessful {
@@ -417,13 +1075,101 @@ func (a *AuctioneerServer) resolveAuction(ctx context.Context) error {
 			return errors.New("transaction failed or did not finalize successfully")
 		}
 
+		confirmed = receipt
+		confirmedAt = time.Now()
 		return nil
 	}, retryInterval, roundEndTime); err != nil {
-		return err
+		if submittedAt.IsZero() {
+			return nil, err
+		}
+		minedTx, receipt := a.minedResolution(client, upcomingRound, submitted, err)
+		if receipt == nil {
+			return nil, err
+		}
+		tx, confirmed, confirmedAt = minedTx, receipt, time.Now()
+	}
+	if outOfFunds != nil {
+		return nil, outOfFunds
+	}
+	if notMined != nil {
+		log.Error("Giving up on auction resolution not mined in time", "round", upcomingRound, "error", notMined)
+		return nil, notMined
+	}
+	if reverted != nil {
+		// Receipts carry no revert reason, which replaying the resolution gives.
+		if err := simulateResolution(ctx, client, signer.From, tx); isRoundAlreadyResolvedError(err) {
+			return a.resolvedElsewhere(upcomingRound, fmt.Errorf("%w: %w", reverted, err)), nil
+		}
+		log.Error("Auction resolution reverted", "round", upcomingRound, "error", reverted)
+		a.attributeResolutionFailure(upcomingRound, result, reverted)
+		return nil, &resolutionRevertError{round: upcomingRound, winner: first.Bidder, err: reverted}
+	}
+	if tooLate {
+		a.setRoundStatus(upcomingRound, RoundStatusSkipped)
+		return newResolutionResult(upcomingRound, RoundStatusSkipped, nil), nil
+	}
+	if a.resolutionCancelled() {
+		a.recordRoundOutcome(upcomingRound, RoundStatusCancelled, result, numBids, tx.Hash(), newResolutionFees(header, nil))
+		return nil, fmt.Errorf("%w: round %d, txHash %s", errResolutionCancelled, upcomingRound, tx.Hash().Hex())
 	}
 
-	log.Info("Auction resolved successfully", "txHash", tx.Hash().Hex())
-	return nil
+	resolvedLog := []any{"round", upcomingRound, "txHash", tx.Hash().Hex()}
+	if first.CorrelationId != "" {
+		// The winner can be tracked from its submission to here.
+		resolvedLog = append(resolvedLog, "winnerCorrelationId", first.CorrelationId)
+	}
+	log.Info("Auction resolved successfully", resolvedLog...)
+	a.bidderBlacklist.recordSuccess(first.Bidder)
+	a.recordResolutionLatency(upcomingRound, second != nil, submittedAt, confirmedAt, roundEndTime)
+	controlStart, controlEnd, _ := resolvedControlPeriod(contract, confirmed, upcomingRound)
+	fees := newResolutionFees(header, confirmed)
+	a.notifyResolutionListeners(AuctionResolution{
+		Round:        upcomingRound,
+		FirstPlace:   first,
+		SecondPlace:  second,
+		TxHash:       tx.Hash(),
+		ControlStart: controlStart,
+		ControlEnd:   controlEnd,
+	})
+	a.emitAuditRecord(upcomingRound, RoundStatusResolved, result, tx.Hash(), fees)
+	a.recordRoundOutcome(upcomingRound, RoundStatusResolved, result, numBids, tx.Hash(), fees)
+	resolution := newResolutionResult(upcomingRound, RoundStatusResolved, result)
+	resolution.TxHash = tx.Hash()
+	resolution.GasUsed = confirmed.GasUsed
+	if confirmed.BlockNumber != nil {
+		resolution.BlockNumber = confirmed.BlockNumber.Uint64()
+	}
+	resolution.ControlStart, resolution.ControlEnd = controlStart, controlEnd
+	resolution.ClearingPrice = a.clearingPrice(result)
+	resolution.BaseFee, resolution.EffectiveGasPrice = fees.baseFee, fees.effectiveGasPrice
+	a.writeRoundReceipt(resolution)
+	return resolution, nil
+}
+
+// closeRound clears the bid cache once the round was handled, whatever the
+// outcome of its resolution. Bids for the round that are still in flight are
+// dropped when they arrive, instead of leaking into the next round.
+func (a *AuctioneerServer) closeRound(round uint64) {
+	// Closed before clearing, so no late bid slips in between.
+	a.setRoundState(round, RoundStatusClosed)
+	a.bidCache.clear()
+	a.belowReserve.reset()
+	a.resolutionSnapshot.reset()
+	if a.auditor != nil {
+		a.auditor.reset()
+	}
+}
+
+// tooLateToResolve reports whether the given round has already started, in
+// which case resolving it would only waste gas on a reverted transaction.
+func (a *AuctioneerServer) tooLateToResolve(round uint64) bool {
+	currentRound := a.CurrentRound()
+	if currentRound < round {
+		return false
+	}
+	log.Warn(fmt.Sprintf("Too late to resolve round %d, skipping submission", round), "currentRound", currentRound)
+	a.getMetrics().tooLateResolutions.Inc(1)
+	return true
 }
 
 // retryUntil retries a given operation defined by the closure until the specified duration
@@ -449,9 +1195,93 @@ func retryUntil(ctx context.Context, operation func() error, retryInterval time.
 	return errors.New("operation failed after multiple attempts")
 }
 
+// receiveValidatedBid stamps a bid with its arrival time and caches it,
+// unless it is flagged as below the reserve price.
+// The timestamp is taken before the bid enters the cache, so it reflects
+// the order bids were received in.
+func (a *AuctioneerServer) receiveValidatedBid(bid *JsonValidatedBid) {
+	receivedAt := time.Now()
+	if a.bidRecorder != nil {
+		a.bidRecorder.record(bid, receivedAt)
+	}
+	a.receiveValidatedBidAt(bid, receivedAt)
+}
+
+// receiveValidatedBidAt caches a bid received at the given time, see
+// receiveValidatedBid. Replayed bids enter here with the time they were
+// recorded at.
+func (a *AuctioneerServer) receiveValidatedBidAt(bid *JsonValidatedBid, receivedAt time.Time) {
+	_, span := a.getTracer().Start(extractBidTrace(context.Background(), bid), "timeboost.cacheBid", bidSpanAttributes(uint64(bid.Round), bid.ExpressLaneController.Hex(), bid.CorrelationId))
+	defer span.End()
+	validated := JsonValidatedBidToGo(bid)
+	validated.ReceivedAt = receivedAt
+	if state := a.roundStates.stateOf(validated.Round); !state.acceptsBids() {
+		// Validated before the auction closed, but arrived once its round was being handled.
+		a.getMetrics().lateBids.Inc(1)
+		span.SetAttributes(attribute.String("timeboost.dropped", "late"))
+		log.Info("Not caching bid for a round no longer accepting bids", "bidder", validated.Bidder, "amount", validated.Amount.String(), "round", validated.Round, "correlationId", validated.CorrelationId, "state", state)
+		return
+	}
+	if a.auditor != nil {
+		a.auditor.receive(validated)
+	}
+	if !a.isForAuctionContract(validated) {
+		// Accepted by a bid validator not yet migrated to the current contract.
+		if a.auditor != nil {
+			a.auditor.reject(validated, AuditReasonWrongContract)
+		}
+		a.getMetrics().wrongContractBids.Inc(1)
+		span.SetAttributes(attribute.String("timeboost.dropped", "wrong-contract"))
+		log.Warn("Not caching bid signed for another auction contract", "bidder", validated.Bidder, "amount", validated.Amount.String(), "round", validated.Round, "correlationId", validated.CorrelationId, "auctionContract", validated.AuctionContractAddress)
+		return
+	}
+	if a.rejectsOnStaleHead(StaleHeadRejectBids) {
+		if a.auditor != nil {
+			a.auditor.reject(validated, AuditReasonStaleHead)
+		}
+		a.getMetrics().staleHeadBids.Inc(1)
+		span.SetAttributes(attribute.String("timeboost.dropped", "stale-head"))
+		log.Info("Not caching bid while the chain head is stale", "bidder", validated.Bidder, "amount", validated.Amount.String(), "round", validated.Round, "correlationId", validated.CorrelationId)
+		return
+	}
+	if a.bidderBlacklist.blocked(validated.Bidder, validated.ReceivedAt) {
+		if a.auditor != nil {
+			a.auditor.reject(validated, AuditReasonBlacklisted)
+		}
+		a.getMetrics().blacklistedBids.Inc(1)
+		span.SetAttributes(attribute.String("timeboost.dropped", "blacklisted"))
+		log.Info("Not caching bid of blacklisted bidder", "bidder", validated.Bidder, "amount", validated.Amount.String(), "round", validated.Round, "correlationId", validated.CorrelationId)
+		return
+	}
+	if validated.BelowReservePrice {
+		// The contract would reject a resolution using this bid.
+		a.belowReserve.add(validated)
+		if a.auditor != nil {
+			a.auditor.reject(validated, AuditReasonBelowReservePrice)
+		}
+		span.SetAttributes(attribute.String("timeboost.dropped", "below-reserve-price"))
+		log.Info("Not caching bid below reserve price", "bidder", validated.Bidder, "amount", validated.Amount.String(), "round", validated.Round, "correlationId", validated.CorrelationId)
+		return
+	}
+	added, evicted := a.bidCache.add(validated)
+	if evicted != nil {
+		log.Info("Evicted lowest bid from full bid cache", "bidder", evicted.Bidder, "amount", evicted.Amount.String(), "round", evicted.Round, "correlationId", evicted.CorrelationId)
+		if a.auditor != nil {
+			a.auditor.reject(evicted, AuditReasonCacheFull)
+		}
+	}
+	if !added {
+		span.SetAttributes(attribute.String("timeboost.dropped", "cache-full"))
+		log.Info("Not caching bid, bid cache is full of higher bids", "bidder", validated.Bidder, "amount", validated.Amount.String(), "round", validated.Round, "correlationId", validated.CorrelationId)
+		if a.auditor != nil {
+			a.auditor.reject(validated, AuditReasonCacheFull)
+		}
+	}
+}
+
 func (a *AuctioneerServer) persistValidatedBid(bid *JsonValidatedBid) {
 	if err := a.database.InsertBid(JsonValidatedBidToGo(bid)); err != nil {
-		log.Error("Could not persist validated bid to database", "err", err, "bidder", bid.Bidder, "amount", bid.Amount.String())
+		log.Error("Could not persist validated bid to database", "err", err, "bidder", bid.Bidder, "amount", bid.Amount.String(), "correlationId", bid.CorrelationId)
 	}
 }
 
@@ -0,0 +1,90 @@
+package timeboost
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// FuzzAuctionSelection feeds random sets of bids through the auctioneer's bid
+// intake and winner selection, and checks the chosen winners against a full
+// scan of the bids. Every four bytes of input make up one bid.
+func FuzzAuctionSelection(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{0, 0, 5, 1, 1, 1, 5, 1})
+	f.Add([]byte{0, 0, 9, 1, 0, 1, 8, 1, 1, 2, 7, 1, 2, 3, 7, 0})
+	f.Add([]byte{0, 0, 3, 1, 0, 0, 15, 0, 1, 1, 3, 1, 1, 1, 2, 1})
+	domainSeparator := [32]byte{0xa}
+	funded := func(*bind.CallOpts, common.Address) (*big.Int, error) {
+		return big.NewInt(math.MaxInt64), nil
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		a := &AuctioneerServer{bidCache: newBidCache(domainSeparator)}
+		latest := make(map[common.Address]*ValidatedBid)
+		for ; len(data) >= 4; data = data[4:] {
+			bid := &JsonValidatedBid{
+				// Few bidders spread over more controllers, so that bidders
+				// compete with themselves.
+				Bidder:                common.Address{data[0]%4 + 1},
+				ExpressLaneController: common.Address{0xc, data[1] % 8},
+				// A narrow range of amounts, to produce ties.
+				Amount:            (*hexutil.Big)(big.NewInt(int64(data[2]%16) + 1)),
+				ChainId:           (*hexutil.Big)(big.NewInt(1)),
+				Round:             1,
+				BelowReservePrice: data[3]%4 == 0,
+			}
+			a.receiveValidatedBid(bid)
+			if !bid.BelowReservePrice {
+				latest[bid.ExpressLaneController] = JsonValidatedBidToGo(bid)
+			}
+		}
+
+		result := a.fundedTopTwoBids(context.Background(), 1, funded)
+		dropDuplicateBidder(1, result)
+		first, second := result.firstPlace, result.secondPlace
+		if first == nil {
+			require.Empty(t, latest)
+			require.Nil(t, second)
+			return
+		}
+		require.False(t, first.BelowReservePrice)
+		if second != nil {
+			require.False(t, second.BelowReservePrice)
+			require.NotEqual(t, first.Bidder, second.Bidder)
+			require.GreaterOrEqual(t, first.Amount.Cmp(second.Amount), 0)
+		}
+
+		eligible := make([]*ValidatedBid, 0, len(latest))
+		for _, bid := range latest {
+			eligible = append(eligible, bid)
+		}
+		sort.Slice(eligible, func(i, j int) bool {
+			if c := eligible[i].Amount.Cmp(eligible[j].Amount); c != 0 {
+				return c > 0
+			}
+			return eligible[i].BigIntHash(domainSeparator).Cmp(eligible[j].BigIntHash(domainSeparator)) > 0
+		})
+		requireSameBid(t, eligible[0], first)
+		if len(eligible) > 1 && eligible[1].Bidder != eligible[0].Bidder {
+			requireSameBid(t, eligible[1], second)
+		} else {
+			require.Nil(t, second)
+		}
+	})
+}
+
+func requireSameBid(t *testing.T, expected, actual *ValidatedBid) {
+	t.Helper()
+	require.NotNil(t, actual)
+	require.Equal(t, expected.ExpressLaneController, actual.ExpressLaneController)
+	require.Equal(t, expected.Bidder, actual.Bidder)
+	require.Equal(t, 0, expected.Amount.Cmp(actual.Amount))
+}
@@ -0,0 +1,99 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// DefaultAuctioneerMetricsPrefix is the prefix of the auctioneer's metrics in
+// the global metrics registry.
+const DefaultAuctioneerMetricsPrefix = "arb/auctioneer/"
+
+// auctioneerMetrics are the metrics of a single auctioneer, registered under a
+// common prefix so that several auctioneers can share a process.
+type auctioneerMetrics struct {
+	firstBidValue       metrics.Gauge
+	secondBidValue      metrics.Gauge
+	tooLateResolutions  metrics.Counter
+	duplicateBidder     metrics.Counter
+	lateBids            metrics.Counter
+	cacheEvictedBids    metrics.Counter
+	cacheRejectedBids   metrics.Counter
+	observedResolutions metrics.Counter
+	roundMismatch       metrics.Counter
+	signerBalance       metrics.Gauge
+	signerOutOfFunds    metrics.Counter
+	consecutiveFailures metrics.Gauge
+	failureAlerts       metrics.Counter
+	minedTimeouts       metrics.Counter
+	blacklistedBidders  metrics.Counter
+	blacklistedBids     metrics.Counter
+	staleHeadBids       metrics.Counter
+	queueFullBids       metrics.Counter
+	wrongContractBids   metrics.Counter
+	allBelowReserve     metrics.Counter
+	highestBelowReserve metrics.Gauge
+	// Milliseconds the latest block is behind the local clock.
+	headLag metrics.Gauge
+	// Milliseconds from submitting a resolution to it being confirmed, and
+	// from then to the start of its round, by kind of resolution.
+	singleBidLatency metrics.Histogram
+	multiBidLatency  metrics.Histogram
+	singleBidMargin  metrics.Gauge
+	multiBidMargin   metrics.Gauge
+}
+
+var defaultAuctioneerMetrics = newAuctioneerMetrics(metrics.DefaultRegistry, DefaultAuctioneerMetricsPrefix)
+
+func newAuctioneerMetrics(registry metrics.Registry, prefix string) *auctioneerMetrics {
+	return &auctioneerMetrics{
+		firstBidValue:       metrics.NewRegisteredGauge(prefix+"bids/firstbidvalue", registry),
+		secondBidValue:      metrics.NewRegisteredGauge(prefix+"bids/secondbidvalue", registry),
+		tooLateResolutions:  metrics.NewRegisteredCounter(prefix+"resolution/toolate", registry),
+		duplicateBidder:     metrics.NewRegisteredCounter(prefix+"resolution/duplicatebidder", registry),
+		lateBids:            metrics.NewRegisteredCounter(prefix+"bids/late", registry),
+		cacheEvictedBids:    metrics.NewRegisteredCounter(prefix+"bids/cache/evicted", registry),
+		cacheRejectedBids:   metrics.NewRegisteredCounter(prefix+"bids/cache/rejected", registry),
+		observedResolutions: metrics.NewRegisteredCounter(prefix+"observer/resolutions", registry),
+		roundMismatch:       metrics.NewRegisteredCounter(prefix+"roundtiming/mismatch", registry),
+		signerBalance:       metrics.NewRegisteredGauge(prefix+"signer/balancegwei", registry),
+		signerOutOfFunds:    metrics.NewRegisteredCounter(prefix+"signer/outoffunds", registry),
+		consecutiveFailures: metrics.NewRegisteredGauge(prefix+"resolution/consecutivefailures", registry),
+		failureAlerts:       metrics.NewRegisteredCounter(prefix+"resolution/failurealerts", registry),
+		minedTimeouts:       metrics.NewRegisteredCounter(prefix+"resolution/minedtimeouts", registry),
+		blacklistedBidders:  metrics.NewRegisteredCounter(prefix+"bidders/blacklisted", registry),
+		blacklistedBids:     metrics.NewRegisteredCounter(prefix+"bids/blacklisted", registry),
+		staleHeadBids:       metrics.NewRegisteredCounter(prefix+"bids/stalehead", registry),
+		queueFullBids:       metrics.NewRegisteredCounter(prefix+"bids/queuefull", registry),
+		wrongContractBids:   metrics.NewRegisteredCounter(prefix+"bids/wrongcontract", registry),
+		headLag:             metrics.NewRegisteredGauge(prefix+"chain/headlag", registry),
+		allBelowReserve:     metrics.NewRegisteredCounter(prefix+"resolution/allbelowreserve", registry),
+		highestBelowReserve: metrics.NewRegisteredGauge(prefix+"bids/highestbelowreserve", registry),
+		singleBidLatency:    metrics.NewRegisteredHistogram(prefix+"resolution/latency/singlebid", registry, metrics.NewBoundedHistogramSample()),
+		multiBidLatency:     metrics.NewRegisteredHistogram(prefix+"resolution/latency/multibid", registry, metrics.NewBoundedHistogramSample()),
+		singleBidMargin:     metrics.NewRegisteredGauge(prefix+"resolution/margin/singlebid", registry),
+		multiBidMargin:      metrics.NewRegisteredGauge(prefix+"resolution/margin/multibid", registry),
+	}
+}
+
+// WithMetricsRegistry registers the auctioneer's metrics into the given
+// registry under the given prefix, e.g. "arb/auctioneer/<chain id>/", rather
+// than into the global registry under DefaultAuctioneerMetricsPrefix. This
+// allows running several auctioneers in one process. If registering into it
+// fails, the metrics are kept but not exported.
+func WithMetricsRegistry(registry metrics.Registry, prefix string) AuctioneerServerOpt {
+	return func(a *AuctioneerServer) {
+		a.metrics = newGuardedAuctioneerMetrics(registry, prefix)
+	}
+}
+
+// getMetrics returns the metrics of the auctioneer, which are the default ones
+// unless it was created with WithMetricsRegistry.
+func (a *AuctioneerServer) getMetrics() *auctioneerMetrics {
+	if a.metrics == nil {
+		return defaultAuctioneerMetrics
+	}
+	return a.metrics
+}
@@ -0,0 +1,27 @@
+package timeboost
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+func TestWithMetricsRegistry(t *testing.T) {
+	t.Parallel()
+	registry := metrics.NewRegistry()
+	first, second := &AuctioneerServer{}, &AuctioneerServer{}
+	WithMetricsRegistry(registry, "arb/auctioneer/1/")(first)
+	WithMetricsRegistry(registry, "arb/auctioneer/2/")(second)
+	require.NotSame(t, first.getMetrics(), second.getMetrics())
+	for _, name := range []string{"bids/firstbidvalue", "resolution/toolate", "bids/cache/evicted", "signer/outoffunds", "resolution/consecutivefailures", "resolution/latency/multibid"} {
+		require.NotNil(t, registry.Get("arb/auctioneer/1/"+name))
+		require.NotNil(t, registry.Get("arb/auctioneer/2/"+name))
+		require.Nil(t, metrics.DefaultRegistry.Get("arb/auctioneer/1/"+name))
+	}
+
+	// Auctioneers created without the option share the global metrics.
+	require.Same(t, defaultAuctioneerMetrics, (&AuctioneerServer{}).getMetrics())
+	require.NotNil(t, metrics.DefaultRegistry.Get(DefaultAuctioneerMetricsPrefix+"bids/firstbidvalue"))
+}
@@ -0,0 +1,55 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// validateOptions checks the combination of configuration and options the
+// auctioneer was created with, so that invalid combinations fail at startup
+// rather than confusingly at the first resolution.
+func (a *AuctioneerServer) validateOptions(dbDirectory string) error {
+	if a.bidCache.maxBids < 0 {
+		return fmt.Errorf("max cached bids %d must not be negative", a.bidCache.maxBids)
+	}
+	if a.bidCache.maxBids > 0 && uint64(a.bidCache.maxBids) < a.minBidsToResolve {
+		return fmt.Errorf("max cached bids %d is below min bids to resolve %d, no round could ever be resolved", a.bidCache.maxBids, a.minBidsToResolve)
+	}
+	if a.observerMode && len(a.signers) > 0 {
+		return fmt.Errorf("resolution signers are given, but the auctioneer never sends transactions in observer mode")
+	}
+	if a.resolutionAccessList != nil && a.resolutionTxType == ResolutionTxTypeLegacy {
+		return errAccessListOnLegacyTx
+	}
+	signers := make(map[common.Address]struct{}, len(a.signers))
+	for _, signer := range a.signers {
+		if signer == nil {
+			return fmt.Errorf("resolution signers must not be nil")
+		}
+		if _, duplicate := signers[signer.From]; duplicate {
+			return fmt.Errorf("resolution signer %s is given more than once, its resolutions would conflict on nonces", signer.From.Hex())
+		}
+		signers[signer.From] = struct{}{}
+	}
+	if a.bidRecorderPath != "" && dbDirectory != "" {
+		recorderPath, err := filepath.Abs(a.bidRecorderPath)
+		if err != nil {
+			return err
+		}
+		dbPath, err := filepath.Abs(filepath.Join(dbDirectory, strings.Split(sqliteFileName, "?")[0]))
+		if err != nil {
+			return err
+		}
+		// Also covers the write ahead log files of the database.
+		if strings.HasPrefix(recorderPath, dbPath) {
+			return fmt.Errorf("bid recorder path %s collides with the bid database %s", a.bidRecorderPath, dbPath)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package timeboost
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestValidateAuctioneerOptions(t *testing.T) {
+	t.Parallel()
+	dbDirectory := t.TempDir()
+	signer := func(from string) *bind.TransactOpts {
+		return &bind.TransactOpts{From: common.HexToAddress(from)}
+	}
+	tests := []struct {
+		name    string
+		opts    []AuctioneerServerOpt
+		prepare func(*AuctioneerServer)
+		wantErr string
+	}{
+		{
+			name: "valid",
+			opts: []AuctioneerServerOpt{
+				WithResolutionSigners(signer("0x1"), signer("0x2")),
+				WithBidRecorder(filepath.Join(dbDirectory, "bids.jsonl")),
+			},
+		},
+		{
+			name:    "duplicate resolution signer",
+			opts:    []AuctioneerServerOpt{WithResolutionSigners(signer("0x1"), signer("0x1"))},
+			wantErr: "more than once",
+		},
+		{
+			name:    "nil resolution signer",
+			opts:    []AuctioneerServerOpt{WithResolutionSigners(signer("0x1"), nil)},
+			wantErr: "must not be nil",
+		},
+		{
+			name:    "resolution signers in observer mode",
+			opts:    []AuctioneerServerOpt{WithObserverMode(true), WithResolutionSigners(signer("0x1"))},
+			wantErr: "observer mode",
+		},
+		{
+			name:    "bid recorder writes to the database",
+			opts:    []AuctioneerServerOpt{WithBidRecorder(filepath.Join(dbDirectory, "validated_bids.db"))},
+			wantErr: "collides",
+		},
+		{
+			name:    "bid recorder writes to the database log",
+			opts:    []AuctioneerServerOpt{WithBidRecorder(filepath.Join(dbDirectory, "validated_bids.db-wal"))},
+			wantErr: "collides",
+		},
+		{
+			name:    "cache too small to resolve",
+			prepare: func(a *AuctioneerServer) { a.bidCache.maxBids, a.minBidsToResolve = 2, 3 },
+			wantErr: "no round could ever be resolved",
+		},
+		{
+			name:    "access list on legacy resolutions",
+			opts:    []AuctioneerServerOpt{WithStaticResolutionAccessList(nil)},
+			prepare: func(a *AuctioneerServer) { a.resolutionTxType = ResolutionTxTypeLegacy },
+			wantErr: "legacy",
+		},
+		{
+			name:    "negative cache size",
+			prepare: func(a *AuctioneerServer) { a.bidCache.maxBids = -1 },
+			wantErr: "must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &AuctioneerServer{bidCache: newBidCache([32]byte{}), minBidsToResolve: 1}
+			for _, opt := range tt.opts {
+				opt(a)
+			}
+			if tt.prepare != nil {
+				tt.prepare(a)
+			}
+			err := a.validateOptions(dbDirectory)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewAuctioneerServerNilInputs(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	config := func() *AuctioneerServerConfig { return &TestAuctioneerServerConfig }
+	tests := []struct {
+		name          string
+		configFetcher AuctioneerServerConfigFetcher
+		opts          []AuctioneerServerOpt
+		wantErr       string
+	}{
+		{
+			name:    "nil config fetcher",
+			wantErr: "config fetcher is nil",
+		},
+		{
+			name:          "nil config",
+			configFetcher: func() *AuctioneerServerConfig { return nil },
+			wantErr:       "config is nil",
+		},
+		{
+			name:          "nil option",
+			configFetcher: config,
+			opts:          []AuctioneerServerOpt{WithObserverMode(true), nil},
+			wantErr:       "option 1 is nil",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NotPanics(t, func() {
+				_, err := NewAuctioneerServer(ctx, tt.configFetcher, tt.opts...)
+				require.ErrorContains(t, err, tt.wantErr)
+			})
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/offchainlabs/nitro/solgen/go/express_lane_auctiongen"
+)
+
+var errUnauthorizedAuctioneer = errors.New("resolution signer is not an authorized auctioneer of the auction contract")
+
+// The auction contract only accepts resolutions from accounts granted the
+// auctioneer role, through these access control methods.
+var auctioneerRoleMethods = []string{"AUCTIONEER_ROLE", "hasRole"}
+
+// auctioneerRoleReader reads the accounts granted the auctioneer role on the
+// auction contract.
+type auctioneerRoleReader interface {
+	AUCTIONEERROLE(opts *bind.CallOpts) ([32]byte, error)
+	HasRole(opts *bind.CallOpts, role [32]byte, account common.Address) (bool, error)
+}
+
+// checkAuctioneerRole checks that every resolution signer is granted the
+// auctioneer role on the auction contract, as otherwise every resolution it
+// sends reverts. Contracts not exposing the role are not checked.
+func checkAuctioneerRole(ctx context.Context, reader ContractCodeReader, roles auctioneerRoleReader, addr common.Address, signers []common.Address) error {
+	code, err := readAuctionContractCode(ctx, reader, addr)
+	if err != nil {
+		return err
+	}
+	contractAbi, err := express_lane_auctiongen.ExpressLaneAuctionMetaData.GetAbi()
+	if err != nil {
+		return err
+	}
+	for _, name := range auctioneerRoleMethods {
+		method, ok := contractAbi.Methods[name]
+		if !ok || !codeContainsSelector(code, method.ID) {
+			log.Warn("Auction contract does not expose its auctioneer role, not checking resolution signers are authorized", "address", addr, "missing", name)
+			return nil
+		}
+	}
+	callOpts := &bind.CallOpts{Context: ctx}
+	role, err := roles.AUCTIONEERROLE(callOpts)
+	if err != nil {
+		return fmt.Errorf("reading auctioneer role of auction contract %s: %w", addr, err)
+	}
+	for _, signer := range signers {
+		authorized, err := roles.HasRole(callOpts, role, signer)
+		if err != nil {
+			return fmt.Errorf("reading auctioneer role of %s on auction contract %s: %w", signer, addr, err)
+		}
+		if !authorized {
+			return fmt.Errorf("%w: %s lacks role %#x on %s, grant it or configure the authorized auctioneer's key", errUnauthorizedAuctioneer, signer, role, addr)
+		}
+	}
+	log.Info("Resolution signers are authorized auctioneers", "address", addr, "signers", signers)
+	return nil
+}
+
+// resolutionSignerAddresses returns the accounts resolutions are sent from.
+func (a *AuctioneerServer) resolutionSignerAddresses() []common.Address {
+	if len(a.signers) == 0 {
+		return []common.Address{a.txOpts.From}
+	}
+	addrs := make([]common.Address, 0, len(a.signers))
+	for _, signer := range a.signers {
+		addrs = append(addrs, signer.From)
+	}
+	return addrs
+}
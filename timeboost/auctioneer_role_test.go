@@ -0,0 +1,55 @@
+package timeboost
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type fakeAuctioneerRoles struct {
+	role        [32]byte
+	auctioneers map[common.Address]bool
+}
+
+func (r *fakeAuctioneerRoles) AUCTIONEERROLE(_ *bind.CallOpts) ([32]byte, error) {
+	return r.role, nil
+}
+
+func (r *fakeAuctioneerRoles) HasRole(_ *bind.CallOpts, role [32]byte, account common.Address) (bool, error) {
+	return role == r.role && r.auctioneers[account], nil
+}
+
+func TestCheckAuctioneerRole(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	addr := common.HexToAddress("0x1234")
+	authorized, other := common.Address{'a'}, common.Address{'b'}
+	roles := &fakeAuctioneerRoles{
+		role:        [32]byte{'r'},
+		auctioneers: map[common.Address]bool{authorized: true},
+	}
+	reader := &fakeContractCodeReader{code: map[common.Address][]byte{
+		addr: dispatcherCode(t, "resolveMultiBidAuction", "resolveSingleBidAuction", "AUCTIONEER_ROLE", "hasRole"),
+	}}
+
+	require.NoError(t, checkAuctioneerRole(ctx, reader, roles, addr, []common.Address{authorized}))
+	err := checkAuctioneerRole(ctx, reader, roles, addr, []common.Address{authorized, other})
+	require.ErrorIs(t, err, errUnauthorizedAuctioneer)
+	require.ErrorContains(t, err, other.Hex())
+
+	// Contracts not exposing the auctioneer role are not checked.
+	reader.code[addr] = dispatcherCode(t, "resolveMultiBidAuction", "resolveSingleBidAuction")
+	require.NoError(t, checkAuctioneerRole(ctx, reader, roles, addr, []common.Address{other}))
+}
+
+func TestResolutionSignerAddresses(t *testing.T) {
+	t.Parallel()
+	a := &AuctioneerServer{txOpts: &bind.TransactOpts{From: common.Address{'a'}}}
+	require.Equal(t, []common.Address{{'a'}}, a.resolutionSignerAddresses())
+	WithResolutionSigners(&bind.TransactOpts{From: common.Address{'b'}}, &bind.TransactOpts{From: common.Address{'c'}})(a)
+	require.Equal(t, []common.Address{{'b'}, {'c'}}, a.resolutionSignerAddresses())
+}
@@ -1,26 +1,36 @@
 package timeboost
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"math/big"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/offchainlabs/nitro/cmd/genericconf"
 	"github.com/offchainlabs/nitro/pubsub"
+	"github.com/offchainlabs/nitro/solgen/go/express_lane_auctiongen"
 	"github.com/offchainlabs/nitro/util/redisutil"
 )
 
@@ -149,9 +159,7 @@ func TestBidValidatorAuctioneerRedisStream(t *testing.T) {
 
 	// We verify that the auctioneer has consumed all validated bids from the single Redis stream.
 	// We also verify the top two bids are those we expect.
-	am.bidCache.Lock()
-	require.Equal(t, 3, len(am.bidCache.bidsByExpressLaneControllerAddr))
-	am.bidCache.Unlock()
+	require.Len(t, am.bidCache.snapshot(), 3)
 	result := am.bidCache.topTwoBids()
 	require.Equal(t, big.NewInt(7), result.firstPlace.Amount) // Best bid should be Charlie's last bid 7
 	require.Equal(t, charlieAddr, result.firstPlace.Bidder)
@@ -222,3 +230,909 @@ func mockOperation(successAfter int, currentAttempt *int) func() error {
 		return errors.New("operation failed")
 	}
 }
+
+// fakeAuctioneerClient is an in-memory AuctioneerClient. It implements just
+// enough of the contract backend to build resolution transactions; any other
+// backend method panics through the nil embedded interface.
+type fakeAuctioneerClient struct {
+	bind.ContractBackend
+	baseFee   *big.Int
+	submitted []*types.Transaction
+	submitErr error
+	callErr   error
+	sent      []*types.Transaction
+	// callResults overrides the result of contract calls by method selector.
+	callResults map[[4]byte][]byte
+	// head is the latest block number, defaulting to 1.
+	head int64
+	// headTime is the timestamp of the latest block.
+	headTime uint64
+	// reorgOnce drops all submitted transactions the first time the latest
+	// header is read after a submission.
+	reorgOnce bool
+	// balance of every account, defaulting to plenty.
+	balance *big.Int
+	// deposits overrides the auction contract deposit of the given bidders.
+	deposits map[common.Address]*big.Int
+	// withholdReceipts keeps submitted transactions pending while set.
+	withholdReceipts atomic.Bool
+	// logs are filtered by block range and event, in blocks whose timestamp
+	// is their number.
+	logs []types.Log
+	// maxLogRange fails log queries covering more blocks, if set.
+	maxLogRange uint64
+	// receiptLogs are the logs of every receipt.
+	receiptLogs []*types.Log
+	// revertReceipts marks every transaction as reverted once mined.
+	revertReceipts bool
+	// revertBidders marks transactions resolving with a bid for any of these
+	// express lane controllers as reverted once mined.
+	revertBidders []common.Address
+	// simulate, if set, returns the error of calls resolving an auction, as
+	// made to simulate resolutions.
+	simulate func(data []byte) error
+}
+
+func (c *fakeAuctioneerClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	from, to := query.FromBlock.Uint64(), query.ToBlock.Uint64()
+	if c.maxLogRange > 0 && to-from+1 > c.maxLogRange {
+		return nil, fmt.Errorf("block range of %d exceeds limit of %d", to-from+1, c.maxLogRange)
+	}
+	var logs []types.Log
+	for _, l := range c.logs {
+		if l.BlockNumber < from || l.BlockNumber > to {
+			continue
+		}
+		if len(query.Topics) > 0 && len(query.Topics[0]) > 0 && !slices.Contains(query.Topics[0], l.Topics[0]) {
+			continue
+		}
+		logs = append(logs, l)
+	}
+	return logs, nil
+}
+
+func (c *fakeAuctioneerClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	if c.balance == nil {
+		return common.MaxHash.Big(), nil
+	}
+	return c.balance, nil
+}
+
+func (c *fakeAuctioneerClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	c.sent = append(c.sent, tx)
+	return nil
+}
+
+func (c *fakeAuctioneerClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if c.callErr != nil {
+		return nil, c.callErr
+	}
+	if len(call.Data) >= 36 && [4]byte(call.Data[:4]) == [4]byte(crypto.Keccak256([]byte("balanceOf(address)"))) {
+		if deposit, ok := c.deposits[common.BytesToAddress(call.Data[4:36])]; ok {
+			return common.BigToHash(deposit).Bytes(), nil
+		}
+	}
+	if c.simulate != nil && isResolutionCall(call.Data) {
+		if err := c.simulate(call.Data); err != nil {
+			return nil, err
+		}
+	}
+	if len(call.Data) >= 4 {
+		if result, ok := c.callResults[[4]byte(call.Data[:4])]; ok {
+			return result, nil
+		}
+	}
+	// Enough for any of the auction contract getters returning a single word,
+	// e.g. every bidder has the maximum possible deposit.
+	return common.MaxHash.Bytes(), nil
+}
+
+// isResolutionCall reports whether the call data resolves an auction.
+func isResolutionCall(data []byte) bool {
+	contractAbi, err := express_lane_auctiongen.ExpressLaneAuctionMetaData.GetAbi()
+	if err != nil || len(data) < 4 {
+		return false
+	}
+	method, err := contractAbi.MethodById(data[:4])
+	return err == nil && (method.Name == "resolveSingleBidAuction" || method.Name == "resolveMultiBidAuction")
+}
+
+func (c *fakeAuctioneerClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if c.reorgOnce && len(c.submitted) > 0 {
+		c.reorgOnce = false
+		c.submitted = nil
+	}
+	if number != nil {
+		return &types.Header{Number: number, Time: number.Uint64(), BaseFee: c.baseFee}, nil
+	}
+	return &types.Header{Number: big.NewInt(max(c.head, 1)), Time: c.headTime, BaseFee: c.baseFee}, nil
+}
+
+func (c *fakeAuctioneerClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(0), nil
+}
+
+func (c *fakeAuctioneerClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(2), nil
+}
+
+func (c *fakeAuctioneerClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return uint64(len(c.submitted)), nil
+}
+
+// effectiveGasPrice is the price per gas the transaction pays at the fake's
+// base fee.
+func (c *fakeAuctioneerClient) effectiveGasPrice(tx *types.Transaction) *big.Int {
+	if c.baseFee == nil {
+		return tx.GasPrice()
+	}
+	tip, err := tx.EffectiveGasTip(c.baseFee)
+	if err != nil {
+		return nil
+	}
+	return tip.Add(tip, c.baseFee)
+}
+
+func (c *fakeAuctioneerClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	if c.withholdReceipts.Load() {
+		return nil, ethereum.NotFound
+	}
+	for _, tx := range c.submitted {
+		if tx.Hash() == txHash {
+			status := types.ReceiptStatusSuccessful
+			if c.revertReceipts || slices.ContainsFunc(c.revertBidders, func(bidder common.Address) bool {
+				return bytes.Contains(tx.Data(), bidder.Bytes())
+			}) {
+				status = types.ReceiptStatusFailed
+			}
+			return &types.Receipt{TxHash: txHash, Status: status, BlockNumber: big.NewInt(1), GasUsed: tx.Gas(), EffectiveGasPrice: c.effectiveGasPrice(tx), Logs: c.receiptLogs}, nil
+		}
+	}
+	return nil, ethereum.NotFound
+}
+
+func (c *fakeAuctioneerClient) SubmitAuctionResolutionTransaction(ctx context.Context, tx *types.Transaction) error {
+	if c.submitErr != nil {
+		return c.submitErr
+	}
+	c.submitted = append(c.submitted, tx)
+	return nil
+}
+
+func TestResolveAuctionWithFakeClient(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainId := big.NewInt(1)
+	txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+	require.NoError(t, err)
+	txOpts.GasLimit = 1_000_000
+
+	newAuctioneer := func() *AuctioneerServer {
+		return &AuctioneerServer{
+			txOpts:              txOpts,
+			chainId:             chainId,
+			auctionContractAddr: common.HexToAddress("0x1234"),
+			bidCache:            newBidCache([32]byte{}),
+			roundTimingInfo: RoundTimingInfo{
+				Offset:            time.Now(),
+				Round:             time.Minute,
+				AuctionClosing:    15 * time.Second,
+				ReserveSubmission: 15 * time.Second,
+			},
+		}
+	}
+	bid := func(controller string, amount int64) *ValidatedBid {
+		return &ValidatedBid{
+			ChainId:               chainId,
+			Bidder:                common.HexToAddress(controller),
+			ExpressLaneController: common.HexToAddress(controller),
+			Round:                 1,
+			Amount:                big.NewInt(amount),
+			Signature:             make([]byte, 65),
+		}
+	}
+
+	t.Run("NoBids", func(t *testing.T) {
+		t.Parallel()
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1)}
+		a := newAuctioneer()
+		result, err := a.resolveAuctionWithClient(ctx, client, true)
+		require.NoError(t, err)
+		require.Equal(t, &ResolutionResult{Round: 1, Outcome: RoundStatusNoBids}, result)
+		require.Empty(t, client.submitted)
+		status, ok := a.RoundOutcome(1)
+		require.True(t, ok)
+		require.Equal(t, RoundStatusNoBids, status)
+	})
+
+	t.Run("SingleBid", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer()
+		a.bidCache.add(bid("0x1", 10))
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1)}
+		result, err := a.resolveAuctionWithClient(ctx, client, true)
+		require.NoError(t, err)
+		require.Len(t, client.submitted, 1)
+		require.Equal(t, a.auctionContractAddr, *client.submitted[0].To())
+		require.Equal(t, RoundStatusResolved, result.Outcome)
+		require.Equal(t, uint64(1), result.Round)
+		require.Equal(t, big.NewInt(10), result.FirstPlace.Amount)
+		require.Nil(t, result.SecondPlace)
+		require.Equal(t, client.submitted[0].Hash(), result.TxHash)
+		require.Equal(t, client.submitted[0].Gas(), result.GasUsed)
+	})
+
+	t.Run("CollapsedToSingleBid", func(t *testing.T) {
+		t.Parallel()
+		for _, tt := range []struct {
+			fallback  SingleBidFallback
+			submitted int
+			status    RoundStatus
+		}{
+			{ResolveSingleBid, 1, RoundStatusResolved},
+			{SkipSingleBid, 0, RoundStatusSkipped},
+		} {
+			t.Run(tt.fallback.String(), func(t *testing.T) {
+				t.Parallel()
+				a := newAuctioneer()
+				WithSingleBidFallback(tt.fallback)(a)
+				a.bidCache.add(bid("0x1", 20))
+				a.bidCache.add(bid("0x2", 10))
+				// The loser withdrew its deposit since its bid was validated.
+				client := &fakeAuctioneerClient{
+					baseFee:  big.NewInt(1),
+					deposits: map[common.Address]*big.Int{common.HexToAddress("0x2"): big.NewInt(5)},
+				}
+				_, err := a.resolveAuctionWithClient(ctx, client, true)
+				require.NoError(t, err)
+				require.Len(t, client.submitted, tt.submitted)
+				require.Equal(t, 1, a.bidCache.size())
+				status, ok := a.RoundOutcome(1)
+				require.True(t, ok)
+				require.Equal(t, tt.status, status)
+			})
+		}
+	})
+
+	t.Run("LegacyChain", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer()
+		a.bidCache.add(bid("0x1", 10))
+		client := &fakeAuctioneerClient{}
+		_, err := a.resolveAuctionWithClient(ctx, client, true)
+		require.NoError(t, err)
+		require.Len(t, client.submitted, 1)
+		require.Equal(t, uint8(types.LegacyTxType), client.submitted[0].Type())
+		require.Equal(t, big.NewInt(2), client.submitted[0].GasPrice())
+	})
+
+	t.Run("NotEnoughBids", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer()
+		a.minBidsToResolve = 3
+		a.bidCache.add(bid("0x1", 10))
+		a.bidCache.add(bid("0x2", 20))
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1)}
+		_, err := a.resolveAuctionWithClient(ctx, client, true)
+		require.NoError(t, err)
+		require.Empty(t, client.submitted)
+		status, _ := a.RoundOutcome(1)
+		require.Equal(t, RoundStatusSkipped, status)
+
+		a.bidCache.add(bid("0x3", 30))
+		_, err = a.resolveAuctionWithClient(ctx, client, false)
+		require.NoError(t, err)
+		require.Len(t, client.submitted, 1)
+		status, _ = a.RoundOutcome(1)
+		require.Equal(t, RoundStatusResolved, status)
+	})
+
+	t.Run("MultiBid", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer()
+		a.bidCache.add(bid("0x1", 10))
+		a.bidCache.add(bid("0x2", 20))
+		// The listener thread is never started, so the notification stays pending.
+		WithResolutionListener(func(AuctionResolution) {})(a)
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1)}
+		_, err := a.resolveAuctionWithClient(ctx, client, true)
+		require.NoError(t, err)
+		require.Len(t, client.submitted, 1)
+		resolved := <-a.resolutionListeners[0].pending
+		require.Equal(t, uint64(1), resolved.Round)
+		require.Equal(t, big.NewInt(20), resolved.FirstPlace.Amount)
+		require.Equal(t, big.NewInt(10), resolved.SecondPlace.Amount)
+		require.Equal(t, client.submitted[0].Hash(), resolved.TxHash)
+	})
+
+	t.Run("ZeroAmount", func(t *testing.T) {
+		t.Parallel()
+		for _, amounts := range [][]int64{{0}, {10, 0}} {
+			a := newAuctioneer()
+			for i, amount := range amounts {
+				a.bidCache.add(bid(fmt.Sprintf("0x%d", i+1), amount))
+			}
+			client := &fakeAuctioneerClient{baseFee: big.NewInt(1)}
+			_, err := a.resolveAuctionWithClient(ctx, client, true)
+			require.NoError(t, err)
+			require.Empty(t, client.submitted)
+			status, ok := a.RoundOutcome(1)
+			require.True(t, ok)
+			require.Equal(t, RoundStatusNoBids, status)
+		}
+	})
+
+	t.Run("GasTooHigh", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer()
+		a.maxResolutionGas = txOpts.GasLimit - 1
+		a.bidCache.add(bid("0x1", 10))
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1)}
+		_, err := a.resolveAuctionWithClient(ctx, client, true)
+		require.ErrorIs(t, err, errResolutionGasTooHigh)
+		require.Empty(t, client.submitted)
+
+		a.maxResolutionGas = txOpts.GasLimit
+		_, err = a.resolveAuctionWithClient(ctx, client, false)
+		require.NoError(t, err)
+		require.Len(t, client.submitted, 1)
+	})
+
+	t.Run("ObserverMode", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer()
+		a.txOpts = nil
+		WithObserverMode(true)(a)
+		a.bidCache.add(bid("0x1", 10))
+		a.bidCache.add(bid("0x2", 20))
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1)}
+		result, err := a.resolveAuctionWithClient(ctx, client, true)
+		require.NoError(t, err)
+		require.Empty(t, client.submitted)
+		require.Empty(t, client.sent)
+		require.Equal(t, RoundStatusObserved, result.Outcome)
+		require.Equal(t, big.NewInt(20), result.FirstPlace.Amount)
+		require.Equal(t, big.NewInt(10), result.SecondPlace.Amount)
+		require.Equal(t, common.Hash{}, result.TxHash)
+		status, ok := a.RoundOutcome(1)
+		require.True(t, ok)
+		require.Equal(t, RoundStatusObserved, status)
+	})
+
+	t.Run("ReorgedOut", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer()
+		WithResolutionConfirmations(3)(a)
+		a.bidCache.add(bid("0x1", 10))
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1), head: 10, reorgOnce: true}
+		_, err := a.resolveAuctionWithClient(ctx, client, true)
+		require.NoError(t, err)
+		require.False(t, client.reorgOnce)
+		// The resolution transaction was resubmitted after being reorged out.
+		require.Len(t, client.submitted, 1)
+	})
+
+	t.Run("SignerOutOfFunds", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer()
+		a.minSignerBalance = big.NewInt(1)
+		a.bidCache.add(bid("0x1", 10))
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1), balance: big.NewInt(0)}
+		_, err := a.resolveAuctionWithClient(ctx, client, true)
+		require.ErrorIs(t, err, errSignerOutOfFunds)
+		require.Empty(t, client.submitted)
+		require.ErrorContains(t, a.checkSignerBalance(), "below minimum")
+
+		// Once topped up, resolutions resume.
+		client.balance = nil
+		_, err = a.resolveAuctionWithClient(ctx, client, false)
+		require.NoError(t, err)
+		require.Len(t, client.submitted, 1)
+		require.NoError(t, a.checkSignerBalance())
+	})
+
+	t.Run("SequencerRejectsForFunds", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer()
+		a.bidCache.add(bid("0x1", 10))
+		client := &fakeAuctioneerClient{
+			baseFee:   big.NewInt(1),
+			submitErr: errors.New("insufficient funds for gas * price + value: address 0x1 have 0 want 1"),
+		}
+		// Not retried until the end of the round.
+		start := time.Now()
+		_, err := a.resolveAuctionWithClient(ctx, client, true)
+		require.ErrorIs(t, err, errSignerOutOfFunds)
+		require.Less(t, time.Since(start), 5*time.Second)
+	})
+}
+
+func TestBidCacheClearDuringResolution(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainId := big.NewInt(1)
+	txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+	require.NoError(t, err)
+	txOpts.GasLimit = 1_000_000
+	a := &AuctioneerServer{
+		txOpts:              txOpts,
+		chainId:             chainId,
+		auctionContractAddr: common.HexToAddress("0x1234"),
+		bidCache:            newBidCache([32]byte{}),
+		bidsReceiver:        make(chan *JsonValidatedBid),
+		roundTimingInfo: RoundTimingInfo{
+			Offset:            time.Now(),
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+	}
+
+	// Mirror the auctioneer's bid receiver and resolution threads, which run
+	// concurrently with each other and with anything inspecting the cache.
+	receiverDone := make(chan struct{})
+	go func() {
+		defer close(receiverDone)
+		for bid := range a.bidsReceiver {
+			a.bidCache.add(JsonValidatedBidToGo(bid))
+		}
+	}()
+	resolverDone := make(chan error, 1)
+	go func() {
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1)}
+		for i := 0; i < 20; i++ {
+			if _, err := a.resolveAuctionWithClient(ctx, client, i == 0); err != nil {
+				resolverDone <- err
+				return
+			}
+			_ = a.bidCache.size()
+			a.bidCache.clear()
+		}
+		resolverDone <- nil
+	}()
+
+	for i := int64(0); ; i++ {
+		select {
+		case err := <-resolverDone:
+			close(a.bidsReceiver)
+			<-receiverDone
+			require.NoError(t, err)
+			return
+		default:
+		}
+		controller := common.BigToAddress(big.NewInt(i%10 + 1))
+		a.bidsReceiver <- (&ValidatedBid{
+			ChainId:               chainId,
+			Bidder:                controller,
+			ExpressLaneController: controller,
+			Round:                 1,
+			Amount:                big.NewInt(i + 1),
+			Signature:             make([]byte, 65),
+		}).ToJson()
+		_ = a.bidCache.snapshot()
+	}
+}
+
+func TestCloseRoundDropsLateBids(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainId := big.NewInt(1)
+	txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+	require.NoError(t, err)
+	txOpts.GasLimit = 1_000_000
+	bid := func(controller string, round uint64) *JsonValidatedBid {
+		return (&ValidatedBid{
+			ChainId:                chainId,
+			AuctionContractAddress: common.HexToAddress("0x1234"),
+			Bidder:                 common.HexToAddress(controller),
+			ExpressLaneController:  common.HexToAddress(controller),
+			Round:                  round,
+			Amount:                 big.NewInt(10),
+			Signature:              make([]byte, 65),
+		}).ToJson()
+	}
+
+	tests := []struct {
+		name   string
+		bids   []*JsonValidatedBid
+		client *fakeAuctioneerClient
+	}{
+		{name: "no bids", client: &fakeAuctioneerClient{baseFee: big.NewInt(1)}},
+		{name: "resolved", bids: []*JsonValidatedBid{bid("0x1", 1)}, client: &fakeAuctioneerClient{baseFee: big.NewInt(1)}},
+		{name: "failed", bids: []*JsonValidatedBid{bid("0x1", 1)}, client: &fakeAuctioneerClient{baseFee: big.NewInt(1), submitErr: errors.New("insufficient funds for gas * price + value")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			a := &AuctioneerServer{
+				txOpts:              txOpts,
+				chainId:             chainId,
+				auctionContractAddr: common.HexToAddress("0x1234"),
+				bidCache:            newBidCache([32]byte{}),
+				roundTimingInfo: RoundTimingInfo{
+					Offset:            time.Now(),
+					Round:             time.Minute,
+					AuctionClosing:    15 * time.Second,
+					ReserveSubmission: 15 * time.Second,
+				},
+			}
+			for _, b := range tt.bids {
+				a.receiveValidatedBid(b)
+			}
+			// The outcome doesn't matter, the round is closed either way.
+			_, _ = a.resolveAuctionWithClient(ctx, tt.client, true)
+			a.closeRound(1)
+			require.Equal(t, 0, a.bidCache.size())
+
+			// Late bids for the closed round don't leak into the next one.
+			a.receiveValidatedBid(bid("0x2", 1))
+			require.Equal(t, 0, a.bidCache.size())
+			a.receiveValidatedBid(bid("0x3", 2))
+			require.Equal(t, 1, a.bidCache.size())
+			require.Equal(t, map[uint64]int{2: 1}, a.bidCache.sizeByRound())
+		})
+	}
+}
+
+// goroutineStacks returns the stack of every goroutine by its header line,
+// e.g. "goroutine 42 [select]:" with the state dropped.
+func goroutineStacks() map[string]string {
+	buf := make([]byte, 1<<20)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	stacks := make(map[string]string)
+	for _, stack := range strings.Split(string(buf), "\n\n") {
+		id, _, _ := strings.Cut(stack, " [")
+		stacks[id] = stack
+	}
+	return stacks
+}
+
+// Not parallel, so that the goroutines of other tests don't show up as leaks.
+func TestAuctioneerStartStopLeavesNoGoroutines(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	redisURL := redisutil.CreateTestRedis(ctx, t)
+	redisClient, err := redisutil.RedisClientFromURL(redisURL)
+	require.NoError(t, err)
+	consumer, err := pubsub.NewConsumer[*JsonValidatedBid, error](redisClient, validatedBidsRedisStream, &pubsub.TestConsumerConfig)
+	require.NoError(t, err)
+
+	before := goroutineStacks()
+	a := &AuctioneerServer{
+		consumer:      consumer,
+		bidCache:      newBidCache([32]byte{}),
+		bidsReceiver:  make(chan *JsonValidatedBid, 10),
+		streamTimeout: time.Minute,
+		roundTimingInfo: RoundTimingInfo{
+			Offset:            time.Now(),
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+		roundTimingRefreshInterval: time.Minute,
+		healthcheckAddr:            fmt.Sprintf("localhost:%d", getRandomPort(t)),
+	}
+	a.Start(ctx)
+	time.Sleep(100 * time.Millisecond)
+	a.StopAndWait()
+
+	// Goroutines may take a moment to unwind after the threads they belong to were stopped.
+	var leaked []string
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(50 * time.Millisecond) {
+		leaked = nil
+		for id, stack := range goroutineStacks() {
+			if _, ok := before[id]; ok {
+				continue
+			}
+			if strings.Contains(stack, "nitro/timeboost.") || strings.Contains(stack, "nitro/pubsub.") {
+				leaked = append(leaked, stack)
+			}
+		}
+		if len(leaked) == 0 || time.Now().After(deadline) {
+			break
+		}
+	}
+	require.Empty(t, leaked)
+}
+
+func TestReceiveValidatedBidRecordsArrivalTime(t *testing.T) {
+	t.Parallel()
+	a := &AuctioneerServer{bidCache: newBidCache([32]byte{})}
+	before := time.Now()
+	for i := int64(1); i <= 3; i++ {
+		a.receiveValidatedBid((&ValidatedBid{
+			ChainId:               big.NewInt(1),
+			Bidder:                common.BigToAddress(big.NewInt(i)),
+			ExpressLaneController: common.BigToAddress(big.NewInt(i)),
+			Round:                 1,
+			Amount:                big.NewInt(i),
+		}).ToJson())
+	}
+	after := time.Now()
+
+	// Bids arrive with the amount matching their arrival order.
+	received := make(map[int64]time.Time)
+	for _, bid := range a.bidCache.snapshot() {
+		require.False(t, bid.ReceivedAt.Before(before))
+		require.False(t, bid.ReceivedAt.After(after))
+		received[bid.Amount.Int64()] = bid.ReceivedAt
+	}
+	require.Len(t, received, 3)
+	require.False(t, received[2].Before(received[1]))
+	require.False(t, received[3].Before(received[2]))
+}
+
+func TestStopAndWaitDrainsConsumedBids(t *testing.T) {
+	t.Parallel()
+	database, err := NewDatabase(t.TempDir())
+	require.NoError(t, err)
+	a := &AuctioneerServer{
+		database:     database,
+		bidsReceiver: make(chan *JsonValidatedBid, 10),
+	}
+	for round := uint64(1); round <= 2; round++ {
+		a.bidsReceiver <- (&ValidatedBid{
+			ChainId:                big.NewInt(1),
+			AuctionContractAddress: common.HexToAddress("0x1234"),
+			Bidder:                 common.HexToAddress("0x1"),
+			ExpressLaneController:  common.HexToAddress("0x1"),
+			Round:                  round,
+			Amount:                 big.NewInt(100),
+			Signature:              []byte{1},
+		}).ToJson()
+	}
+
+	a.StopAndWait()
+	require.Empty(t, a.bidsReceiver)
+	bids, maxRound, err := database.GetBids(0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), maxRound)
+	require.Len(t, bids, 1)
+	require.Equal(t, uint64(1), bids[0].Round)
+}
+
+func TestApplyResolutionTxType(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := &fakeAuctioneerClient{}
+	dynamicHeader := &types.Header{BaseFee: big.NewInt(1)}
+	legacyHeader := &types.Header{}
+	tests := []struct {
+		name       string
+		txType     string
+		header     *types.Header
+		opts       bind.TransactOpts
+		wantErr    bool
+		wantLegacy bool
+	}{
+		{name: "auto on dynamic fee chain", txType: ResolutionTxTypeAuto, header: dynamicHeader},
+		{name: "auto on legacy chain", txType: ResolutionTxTypeAuto, header: legacyHeader, opts: bind.TransactOpts{GasFeeCap: big.NewInt(5)}, wantLegacy: true},
+		{name: "forced legacy on dynamic fee chain", txType: ResolutionTxTypeLegacy, header: dynamicHeader, wantLegacy: true},
+		{name: "forced dynamic fee on dynamic fee chain", txType: ResolutionTxTypeDynamicFee, header: dynamicHeader},
+		{name: "forced dynamic fee on legacy chain", txType: ResolutionTxTypeDynamicFee, header: legacyHeader, wantErr: true},
+		{name: "forced dynamic fee with gas price", txType: ResolutionTxTypeDynamicFee, header: dynamicHeader, opts: bind.TransactOpts{GasPrice: big.NewInt(1)}, wantErr: true},
+		{name: "unknown", txType: "blob", header: dynamicHeader, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := tt.opts
+			err := applyResolutionTxType(ctx, &opts, tt.txType, tt.header, client)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantLegacy {
+				require.Equal(t, big.NewInt(2), opts.GasPrice)
+				require.Nil(t, opts.GasFeeCap)
+				require.Nil(t, opts.GasTipCap)
+			} else {
+				require.Nil(t, opts.GasPrice)
+			}
+		})
+	}
+}
+
+func TestFundedTopTwoBids(t *testing.T) {
+	t.Parallel()
+	a := &AuctioneerServer{bidCache: newBidCache([32]byte{})}
+	bidder1 := common.HexToAddress("0x1")
+	bidder2 := common.HexToAddress("0x2")
+	bidder3 := common.HexToAddress("0x3")
+	bidder4 := common.HexToAddress("0x4")
+	a.bidCache.add(&ValidatedBid{Bidder: bidder1, ExpressLaneController: bidder1, Amount: big.NewInt(400)})
+	a.bidCache.add(&ValidatedBid{Bidder: bidder2, ExpressLaneController: bidder2, Amount: big.NewInt(300)})
+	a.bidCache.add(&ValidatedBid{Bidder: bidder3, ExpressLaneController: bidder3, Amount: big.NewInt(200)})
+	a.bidCache.add(&ValidatedBid{Bidder: bidder4, ExpressLaneController: bidder4, Amount: big.NewInt(100)})
+
+	balances := map[common.Address]*big.Int{
+		bidder1: big.NewInt(50), // withdrew after bidding
+		bidder3: big.NewInt(200),
+		bidder4: big.NewInt(100),
+	}
+	var checked []common.Address
+	balanceOf := func(opts *bind.CallOpts, account common.Address) (*big.Int, error) {
+		checked = append(checked, account)
+		if balance, ok := balances[account]; ok {
+			return balance, nil
+		}
+		return nil, errors.New("rpc error")
+	}
+	result := a.fundedTopTwoBids(context.Background(), 1, balanceOf)
+
+	// bidder1 is evicted and bidder3 promoted, bidder2's deposit couldn't be
+	// checked so its bid is kept. bidder4 is never checked.
+	require.Equal(t, bidder2, result.firstPlace.Bidder)
+	require.Equal(t, bidder3, result.secondPlace.Bidder)
+	require.Equal(t, 3, a.bidCache.size())
+	require.ElementsMatch(t, []common.Address{bidder1, bidder2, bidder3}, checked)
+}
+
+func TestDropDuplicateBidder(t *testing.T) {
+	t.Parallel()
+	bidder := common.HexToAddress("0x1")
+	first := &ValidatedBid{Bidder: bidder, ExpressLaneController: common.HexToAddress("0xa"), Amount: big.NewInt(20)}
+	second := &ValidatedBid{Bidder: bidder, ExpressLaneController: common.HexToAddress("0xb"), Amount: big.NewInt(10)}
+
+	result := &auctionResult{firstPlace: first, secondPlace: second}
+	dropDuplicateBidder(1, result)
+	require.Equal(t, first, result.firstPlace)
+	require.Nil(t, result.secondPlace)
+
+	other := &ValidatedBid{Bidder: common.HexToAddress("0x2"), ExpressLaneController: common.HexToAddress("0xb"), Amount: big.NewInt(10)}
+	result = &auctionResult{firstPlace: first, secondPlace: other}
+	dropDuplicateBidder(1, result)
+	require.Equal(t, other, result.secondPlace)
+}
+
+func TestAuctionRoundEndToEnd(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainId := big.NewInt(1)
+	txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+	require.NoError(t, err)
+	txOpts.GasLimit = 1_000_000
+	contractAbi, err := express_lane_auctiongen.ExpressLaneAuctionMetaData.GetAbi()
+	require.NoError(t, err)
+
+	// decodeResolution returns the name of the contract method a resolution
+	// transaction calls, along with the bids passed to it.
+	decodeResolution := func(t *testing.T, tx *types.Transaction) (string, []*express_lane_auctiongen.Bid) {
+		method, err := contractAbi.MethodById(tx.Data()[:4])
+		require.NoError(t, err)
+		args, err := method.Inputs.Unpack(tx.Data()[4:])
+		require.NoError(t, err)
+		var bids []*express_lane_auctiongen.Bid
+		for _, arg := range args {
+			bids = append(bids, abi.ConvertType(arg, new(express_lane_auctiongen.Bid)).(*express_lane_auctiongen.Bid))
+		}
+		return method.Name, bids
+	}
+
+	type testBid struct {
+		controller string
+		amount     int64
+	}
+	tests := []struct {
+		name       string
+		bids       []testBid
+		wantMethod string
+		wantBids   []testBid
+	}{
+		{
+			name: "no bids",
+		},
+		{
+			name:       "single bid",
+			bids:       []testBid{{"0x1", 10}},
+			wantMethod: "resolveSingleBidAuction",
+			wantBids:   []testBid{{"0x1", 10}},
+		},
+		{
+			name:       "multiple bids",
+			bids:       []testBid{{"0x1", 10}, {"0x2", 30}, {"0x3", 20}},
+			wantMethod: "resolveMultiBidAuction",
+			wantBids:   []testBid{{"0x2", 30}, {"0x3", 20}},
+		},
+		{
+			name:       "later bid from the same controller replaces its earlier bid",
+			bids:       []testBid{{"0x1", 10}, {"0x2", 30}, {"0x1", 40}},
+			wantMethod: "resolveMultiBidAuction",
+			wantBids:   []testBid{{"0x1", 40}, {"0x2", 30}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &AuctioneerServer{
+				txOpts:              txOpts,
+				chainId:             chainId,
+				auctionContractAddr: common.HexToAddress("0x1234"),
+				bidCache:            newBidCache([32]byte{}),
+				roundTimingInfo: RoundTimingInfo{
+					Offset:            time.Now(),
+					Round:             time.Minute,
+					AuctionClosing:    15 * time.Second,
+					ReserveSubmission: 15 * time.Second,
+				},
+			}
+			for i, bid := range tt.bids {
+				a.receiveValidatedBid((&ValidatedBid{
+					ChainId:                chainId,
+					AuctionContractAddress: a.auctionContractAddr,
+					Bidder:                 common.HexToAddress(bid.controller),
+					ExpressLaneController:  common.HexToAddress(bid.controller),
+					Round:                  1,
+					Amount:                 big.NewInt(bid.amount),
+					Signature:              append(make([]byte, 64), byte(i)),
+				}).ToJson())
+			}
+
+			// Resolve the round as the auctioneer does once its auction closes.
+			client := &fakeAuctioneerClient{baseFee: big.NewInt(1)}
+			_, err := a.resolveAuctionWithClient(ctx, client, true)
+			require.NoError(t, err)
+			if tt.wantMethod == "" {
+				require.Empty(t, client.submitted)
+				return
+			}
+			require.Len(t, client.submitted, 1)
+			tx := client.submitted[0]
+			require.Equal(t, a.auctionContractAddr, *tx.To())
+			method, bids := decodeResolution(t, tx)
+			require.Equal(t, tt.wantMethod, method)
+			require.Len(t, bids, len(tt.wantBids))
+			for i, want := range tt.wantBids {
+				require.Equal(t, common.HexToAddress(want.controller), bids[i].ExpressLaneController)
+				require.Equal(t, big.NewInt(want.amount), bids[i].Amount)
+				require.Len(t, bids[i].Signature, 65)
+			}
+		})
+	}
+}
+
+func TestAuctioneerRoundBoundaries(t *testing.T) {
+	t.Parallel()
+	a := &AuctioneerServer{
+		roundTimingInfo: RoundTimingInfo{
+			Offset:            time.Now().Add(-2*time.Minute - 10*time.Second),
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+	}
+	require.Equal(t, uint64(2), a.CurrentRound())
+	require.Equal(t, uint64(3), a.UpcomingRound())
+	// 50s are left in the round, bidding closes 15s before it ends.
+	require.InDelta(t, 35*time.Second, a.TimeUntilClose(), float64(time.Second))
+	// Only rounds that haven't started yet can still be resolved.
+	require.False(t, a.tooLateToResolve(a.UpcomingRound()))
+	require.True(t, a.tooLateToResolve(a.CurrentRound()))
+
+	// Within the closing window there's no time left to bid.
+	a.roundTimingInfo.Offset = time.Now().Add(-50 * time.Second)
+	require.Equal(t, uint64(0), a.CurrentRound())
+	require.Equal(t, time.Duration(0), a.TimeUntilClose())
+}
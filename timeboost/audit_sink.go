@@ -0,0 +1,181 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// auditSinkBuffer is the number of audit records that may be waiting to be
+// written before newer records are dropped.
+const auditSinkBuffer = 16
+
+// Reasons for which the auctioneer did not consider a received bid when
+// resolving a round.
+const (
+	AuditReasonBelowReservePrice = "below reserve price"
+	AuditReasonUnfunded          = "deposit no longer covers bid"
+	AuditReasonSuperseded        = "superseded by a later bid for the same express lane controller"
+	AuditReasonCacheFull         = "outbid while the bid cache was full"
+	AuditReasonBlacklisted       = "bidder blacklisted after repeated resolution failures"
+	AuditReasonStaleHead         = "received while the chain head was stale"
+	AuditReasonWrongContract     = "signed for another auction contract"
+)
+
+// AuditedBid is a bid received by the auctioneer, along with whether it took
+// part in the resolution of its round.
+type AuditedBid struct {
+	Bidder                common.Address `json:"bidder"`
+	ExpressLaneController common.Address `json:"expressLaneController"`
+	Amount                *hexutil.Big   `json:"amount"`
+	ReceivedAt            time.Time      `json:"receivedAt"`
+	Accepted              bool           `json:"accepted"`
+	RejectionReason       string         `json:"rejectionReason,omitempty"`
+	CorrelationId         string         `json:"correlationId,omitempty"`
+}
+
+// AuditRecord describes the decision made when resolving a round: every bid
+// received for it, and which bids won.
+type AuditRecord struct {
+	Round       uint64       `json:"round"`
+	Bids        []AuditedBid `json:"bids"`
+	FirstPlace  *AuditedBid  `json:"firstPlace"`
+	SecondPlace *AuditedBid  `json:"secondPlace,omitempty"`
+	TxHash      common.Hash  `json:"txHash"`
+	// BaseFee and EffectiveGasPrice are the parent chain fees the resolution
+	// was sent and mined at, see ResolutionResult. They are nil if unknown.
+	BaseFee           *hexutil.Big `json:"baseFee,omitempty"`
+	EffectiveGasPrice *hexutil.Big `json:"effectiveGasPrice,omitempty"`
+	// Revenue is what the auction earned from the round, see
+	// CommittedValue, zero for rounds only observed. It is nil if
+	// unknown.
+	Revenue *hexutil.Big `json:"revenue,omitempty"`
+}
+
+// WithAuditSink writes one JSON audit record per resolved round to w, for
+// post-hoc dispute resolution. Records are written from their own thread, and
+// dropped with a warning if w falls behind, so a slow sink never blocks the
+// resolution loop.
+func WithAuditSink(w io.Writer) AuctioneerServerOpt {
+	return func(a *AuctioneerServer) {
+		a.auditor = &bidAuditor{
+			encoder: json.NewEncoder(w),
+			pending: make(chan *AuditRecord, auditSinkBuffer),
+		}
+	}
+}
+
+type auditedBidEntry struct {
+	bid    *ValidatedBid
+	reason string
+}
+
+// bidAuditor tracks the bids received for the upcoming round.
+type bidAuditor struct {
+	mutex    sync.Mutex
+	received []*auditedBidEntry
+	encoder  *json.Encoder
+	pending  chan *AuditRecord
+}
+
+func (ba *bidAuditor) receive(bid *ValidatedBid) {
+	ba.mutex.Lock()
+	defer ba.mutex.Unlock()
+	ba.received = append(ba.received, &auditedBidEntry{bid: bid})
+}
+
+// reject records why a received bid was not considered. Only the first reason
+// given for a bid is kept.
+func (ba *bidAuditor) reject(bid *ValidatedBid, reason string) {
+	ba.mutex.Lock()
+	defer ba.mutex.Unlock()
+	for _, entry := range ba.received {
+		if entry.bid == bid && entry.reason == "" {
+			entry.reason = reason
+		}
+	}
+}
+
+func (ba *bidAuditor) reset() {
+	ba.mutex.Lock()
+	defer ba.mutex.Unlock()
+	ba.received = nil
+}
+
+// record builds the audit record of a round resolved from the given cache.
+// Bids that are no longer cached without having been rejected were replaced
+// by a later bid for the same express lane controller.
+func (ba *bidAuditor) record(round uint64, cache *bidCache, result *auctionResult, txHash common.Hash, fees *resolutionFees, revenue *big.Int) *AuditRecord {
+	ba.mutex.Lock()
+	defer ba.mutex.Unlock()
+	record := &AuditRecord{
+		Round:  round,
+		Bids:   make([]AuditedBid, 0, len(ba.received)),
+		TxHash: txHash,
+	}
+	record.BaseFee, record.EffectiveGasPrice = fees.hexFees()
+	if revenue != nil {
+		record.Revenue = (*hexutil.Big)(revenue)
+	}
+	for _, entry := range ba.received {
+		reason := entry.reason
+		if reason == "" && !cache.contains(entry.bid) {
+			reason = AuditReasonSuperseded
+		}
+		audited := AuditedBid{
+			Bidder:                entry.bid.Bidder,
+			ExpressLaneController: entry.bid.ExpressLaneController,
+			Amount:                (*hexutil.Big)(entry.bid.Amount),
+			ReceivedAt:            entry.bid.ReceivedAt,
+			Accepted:              reason == "",
+			RejectionReason:       reason,
+			CorrelationId:         entry.bid.CorrelationId,
+		}
+		record.Bids = append(record.Bids, audited)
+		if entry.bid == result.firstPlace {
+			record.FirstPlace = &audited
+		} else if entry.bid == result.secondPlace {
+			record.SecondPlace = &audited
+		}
+	}
+	return record
+}
+
+func (a *AuctioneerServer) startAuditSink() {
+	if a.auditor == nil {
+		return
+	}
+	a.StopWaiter.LaunchThread(func(ctx context.Context) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case record := <-a.auditor.pending:
+				if err := a.auditor.encoder.Encode(record); err != nil {
+					log.Error("Could not write auction audit record", "round", record.Round, "err", err)
+				}
+			}
+		}
+	})
+}
+
+func (a *AuctioneerServer) emitAuditRecord(round uint64, status RoundStatus, result *auctionResult, txHash common.Hash, fees *resolutionFees) {
+	if a.auditor == nil {
+		return
+	}
+	select {
+	case a.auditor.pending <- a.auditor.record(round, a.bidCache, result, txHash, fees, a.roundRevenue(status, result)):
+	default:
+		log.Warn("Audit sink is falling behind, dropping audit record", "round", round)
+	}
+}
@@ -0,0 +1,86 @@
+package timeboost
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestAuditSinkRecordsRoundDecision(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainId := big.NewInt(1)
+	txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+	require.NoError(t, err)
+	txOpts.GasLimit = 1_000_000
+
+	a := &AuctioneerServer{
+		txOpts:              txOpts,
+		chainId:             chainId,
+		auctionContractAddr: common.HexToAddress("0x1234"),
+		bidCache:            newBidCache([32]byte{}),
+		roundTimingInfo: RoundTimingInfo{
+			Offset:            time.Now(),
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+	}
+	var sink bytes.Buffer
+	WithAuditSink(&sink)(a)
+
+	bid := func(controller string, amount int64, belowReservePrice bool) *JsonValidatedBid {
+		return (&ValidatedBid{
+			ChainId:                chainId,
+			AuctionContractAddress: a.auctionContractAddr,
+			Bidder:                 common.HexToAddress(controller),
+			ExpressLaneController:  common.HexToAddress(controller),
+			Round:                  1,
+			Amount:                 big.NewInt(amount),
+			Signature:              make([]byte, 65),
+			BelowReservePrice:      belowReservePrice,
+		}).ToJson()
+	}
+	a.receiveValidatedBid(bid("0x1", 10, false))
+	a.receiveValidatedBid(bid("0x2", 20, false))
+	a.receiveValidatedBid(bid("0x2", 30, false))
+	a.receiveValidatedBid(bid("0x3", 5, true))
+
+	client := &fakeAuctioneerClient{baseFee: big.NewInt(1)}
+	_, err = a.resolveAuctionWithClient(ctx, client, true)
+	require.NoError(t, err)
+	require.Len(t, client.submitted, 1)
+
+	// The writer thread is never started, so the record stays pending.
+	record := <-a.auditor.pending
+	require.Equal(t, uint64(1), record.Round)
+	require.Equal(t, client.submitted[0].Hash(), record.TxHash)
+	require.Len(t, record.Bids, 4)
+	require.True(t, record.Bids[0].Accepted)
+	require.Equal(t, AuditReasonSuperseded, record.Bids[1].RejectionReason)
+	require.True(t, record.Bids[2].Accepted)
+	require.Equal(t, AuditReasonBelowReservePrice, record.Bids[3].RejectionReason)
+	require.False(t, record.Bids[3].Accepted)
+	require.Equal(t, big.NewInt(30), record.FirstPlace.Amount.ToInt())
+	require.Equal(t, big.NewInt(10), record.SecondPlace.Amount.ToInt())
+
+	// Records are written as one JSON document per line.
+	require.NoError(t, a.auditor.encoder.Encode(record))
+	var decoded AuditRecord
+	require.NoError(t, json.Unmarshal(sink.Bytes(), &decoded))
+	require.Equal(t, record.Round, decoded.Round)
+	require.Len(t, decoded.Bids, 4)
+}
@@ -0,0 +1,67 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// belowReserveTally counts the bids of a round that weren't cached for being
+// below the reserve price, which only bid validators not enforcing the reserve
+// price let through. It tells a round no one bid on apart from one the reserve
+// price priced everyone out of.
+type belowReserveTally struct {
+	sync.Mutex
+	round   uint64
+	count   uint64
+	highest *ValidatedBid
+}
+
+func (t *belowReserveTally) add(bid *ValidatedBid) {
+	t.Lock()
+	defer t.Unlock()
+	if bid.Round != t.round {
+		t.round, t.count, t.highest = bid.Round, 0, nil
+	}
+	t.count++
+	if t.highest == nil || bid.Amount.Cmp(t.highest.Amount) > 0 {
+		t.highest = bid
+	}
+}
+
+// of returns the number of bids of the round below the reserve price, and the
+// highest of them.
+func (t *belowReserveTally) of(round uint64) (uint64, *ValidatedBid) {
+	t.Lock()
+	defer t.Unlock()
+	if round != t.round {
+		return 0, nil
+	}
+	return t.count, t.highest
+}
+
+func (t *belowReserveTally) reset() {
+	t.Lock()
+	defer t.Unlock()
+	t.round, t.count, t.highest = 0, 0, nil
+}
+
+// warnAllBelowReserve warns about a round left without bids although bids were
+// received for it, all below the reserve price, as a sign that the reserve
+// price may be set too high.
+func (a *AuctioneerServer) warnAllBelowReserve(round uint64) {
+	count, highest := a.belowReserve.of(round)
+	if count == 0 {
+		return
+	}
+	a.getMetrics().allBelowReserve.Inc(1)
+	a.getMetrics().highestBelowReserve.Update(highest.Amount.Int64())
+	reservePrice := "unknown"
+	if highest.ReservePrice != nil {
+		reservePrice = highest.ReservePrice.String()
+	}
+	log.Warn("All bids received for round were below the reserve price, which may be set too high", "round", round, "bids", count, "highestBid", highest.Amount.String(), "reservePrice", reservePrice)
+}
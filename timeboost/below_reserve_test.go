@@ -0,0 +1,88 @@
+package timeboost
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+func TestAllBidsBelowReserve(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	chainId := big.NewInt(1)
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+	require.NoError(t, err)
+	txOpts.GasLimit = 1_000_000
+	a := &AuctioneerServer{
+		txOpts:   txOpts,
+		chainId:  chainId,
+		bidCache: newBidCache([32]byte{}),
+		roundTimingInfo: RoundTimingInfo{
+			Offset:            time.Now(),
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+	}
+	WithMetricsRegistry(metrics.NewRegistry(), "arb/auctioneer/")(a)
+	bid := func(bidder string, amount int64, belowReserve bool) *JsonValidatedBid {
+		return &JsonValidatedBid{
+			ChainId:               (*hexutil.Big)(chainId),
+			Bidder:                common.HexToAddress(bidder),
+			ExpressLaneController: common.HexToAddress(bidder),
+			Round:                 hexutil.Uint64(a.UpcomingRound()),
+			Amount:                (*hexutil.Big)(big.NewInt(amount)),
+			ReservePrice:          (*hexutil.Big)(big.NewInt(100)),
+			Signature:             make([]byte, 65),
+			BelowReservePrice:     belowReserve,
+		}
+	}
+	client := &fakeAuctioneerClient{baseFee: big.NewInt(1)}
+	nextRound := func() {
+		a.closeRound(a.UpcomingRound())
+		a.roundTimingInfo.Offset = a.roundTimingInfo.Offset.Add(-a.roundTimingInfo.Round)
+	}
+
+	// A round without any bid is not one priced out by the reserve price.
+	result, err := a.resolveAuctionWithClient(ctx, client, true)
+	require.NoError(t, err)
+	require.Equal(t, RoundStatusNoBids, result.Outcome)
+	require.Zero(t, a.getMetrics().allBelowReserve.Snapshot().Count())
+	nextRound()
+
+	for _, amount := range []int64{40, 90, 60} {
+		a.receiveValidatedBid(bid(common.BigToAddress(big.NewInt(amount)).Hex(), amount, true))
+	}
+	count, highest := a.belowReserve.of(a.UpcomingRound())
+	require.Equal(t, uint64(3), count)
+	require.Equal(t, big.NewInt(90), highest.Amount)
+	result, err = a.resolveAuctionWithClient(ctx, client, true)
+	require.NoError(t, err)
+	require.Equal(t, RoundStatusNoBids, result.Outcome)
+	require.Equal(t, int64(1), a.getMetrics().allBelowReserve.Snapshot().Count())
+	require.Equal(t, int64(90), a.getMetrics().highestBelowReserve.Snapshot().Value())
+
+	// The tally doesn't carry over to the next round.
+	nextRound()
+	count, _ = a.belowReserve.of(a.UpcomingRound())
+	require.Zero(t, count)
+
+	// Nor is a round with an eligible bid reported.
+	a.receiveValidatedBid(bid("0x1", 50, true))
+	a.receiveValidatedBid(bid("0x2", 150, false))
+	result, err = a.resolveAuctionWithClient(ctx, client, true)
+	require.NoError(t, err)
+	require.Equal(t, RoundStatusResolved, result.Outcome)
+	require.Equal(t, int64(1), a.getMetrics().allBelowReserve.Snapshot().Count())
+}
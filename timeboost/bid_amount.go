@@ -0,0 +1,83 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Units bid amounts can be written in, as decimal strings.
+const (
+	BidAmountWei   = "wei"
+	BidAmountGwei  = "gwei"
+	BidAmountEther = "ether"
+)
+
+// Number of decimals of each unit, relative to wei.
+var bidAmountUnitDecimals = map[string]int{
+	BidAmountWei:   0,
+	BidAmountGwei:  9,
+	BidAmountEther: 18,
+}
+
+// An unsigned decimal, optionally followed by a unit, e.g. "1.5 gwei".
+// Exponents, signs and hex are deliberately not accepted.
+var bidAmountPattern = regexp.MustCompile(`^([0-9]+)(?:\.([0-9]+))?\s*([a-zA-Z]*)$`)
+
+// ParseBidAmount parses a bid amount written as a decimal string with an
+// optional unit, e.g. "1.5 gwei" or "0.01 ether". Amounts without a unit are
+// in wei, and may not have more decimals than their unit. Malformed amounts
+// and amounts that don't fit in 256 bits are rejected with ErrMalformedData.
+func ParseBidAmount(s string) (*big.Int, error) {
+	match := bidAmountPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if match == nil {
+		return nil, errors.Wrapf(ErrMalformedData, "bid amount %q is not a decimal number with an optional unit", s)
+	}
+	whole, fraction, unit := match[1], match[2], strings.ToLower(match[3])
+	if unit == "" {
+		unit = BidAmountWei
+	}
+	decimals, ok := bidAmountUnitDecimals[unit]
+	if !ok {
+		return nil, errors.Wrapf(ErrMalformedData, "bid amount %q has unknown unit %q, must be %s, %s or %s", s, unit, BidAmountWei, BidAmountGwei, BidAmountEther)
+	}
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > decimals {
+		return nil, errors.Wrapf(ErrMalformedData, "bid amount %q has more than %d decimals allowed in %s", s, decimals, unit)
+	}
+	amount, ok := new(big.Int).SetString(whole+fraction+strings.Repeat("0", decimals-len(fraction)), 10)
+	if !ok {
+		return nil, errors.Wrapf(ErrMalformedData, "bid amount %q is not a decimal number", s)
+	}
+	if amount.BitLen() > 256 {
+		return nil, errors.Wrapf(ErrMalformedData, "bid amount %q does not fit in 256 bits", s)
+	}
+	return amount, nil
+}
+
+// FormatBidAmount writes a bid amount as a decimal string in the given unit,
+// followed by the unit, e.g. "1.5 gwei", so that ParseBidAmount reads it back
+// as the same amount.
+func FormatBidAmount(amount *big.Int, unit string) (string, error) {
+	decimals, ok := bidAmountUnitDecimals[unit]
+	if !ok {
+		return "", errors.Errorf("unknown bid amount unit %q, must be %s, %s or %s", unit, BidAmountWei, BidAmountGwei, BidAmountEther)
+	}
+	if amount == nil || amount.Sign() < 0 {
+		return "", errors.Wrap(ErrMalformedData, "bid amount is empty or negative")
+	}
+	digits := amount.String()
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	whole, fraction := digits[:len(digits)-decimals], strings.TrimRight(digits[len(digits)-decimals:], "0")
+	if fraction != "" {
+		whole += "." + fraction
+	}
+	return whole + " " + unit, nil
+}
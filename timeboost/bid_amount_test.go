@@ -0,0 +1,98 @@
+package timeboost
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestParseBidAmount(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		amount   string
+		expected *big.Int
+	}{
+		{"100", big.NewInt(100)},
+		{"100 wei", big.NewInt(100)},
+		{"1.5 gwei", big.NewInt(1_500_000_000)},
+		{"1.5gwei", big.NewInt(1_500_000_000)},
+		{" 2 GWEI ", big.NewInt(2_000_000_000)},
+		{"0.000000001 ether", big.NewInt(1_000_000_000)},
+		{"1.000 wei", big.NewInt(1)},
+		{"0", big.NewInt(0)},
+	}
+	for _, tt := range tests {
+		amount, err := ParseBidAmount(tt.amount)
+		require.NoError(t, err, tt.amount)
+		require.Equal(t, tt.expected, amount, tt.amount)
+	}
+
+	for _, malformed := range []string{
+		"",
+		"gwei",
+		"-1",
+		"+1",
+		"1e9",
+		"0x10",
+		"1.5",
+		"1.5 wei",
+		"1.0000000001 gwei",
+		"1,5 gwei",
+		"1 finney",
+		"1 2",
+		"1.",
+		".5 gwei",
+		"115792089237316195423570985008687907853269984665640564039457584007913129639936",
+	} {
+		_, err := ParseBidAmount(malformed)
+		require.ErrorIs(t, err, ErrMalformedData, malformed)
+	}
+}
+
+func TestFormatBidAmount(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		amount   *big.Int
+		unit     string
+		expected string
+	}{
+		{big.NewInt(100), BidAmountWei, "100 wei"},
+		{big.NewInt(1_500_000_000), BidAmountGwei, "1.5 gwei"},
+		{big.NewInt(5), BidAmountGwei, "0.000000005 gwei"},
+		{big.NewInt(2_000_000_000), BidAmountGwei, "2 gwei"},
+		{big.NewInt(0), BidAmountEther, "0 ether"},
+	}
+	for _, tt := range tests {
+		formatted, err := FormatBidAmount(tt.amount, tt.unit)
+		require.NoError(t, err)
+		require.Equal(t, tt.expected, formatted)
+		parsed, err := ParseBidAmount(formatted)
+		require.NoError(t, err)
+		require.Equal(t, tt.amount, parsed)
+	}
+
+	_, err := FormatBidAmount(big.NewInt(1), "finney")
+	require.Error(t, err)
+	_, err = FormatBidAmount(big.NewInt(-1), BidAmountWei)
+	require.ErrorIs(t, err, ErrMalformedData)
+}
+
+func TestBidFromJsonDecimalAmount(t *testing.T) {
+	t.Parallel()
+	bid, err := bidFromJson(&JsonBid{AmountDecimal: "1.5 gwei"})
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1_500_000_000), bid.Amount)
+
+	// Both amounts may be given as long as they match.
+	bid, err = bidFromJson(&JsonBid{Amount: (*hexutil.Big)(big.NewInt(1_500_000_000)), AmountDecimal: "1.5 gwei"})
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1_500_000_000), bid.Amount)
+
+	_, err = bidFromJson(&JsonBid{Amount: (*hexutil.Big)(big.NewInt(1)), AmountDecimal: "1.5 gwei"})
+	require.ErrorIs(t, err, ErrMalformedData)
+	_, err = bidFromJson(&JsonBid{AmountDecimal: "1.5"})
+	require.ErrorIs(t, err, ErrMalformedData)
+}
@@ -0,0 +1,85 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// BidBackpressure selects what becomes of a bid consumed from the bid
+// validators while the queue of bids waiting to be cached is full, which only
+// happens if bids arrive faster than the auctioneer can cache them.
+type BidBackpressure uint8
+
+const (
+	// BlockOnFullQueue is the default. Consuming bids is held up until there is
+	// room in the queue, for at most the timeout if one is set, after which the
+	// bid is dropped. Without a timeout, no bid is ever dropped, but the bid
+	// validators' stream backs up for as long as the queue is full.
+	BlockOnFullQueue BidBackpressure = iota
+	// DropOnFullQueue drops the bid right away, so that consuming bids is never
+	// held up.
+	DropOnFullQueue
+)
+
+func (b BidBackpressure) String() string {
+	switch b {
+	case BlockOnFullQueue:
+		return "block"
+	case DropOnFullQueue:
+		return "drop"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(b))
+	}
+}
+
+// WithBidBackpressure sets what becomes of bids consumed while the queue of
+// bids waiting to be cached is full, and for BlockOnFullQueue how long to wait
+// for room before dropping them, zero waiting indefinitely. Dropped bids are
+// counted. By default, consuming bids blocks indefinitely.
+func WithBidBackpressure(backpressure BidBackpressure, timeout time.Duration) AuctioneerServerOpt {
+	return func(a *AuctioneerServer) {
+		a.bidBackpressure = backpressure
+		a.bidQueueTimeout = timeout
+	}
+}
+
+// enqueueBid queues a consumed bid to be cached, applying the configured
+// backpressure while the queue is full. It reports whether the bid was queued,
+// as opposed to dropped, or abandoned because ctx is done.
+func (a *AuctioneerServer) enqueueBid(ctx context.Context, bid *JsonValidatedBid) bool {
+	select {
+	case a.bidsReceiver <- bid:
+		return true
+	default:
+	}
+	if a.bidBackpressure == DropOnFullQueue {
+		a.dropQueuedBid(bid)
+		return false
+	}
+	var timeout <-chan time.Time
+	if a.bidQueueTimeout > 0 {
+		timer := time.NewTimer(a.bidQueueTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case a.bidsReceiver <- bid:
+		return true
+	case <-timeout:
+		a.dropQueuedBid(bid)
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (a *AuctioneerServer) dropQueuedBid(bid *JsonValidatedBid) {
+	a.getMetrics().queueFullBids.Inc(1)
+	log.Warn("Dropping bid, queue of bids to cache is full", "bidder", bid.Bidder, "round", bid.Round, "correlationId", bid.CorrelationId, "queued", len(a.bidsReceiver), "backpressure", a.bidBackpressure, "timeout", a.bidQueueTimeout)
+}
@@ -0,0 +1,66 @@
+package timeboost
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+func TestBidBackpressure(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	newAuctioneer := func(backpressure BidBackpressure, timeout time.Duration) *AuctioneerServer {
+		a := &AuctioneerServer{bidsReceiver: make(chan *JsonValidatedBid, 1)}
+		WithMetricsRegistry(metrics.NewRegistry(), "arb/auctioneer/")(a)
+		WithBidBackpressure(backpressure, timeout)(a)
+		// Fill the queue.
+		require.True(t, a.enqueueBid(ctx, &JsonValidatedBid{}))
+		return a
+	}
+
+	t.Run("Drop", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer(DropOnFullQueue, time.Hour)
+		start := time.Now()
+		require.False(t, a.enqueueBid(ctx, &JsonValidatedBid{}))
+		require.Less(t, time.Since(start), time.Second)
+		require.Equal(t, int64(1), a.getMetrics().queueFullBids.Snapshot().Count())
+		require.Len(t, a.bidsReceiver, 1)
+	})
+
+	t.Run("BlockWithTimeout", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer(BlockOnFullQueue, 50*time.Millisecond)
+		start := time.Now()
+		require.False(t, a.enqueueBid(ctx, &JsonValidatedBid{}))
+		require.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+		require.Equal(t, int64(1), a.getMetrics().queueFullBids.Snapshot().Count())
+
+		// A bid is queued once there is room before the timeout.
+		a.bidQueueTimeout = time.Minute
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			<-a.bidsReceiver
+		}()
+		require.True(t, a.enqueueBid(ctx, &JsonValidatedBid{}))
+		require.Equal(t, int64(1), a.getMetrics().queueFullBids.Snapshot().Count())
+	})
+
+	t.Run("BlockIndefinitely", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer(BlockOnFullQueue, 0)
+		ctx, cancel := context.WithCancel(ctx)
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			cancel()
+		}()
+		// Only abandoned once shutting down, without being counted as dropped.
+		require.False(t, a.enqueueBid(ctx, &JsonValidatedBid{}))
+		require.Error(t, ctx.Err())
+		require.Zero(t, a.getMetrics().queueFullBids.Snapshot().Count())
+	})
+}
@@ -1,71 +1,207 @@
 package timeboost
 
 import (
+	"math/big"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/metrics"
 )
 
 type bidCache struct {
 	auctionContractDomainSeparator [32]byte
 	sync.RWMutex
 	bidsByExpressLaneControllerAddr map[common.Address]*ValidatedBid
+	// maxBids caps the number of bids held, 0 means unbounded.
+	maxBids int
+	// ranking tracks the cached bids in auction order.
+	ranking *bidRanking
+	// metrics are those of the auctioneer owning the cache.
+	metrics *auctioneerMetrics
 }
 
 func newBidCache(auctionContractDomainSeparator [32]byte) *bidCache {
 	return &bidCache{
 		bidsByExpressLaneControllerAddr: make(map[common.Address]*ValidatedBid),
 		auctionContractDomainSeparator:  auctionContractDomainSeparator,
+		ranking:                         newBidRanking(auctionContractDomainSeparator),
+		metrics:                         defaultAuctioneerMetrics,
 	}
 }
 
-func (bc *bidCache) add(bid *ValidatedBid) {
+// add caches a bid, replacing any earlier bid for the same express lane
+// controller. Once the cache is full, a bid for a new express lane controller
+// is only added if it outbids the lowest ranked cached bid, which is then
+// evicted and returned. Otherwise the bid isn't added.
+func (bc *bidCache) add(bid *ValidatedBid) (added bool, evicted *ValidatedBid) {
 	bc.Lock()
 	defer bc.Unlock()
+	_, replacing := bc.bidsByExpressLaneControllerAddr[bid.ExpressLaneController]
+	if !replacing && bc.maxBids > 0 && len(bc.bidsByExpressLaneControllerAddr) >= bc.maxBids {
+		evicted = bc.ranking.lowestRanked()
+		if evicted == nil || bid.Amount.Cmp(evicted.Amount) <= 0 {
+			bc.metrics.cacheRejectedBids.Inc(1)
+			return false, nil
+		}
+		delete(bc.bidsByExpressLaneControllerAddr, evicted.ExpressLaneController)
+		bc.ranking.remove(evicted.ExpressLaneController)
+		bc.metrics.cacheEvictedBids.Inc(1)
+	}
 	bc.bidsByExpressLaneControllerAddr[bid.ExpressLaneController] = bid
+	bc.ranking.set(bid)
+	return true, evicted
+}
+
+// remove evicts every bid placed by the given bidder, whichever express lane
+// controller it named.
+func (bc *bidCache) remove(bidder common.Address) {
+	bc.Lock()
+	defer bc.Unlock()
+	for controller, bid := range bc.bidsByExpressLaneControllerAddr {
+		if bid.Bidder == bidder {
+			delete(bc.bidsByExpressLaneControllerAddr, controller)
+			bc.ranking.remove(controller)
+		}
+	}
+}
+
+// removeForOtherContract evicts every bid signed for an auction contract other
+// than the given one, returning how many were evicted.
+func (bc *bidCache) removeForOtherContract(auctionContractAddr common.Address) int {
+	bc.Lock()
+	defer bc.Unlock()
+	removed := 0
+	for controller, bid := range bc.bidsByExpressLaneControllerAddr {
+		if bid.AuctionContractAddress != auctionContractAddr {
+			delete(bc.bidsByExpressLaneControllerAddr, controller)
+			bc.ranking.remove(controller)
+			removed++
+		}
+	}
+	return removed
 }
 
-// TwoTopBids returns the top two bids for the given chain ID and round
+// clear removes every bid from the cache. The cache is cleared in place rather
+// than replaced, so that concurrent readers and writers always see a consistent
+// cache without synchronizing on the pointer to it.
+func (bc *bidCache) clear() {
+	bc.Lock()
+	defer bc.Unlock()
+	bc.bidsByExpressLaneControllerAddr = make(map[common.Address]*ValidatedBid)
+	bc.ranking.clear()
+}
+
+// contains reports whether this exact bid is in the cache, as opposed to a
+// later bid for the same express lane controller.
+func (bc *bidCache) contains(bid *ValidatedBid) bool {
+	bc.RLock()
+	defer bc.RUnlock()
+	return bc.bidsByExpressLaneControllerAddr[bid.ExpressLaneController] == bid
+}
+
+// setDomainSeparator changes the domain separator used to break ties, e.g.
+// after migrating to a new auction contract.
+func (bc *bidCache) setDomainSeparator(auctionContractDomainSeparator [32]byte) {
+	bc.Lock()
+	defer bc.Unlock()
+	bc.auctionContractDomainSeparator = auctionContractDomainSeparator
+	bc.ranking.setDomainSeparator(auctionContractDomainSeparator)
+}
+
+// auctionResult is the bids a round is resolved with.
 type auctionResult struct {
 	firstPlace  *ValidatedBid
 	secondPlace *ValidatedBid
+	// reservePrice is the reserve price a round with a single bid is resolved
+	// at, if known.
+	reservePrice *big.Int
+}
+
+// emptyCopy returns an empty cache ranking and capping bids like this one, for
+// replaying bids. Its metrics are discarded, so replays don't count as bids
+// received.
+func (bc *bidCache) emptyCopy() *bidCache {
+	bc.RLock()
+	defer bc.RUnlock()
+	copied := newBidCache(bc.auctionContractDomainSeparator)
+	copied.maxBids = bc.maxBids
+	copied.metrics = newAuctioneerMetrics(metrics.NewRegistry(), "")
+	return copied
 }
 
+// size returns the number of cached bids, at most one per express lane
+// controller.
 func (bc *bidCache) size() int {
 	bc.RLock()
 	defer bc.RUnlock()
 	return len(bc.bidsByExpressLaneControllerAddr)
+}
 
+// distinctBidders returns the number of bidders the cached bids were placed
+// by, which is below size when a bidder bid for several express lane
+// controllers.
+func (bc *bidCache) distinctBidders() int {
+	bc.RLock()
+	defer bc.RUnlock()
+	return bc.distinctBiddersLocked()
 }
 
-// topTwoBids returns the top two bids in the cache.
-func (bc *bidCache) topTwoBids() *auctionResult {
+// sizeAndDistinctBidders returns both size and distinctBidders, taken under a
+// single read lock so that they describe the same cache.
+func (bc *bidCache) sizeAndDistinctBidders() (int, int) {
 	bc.RLock()
 	defer bc.RUnlock()
+	return len(bc.bidsByExpressLaneControllerAddr), bc.distinctBiddersLocked()
+}
 
-	result := &auctionResult{}
+func (bc *bidCache) distinctBiddersLocked() int {
+	bidders := make(map[common.Address]struct{}, len(bc.bidsByExpressLaneControllerAddr))
+	for _, bid := range bc.bidsByExpressLaneControllerAddr {
+		bidders[bid.Bidder] = struct{}{}
+	}
+	return len(bidders)
+}
 
+// sizeByRound returns the number of cached bids for each round. Their sum is size.
+func (bc *bidCache) sizeByRound() map[uint64]int {
+	bc.RLock()
+	defer bc.RUnlock()
+	sizes := make(map[uint64]int)
 	for _, bid := range bc.bidsByExpressLaneControllerAddr {
-		if result.firstPlace == nil {
-			result.firstPlace = bid
-		} else if bid.Amount.Cmp(result.firstPlace.Amount) > 0 {
-			result.secondPlace = result.firstPlace
-			result.firstPlace = bid
-		} else if bid.Amount.Cmp(result.firstPlace.Amount) == 0 {
-			if bid.BigIntHash(bc.auctionContractDomainSeparator).Cmp(result.firstPlace.BigIntHash(bc.auctionContractDomainSeparator)) > 0 {
-				result.secondPlace = result.firstPlace
-				result.firstPlace = bid
-			} else if result.secondPlace == nil || bid.BigIntHash(bc.auctionContractDomainSeparator).Cmp(result.secondPlace.BigIntHash(bc.auctionContractDomainSeparator)) > 0 {
-				result.secondPlace = bid
-			}
-		} else if result.secondPlace == nil || bid.Amount.Cmp(result.secondPlace.Amount) > 0 {
-			result.secondPlace = bid
-		} else if bid.Amount.Cmp(result.secondPlace.Amount) == 0 {
-			if bid.BigIntHash(bc.auctionContractDomainSeparator).Cmp(result.secondPlace.BigIntHash(bc.auctionContractDomainSeparator)) > 0 {
-				result.secondPlace = bid
-			}
-		}
+		sizes[bid.Round]++
+	}
+	return sizes
+}
+
+// snapshot returns a copy of every bid currently in the cache, taken under a
+// single read lock. The returned bids are deep copies, so callers can iterate
+// and inspect them without racing with, or leaking mutations into, the cache.
+func (bc *bidCache) snapshot() []*ValidatedBid {
+	bc.RLock()
+	defer bc.RUnlock()
+	bids := make([]*ValidatedBid, 0, len(bc.bidsByExpressLaneControllerAddr))
+	for _, bid := range bc.bidsByExpressLaneControllerAddr {
+		bids = append(bids, bid.clone())
 	}
+	return bids
+}
+
+// bidderRank returns the rank of the bidder's best cached bid among the best
+// bids of every bidder, starting at 1, or 0 if it has no cached bid.
+func (bc *bidCache) bidderRank(bidder common.Address) int {
+	bc.RLock()
+	defer bc.RUnlock()
+	return bc.ranking.bidderRank(bidder)
+}
 
+// topTwoBids returns the top two bids in the cache. They are tracked as bids
+// are added and removed, so this doesn't depend on the number of cached bids.
+func (bc *bidCache) topTwoBids() *auctionResult {
+	bc.RLock()
+	defer bc.RUnlock()
+	result := bc.ranking.topTwo()
+	if checkInvariants {
+		bc.checkTopTwoBids(result)
+	}
 	return result
 }
@@ -2,14 +2,21 @@ package timeboost
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math/big"
+	"math/rand"
 	"net"
+	"sort"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -126,8 +133,9 @@ func TestTopTwoBids(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			bc := &bidCache{
-				bidsByExpressLaneControllerAddr: tt.bids,
+			bc := newBidCache([32]byte{})
+			for _, bid := range tt.bids {
+				bc.add(bid)
 			}
 			result := bc.topTwoBids()
 			if (result.firstPlace == nil) != (tt.expected.firstPlace == nil) || (result.secondPlace == nil) != (tt.expected.secondPlace == nil) {
@@ -143,6 +151,284 @@ func TestTopTwoBids(t *testing.T) {
 	}
 }
 
+// naiveTopTwoBids ranks every cached bid from scratch.
+func naiveTopTwoBids(bc *bidCache) *auctionResult {
+	bids := make([]*ValidatedBid, 0, len(bc.bidsByExpressLaneControllerAddr))
+	for _, bid := range bc.bidsByExpressLaneControllerAddr {
+		bids = append(bids, bid)
+	}
+	sort.Slice(bids, func(i, j int) bool {
+		if c := bids[i].Amount.Cmp(bids[j].Amount); c != 0 {
+			return c > 0
+		}
+		return bids[i].BigIntHash(bc.auctionContractDomainSeparator).Cmp(bids[j].BigIntHash(bc.auctionContractDomainSeparator)) > 0
+	})
+	result := &auctionResult{}
+	if len(bids) > 0 {
+		result.firstPlace = bids[0]
+	}
+	if len(bids) > 1 {
+		result.secondPlace = bids[1]
+	}
+	return result
+}
+
+func TestBidCacheTopTwoBidsMatchesFullScan(t *testing.T) {
+	t.Parallel()
+	for seed := int64(0); seed < 20; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		bc := newBidCache([32]byte{byte(seed)})
+		if seed%2 == 1 {
+			bc.maxBids = 8
+		}
+		for op := 0; op < 500; op++ {
+			// Few controllers, bidders and amounts, so that bids are often
+			// replaced, raised, lowered and tied.
+			switch n := rng.Intn(100); {
+			case n < 70:
+				bc.add(&ValidatedBid{
+					ExpressLaneController: common.BigToAddress(big.NewInt(rng.Int63n(16) + 1)),
+					Bidder:                common.BigToAddress(big.NewInt(rng.Int63n(6) + 1)),
+					Round:                 1,
+					Amount:                big.NewInt(rng.Int63n(10)),
+				})
+			case n < 95:
+				bc.remove(common.BigToAddress(big.NewInt(rng.Int63n(6) + 1)))
+			case n < 98:
+				bc.setDomainSeparator([32]byte{byte(rng.Intn(256))})
+			default:
+				bc.clear()
+			}
+			expected := naiveTopTwoBids(bc)
+			result := bc.topTwoBids()
+			require.Same(t, expected.firstPlace, result.firstPlace, "seed %d, op %d", seed, op)
+			require.Same(t, expected.secondPlace, result.secondPlace, "seed %d, op %d", seed, op)
+			// The bid a full cache evicts is the lowest ranked.
+			var lowest *rankedBid
+			for _, bid := range bc.bidsByExpressLaneControllerAddr {
+				ranked := &rankedBid{bid: bid, hash: bid.BigIntHash(bc.auctionContractDomainSeparator)}
+				if lowest == nil || exactlyOutranks(lowest, ranked) {
+					lowest = ranked
+				}
+			}
+			require.Same(t, bidOf(lowest), bc.ranking.lowestRanked(), "seed %d, op %d", seed, op)
+		}
+	}
+}
+
+func TestBidCacheSnapshot(t *testing.T) {
+	t.Parallel()
+	bc := newBidCache([32]byte{})
+	bc.add(&ValidatedBid{
+		ChainId:               big.NewInt(1),
+		Bidder:                common.HexToAddress("0x1"),
+		ExpressLaneController: common.HexToAddress("0x1"),
+		Round:                 1,
+		Amount:                big.NewInt(100),
+		Signature:             []byte{1, 2, 3},
+	})
+	bc.add(&ValidatedBid{
+		ChainId:               big.NewInt(1),
+		Bidder:                common.HexToAddress("0x2"),
+		ExpressLaneController: common.HexToAddress("0x2"),
+		Round:                 1,
+		Amount:                big.NewInt(200),
+		Signature:             []byte{4, 5, 6},
+	})
+
+	snapshot := bc.snapshot()
+	require.Len(t, snapshot, 2)
+
+	// Mutating the snapshot must not leak into the cache.
+	for _, bid := range snapshot {
+		bid.Amount.SetInt64(0)
+		bid.Signature[0] = 0xff
+	}
+	for _, bid := range bc.snapshot() {
+		require.NotZero(t, bid.Amount.Sign())
+		require.NotEqual(t, byte(0xff), bid.Signature[0])
+	}
+
+	// Adding to the cache after taking a snapshot must not change the snapshot.
+	bc.add(&ValidatedBid{
+		Bidder:                common.HexToAddress("0x3"),
+		ExpressLaneController: common.HexToAddress("0x3"),
+		Round:                 1,
+		Amount:                big.NewInt(300),
+	})
+	require.Len(t, snapshot, 2)
+	require.Len(t, bc.snapshot(), 3)
+}
+
+func TestBidCacheRemove(t *testing.T) {
+	t.Parallel()
+	bc := newBidCache([32]byte{})
+	bidder1 := common.HexToAddress("0x1")
+	bidder2 := common.HexToAddress("0x2")
+	bidder3 := common.HexToAddress("0x3")
+	// bidder1 places two bids towards different express lane controllers.
+	bc.add(&ValidatedBid{Bidder: bidder1, ExpressLaneController: common.HexToAddress("0xa"), Amount: big.NewInt(300)})
+	bc.add(&ValidatedBid{Bidder: bidder1, ExpressLaneController: common.HexToAddress("0xb"), Amount: big.NewInt(250)})
+	bc.add(&ValidatedBid{Bidder: bidder2, ExpressLaneController: common.HexToAddress("0xc"), Amount: big.NewInt(200)})
+	bc.add(&ValidatedBid{Bidder: bidder3, ExpressLaneController: common.HexToAddress("0xd"), Amount: big.NewInt(100)})
+
+	require.Equal(t, 4, bc.size())
+	require.Equal(t, 3, bc.distinctBidders())
+	result := bc.topTwoBids()
+	require.Equal(t, bidder1, result.firstPlace.Bidder)
+	require.Equal(t, bidder1, result.secondPlace.Bidder)
+
+	bc.remove(bidder1)
+	require.Equal(t, 2, bc.size())
+	bids, bidders := bc.sizeAndDistinctBidders()
+	require.Equal(t, 2, bids)
+	require.Equal(t, 2, bidders)
+	result = bc.topTwoBids()
+	require.Equal(t, bidder2, result.firstPlace.Bidder)
+	require.Equal(t, bidder3, result.secondPlace.Bidder)
+
+	// Removing an unknown bidder is a no-op.
+	bc.remove(common.HexToAddress("0x4"))
+	require.Equal(t, 2, bc.size())
+}
+
+func TestBidderRanking(t *testing.T) {
+	t.Parallel()
+	a := &AuctioneerServer{bidCache: newBidCache([32]byte{})}
+	bidder1 := common.HexToAddress("0x1")
+	bidder2 := common.HexToAddress("0x2")
+	bidder3 := common.HexToAddress("0x3")
+	rank, isTopTwo := a.BidderRanking(bidder1)
+	require.Equal(t, 0, rank)
+	require.False(t, isTopTwo)
+
+	// Only a bidder's best bid counts towards its ranking.
+	a.bidCache.add(&ValidatedBid{Bidder: bidder1, ExpressLaneController: common.HexToAddress("0xa"), Amount: big.NewInt(300)})
+	a.bidCache.add(&ValidatedBid{Bidder: bidder1, ExpressLaneController: common.HexToAddress("0xb"), Amount: big.NewInt(250)})
+	a.bidCache.add(&ValidatedBid{Bidder: bidder2, ExpressLaneController: common.HexToAddress("0xc"), Amount: big.NewInt(200)})
+	a.bidCache.add(&ValidatedBid{Bidder: bidder3, ExpressLaneController: common.HexToAddress("0xd"), Amount: big.NewInt(100)})
+	for bidder, expected := range map[common.Address]int{bidder1: 1, bidder2: 2, bidder3: 3} {
+		rank, isTopTwo = a.BidderRanking(bidder)
+		require.Equal(t, expected, rank)
+		require.Equal(t, expected <= 2, isTopTwo)
+	}
+
+	api := &AuctioneerBidderAPI{a}
+	require.Equal(t, &BidderRankingResult{Rank: 3, IsTopTwo: false}, api.BidderRanking(bidder3))
+	a.bidCache.remove(bidder1)
+	require.Equal(t, &BidderRankingResult{Rank: 2, IsTopTwo: true}, api.BidderRanking(bidder3))
+}
+
+func TestBidStats(t *testing.T) {
+	t.Parallel()
+	a := &AuctioneerServer{bidCache: newBidCache([32]byte{})}
+	api := &AuctioneerBidderAPI{a}
+	require.Equal(t, &BidStatsResult{Round: hexutil.Uint64(a.UpcomingRound())}, api.BidStats())
+
+	bidder1 := common.HexToAddress("0x1111")
+	bidder2 := common.HexToAddress("0x2222")
+	a.bidCache.add(&ValidatedBid{Bidder: bidder1, ExpressLaneController: common.HexToAddress("0xa"), Amount: big.NewInt(300)})
+	a.bidCache.add(&ValidatedBid{Bidder: bidder1, ExpressLaneController: common.HexToAddress("0xb"), Amount: big.NewInt(100)})
+	a.bidCache.add(&ValidatedBid{Bidder: bidder2, ExpressLaneController: common.HexToAddress("0xc"), Amount: big.NewInt(200)})
+	require.Equal(t, &BidStatsResult{
+		Round:           hexutil.Uint64(a.UpcomingRound()),
+		Count:           3,
+		DistinctBidders: 2,
+		Min:             (*hexutil.Big)(big.NewInt(100)),
+		Max:             (*hexutil.Big)(big.NewInt(300)),
+		Median:          (*hexutil.Big)(big.NewInt(200)),
+		Mean:            (*hexutil.Big)(big.NewInt(200)),
+	}, api.BidStats())
+
+	// The median of an even number of bids is the mean of the middle two.
+	a.bidCache.add(&ValidatedBid{Bidder: bidder2, ExpressLaneController: common.HexToAddress("0xd"), Amount: big.NewInt(251)})
+	stats := api.BidStats()
+	require.Equal(t, big.NewInt(225), stats.Median.ToInt())
+	require.Equal(t, big.NewInt(212), stats.Mean.ToInt())
+
+	// Nothing ties the statistics to a bidder.
+	encoded, err := json.Marshal(stats)
+	require.NoError(t, err)
+	for _, address := range []string{bidder1.Hex(), bidder2.Hex(), common.HexToAddress("0xa").Hex()} {
+		require.NotContains(t, string(encoded), address[2:])
+	}
+}
+
+func TestTieBreakingIsReproducible(t *testing.T) {
+	t.Parallel()
+	domainSeparator := [32]byte{1, 2, 3}
+	var bids []*ValidatedBid
+	for i := 0; i < 20; i++ {
+		controller := common.BigToAddress(big.NewInt(int64(i + 1)))
+		bids = append(bids, &ValidatedBid{Bidder: controller, ExpressLaneController: controller, Round: 1, Amount: big.NewInt(100)})
+	}
+	resolve := func(seed int64) *auctionResult {
+		a := &AuctioneerServer{bidCache: newBidCache(domainSeparator)}
+		rng := rand.New(rand.NewSource(seed))
+		for _, i := range rng.Perm(len(bids)) {
+			a.bidCache.add(bids[i])
+		}
+		return a.bidCache.topTwoBids()
+	}
+
+	// Two auctioneers receiving the same tied bids in different orders
+	// resolve the round identically.
+	expected := resolve(0)
+	for seed := int64(1); seed < 10; seed++ {
+		result := resolve(seed)
+		require.Equal(t, expected.firstPlace.ExpressLaneController, result.firstPlace.ExpressLaneController, "seed %d", seed)
+		require.Equal(t, expected.secondPlace.ExpressLaneController, result.secondPlace.ExpressLaneController, "seed %d", seed)
+	}
+}
+
+func TestBidCacheSizeByRound(t *testing.T) {
+	t.Parallel()
+	bc := newBidCache([32]byte{})
+	require.Empty(t, bc.sizeByRound())
+	bc.add(&ValidatedBid{ExpressLaneController: common.HexToAddress("0xa"), Round: 1, Amount: big.NewInt(1)})
+	bc.add(&ValidatedBid{ExpressLaneController: common.HexToAddress("0xb"), Round: 2, Amount: big.NewInt(1)})
+	bc.add(&ValidatedBid{ExpressLaneController: common.HexToAddress("0xc"), Round: 2, Amount: big.NewInt(1)})
+	// Replacing a controller's bid moves it to the round of the new bid.
+	bc.add(&ValidatedBid{ExpressLaneController: common.HexToAddress("0xa"), Round: 2, Amount: big.NewInt(2)})
+	require.Equal(t, map[uint64]int{2: 3}, bc.sizeByRound())
+	require.Equal(t, 3, bc.size())
+}
+
+func TestBidCacheMaxBids(t *testing.T) {
+	t.Parallel()
+	bc := newBidCache([32]byte{})
+	bc.maxBids = 2
+	bid := func(controller string, amount int64) *ValidatedBid {
+		return &ValidatedBid{ExpressLaneController: common.HexToAddress(controller), Amount: big.NewInt(amount)}
+	}
+	for _, b := range []*ValidatedBid{bid("0xa", 10), bid("0xb", 20)} {
+		added, evicted := bc.add(b)
+		require.True(t, added)
+		require.Nil(t, evicted)
+	}
+
+	// Bids that wouldn't rank among the cached bids are rejected.
+	added, evicted := bc.add(bid("0xc", 10))
+	require.False(t, added)
+	require.Nil(t, evicted)
+	require.Equal(t, 2, bc.size())
+
+	// Higher bids evict the lowest cached bid.
+	added, evicted = bc.add(bid("0xc", 30))
+	require.True(t, added)
+	require.Equal(t, common.HexToAddress("0xa"), evicted.ExpressLaneController)
+	require.Equal(t, 2, bc.size())
+	result := bc.topTwoBids()
+	require.Equal(t, big.NewInt(30), result.firstPlace.Amount)
+	require.Equal(t, big.NewInt(20), result.secondPlace.Amount)
+
+	// Replacing the bid of a cached express lane controller is always allowed.
+	added, evicted = bc.add(bid("0xb", 5))
+	require.True(t, added)
+	require.Nil(t, evicted)
+	require.Equal(t, 2, bc.size())
+}
+
 func BenchmarkBidValidation(b *testing.B) {
 	b.StopTimer()
 	ctx, cancel := context.WithCancel(context.Background())
@@ -164,6 +450,94 @@ func BenchmarkBidValidation(b *testing.B) {
 	}
 }
 
+// BenchmarkCheckBid measures validating signed bids without a chain behind
+// the validator, so that it reflects the cost of validation itself.
+func BenchmarkCheckBid(b *testing.B) {
+	auctionContractAddr := common.Address{'a'}
+	bv := &BidValidator{
+		chainId: big.NewInt(1),
+		roundTimingInfo: RoundTimingInfo{
+			Offset:         time.Now(),
+			Round:          time.Hour,
+			AuctionClosing: time.Second,
+		},
+		reservePrice:            big.NewInt(1),
+		bidsPerSenderInRound:    make(map[common.Address]uint8),
+		maxBidsPerSenderInRound: 5,
+		auctionContractAddr:     auctionContractAddr,
+	}
+	balanceCheckerFn := func(_ *bind.CallOpts, _ common.Address) (*big.Int, error) {
+		return big.NewInt(10), nil
+	}
+	bids := make([]*Bid, 64)
+	for i := range bids {
+		bids[i] = buildValidBid(b, auctionContractAddr)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	var next atomic.Uint64
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			// Not counting the bids keeps the bidders below the per-round bid limit.
+			if _, err := bv.checkBid(bids[next.Add(1)%uint64(len(bids))], balanceCheckerFn, false); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+}
+
+func benchmarkBids(n int) []*ValidatedBid {
+	bids := make([]*ValidatedBid, n)
+	for i := range bids {
+		controller := common.BigToAddress(big.NewInt(int64(i + 1)))
+		bids[i] = &ValidatedBid{
+			ExpressLaneController: controller,
+			Bidder:                controller,
+			Amount:                big.NewInt(rand.Int63n(1_000_000) + 1),
+			Round:                 1,
+			Signature:             make([]byte, 65),
+		}
+	}
+	return bids
+}
+
+func BenchmarkBidCacheAdd(b *testing.B) {
+	bids := benchmarkBids(100_000)
+	for _, maxBids := range []int{0, 1_000} {
+		b.Run(fmt.Sprintf("maxBids=%d", maxBids), func(b *testing.B) {
+			bc := newBidCache([32]byte{})
+			bc.maxBids = maxBids
+			b.ReportAllocs()
+			b.ResetTimer()
+			var next atomic.Uint64
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					bc.add(bids[next.Add(1)%uint64(len(bids))])
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkTopTwoBids(b *testing.B) {
+	// The invariant check scans every bid, which is what this measures against.
+	defer func(enabled bool) { checkInvariants = enabled }(checkInvariants)
+	checkInvariants = false
+	for _, n := range []int{1_000, 100_000} {
+		b.Run(fmt.Sprintf("bids=%d", n), func(b *testing.B) {
+			bc := newBidCache([32]byte{})
+			for _, bid := range benchmarkBids(n) {
+				bc.add(bid)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				bc.topTwoBids()
+			}
+		})
+	}
+}
+
 func setupBidValidator(t testing.TB, ctx context.Context, redisURL string, testSetup *auctionSetup) (*BidValidator, string) {
 	randHttp := getRandomPort(t)
 	stackConf := node.Config{
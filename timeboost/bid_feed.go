@@ -0,0 +1,153 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"crypto/rand"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var bidFeedDroppedCounter = metrics.NewRegisteredCounter("arb/validator/bidfeed/dropped", nil)
+
+// bidFeedBuffer is the number of events a subscriber may fall behind by before
+// the oldest events are dropped for it.
+const bidFeedBuffer = 256
+
+// BidEvent is published to bid feed subscribers for every accepted bid.
+type BidEvent struct {
+	Round hexutil.Uint64 `json:"round"`
+	// Bidder is set unless the subscriber asked for bidders to be hashed.
+	Bidder *common.Address `json:"bidder,omitempty"`
+	// BidderHash identifies the bidder without revealing it, see bidFeed.
+	BidderHash *common.Hash `json:"bidderHash,omitempty"`
+	Amount     *hexutil.Big `json:"amount"`
+	Timestamp  time.Time    `json:"timestamp"`
+	// AmountDecimal is Amount in gwei, e.g. "1.5 gwei", see ParseBidAmount.
+	AmountDecimal string `json:"amountDecimal"`
+	// CorrelationId is the id the bid was submitted under. Like the bidder, it
+	// is left out for subscribers that asked for bidders to be hashed, as
+	// bidders may choose ids that identify them.
+	CorrelationId string `json:"correlationId,omitempty"`
+}
+
+// bidFeed fans accepted bids out to subscribers. Publishing never blocks: a
+// subscriber that falls behind loses its oldest events instead. Hashed bidders
+// are salted with a secret drawn when the feed is first used, so that they
+// can't be matched against known addresses, and are stable for the lifetime
+// of the validator.
+type bidFeed struct {
+	mutex       sync.Mutex
+	salt        []byte
+	subscribers map[*bidSubscriber]struct{}
+}
+
+type bidSubscriber struct {
+	events      chan *BidEvent
+	hashBidders bool
+}
+
+// subscribe returns the events of every bid accepted from now on, and a
+// function to stop receiving them.
+func (f *bidFeed) subscribe(buffer int, hashBidders bool) (<-chan *BidEvent, func()) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.subscribers == nil {
+		f.subscribers = make(map[*bidSubscriber]struct{})
+		f.salt = make([]byte, 32)
+		if _, err := rand.Read(f.salt); err != nil {
+			panic(err)
+		}
+	}
+	sub := &bidSubscriber{events: make(chan *BidEvent, buffer), hashBidders: hashBidders}
+	f.subscribers[sub] = struct{}{}
+	return sub.events, func() {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+		delete(f.subscribers, sub)
+	}
+}
+
+func (f *bidFeed) publish(bid *JsonValidatedBid, acceptedAt time.Time) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if len(f.subscribers) == 0 {
+		return
+	}
+	bidder := bid.Bidder
+	bidderHash := crypto.Keccak256Hash(f.salt, bidder.Bytes())
+	// Accepted bids always have a valid amount.
+	amountDecimal, _ := FormatBidAmount(bid.Amount.ToInt(), BidAmountGwei)
+	for sub := range f.subscribers {
+		event := &BidEvent{
+			Round:         bid.Round,
+			Amount:        bid.Amount,
+			Timestamp:     acceptedAt,
+			AmountDecimal: amountDecimal,
+		}
+		if sub.hashBidders {
+			event.BidderHash = &bidderHash
+		} else {
+			event.Bidder = &bidder
+			event.CorrelationId = bid.CorrelationId
+		}
+		select {
+		case sub.events <- event:
+			continue
+		default:
+		}
+		// Make room by dropping the oldest event, unless the subscriber just
+		// caught up.
+		select {
+		case <-sub.events:
+			bidFeedDroppedCounter.Inc(1)
+		default:
+		}
+		select {
+		case sub.events <- event:
+		default:
+			bidFeedDroppedCounter.Inc(1)
+		}
+	}
+}
+
+// Bids streams an event for every bid accepted by the validator from now on,
+// as the auctioneer_subscribe("bids", hashBidders) subscription over
+// websockets. If hashBidders is set, bidders are replaced by a salted hash.
+func (api *BidValidatorAPI) Bids(ctx context.Context, hashBidders *bool) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	bv := api.bidValidator
+	sub := notifier.CreateSubscription()
+	events, unsubscribe := bv.bidFeed.subscribe(bidFeedBuffer, hashBidders != nil && *hashBidders)
+	err := bv.StopWaiter.LaunchThreadSafe(func(ctx context.Context) {
+		defer unsubscribe()
+		for {
+			select {
+			case event := <-events:
+				if err := notifier.Notify(sub.ID, event); err != nil {
+					return
+				}
+			case <-sub.Err():
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+	if err != nil {
+		unsubscribe()
+		return nil, err
+	}
+	return sub, nil
+}
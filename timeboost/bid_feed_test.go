@@ -0,0 +1,59 @@
+package timeboost
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestBidFeed(t *testing.T) {
+	t.Parallel()
+	var feed bidFeed
+	bid := func(bidder string, amount int64) *JsonValidatedBid {
+		return &JsonValidatedBid{
+			Bidder: common.HexToAddress(bidder),
+			Round:  7,
+			Amount: (*hexutil.Big)(big.NewInt(amount)),
+		}
+	}
+	// Publishing without subscribers is a no-op.
+	feed.publish(bid("0x1", 1), time.Now())
+
+	plain, unsubscribePlain := feed.subscribe(2, false)
+	hashed, unsubscribeHashed := feed.subscribe(2, true)
+	defer unsubscribeHashed()
+	now := time.Now()
+	for amount := int64(1); amount <= 3; amount++ {
+		feed.publish(bid("0x1", amount), now)
+	}
+
+	// A subscriber falling behind loses the oldest events, without ever blocking the publisher.
+	for _, expected := range []int64{2, 3} {
+		event := <-plain
+		require.Equal(t, hexutil.Uint64(7), event.Round)
+		require.Equal(t, big.NewInt(expected), event.Amount.ToInt())
+		require.Equal(t, common.HexToAddress("0x1"), *event.Bidder)
+		require.Nil(t, event.BidderHash)
+		require.True(t, now.Equal(event.Timestamp))
+		require.Equal(t, fmt.Sprintf("0.00000000%d gwei", expected), event.AmountDecimal)
+	}
+	first, second := <-hashed, <-hashed
+	require.Nil(t, first.Bidder)
+	require.NotEqual(t, common.HexToAddress("0x1").Hash(), *first.BidderHash)
+	require.Equal(t, *first.BidderHash, *second.BidderHash)
+
+	// Hashed bidders still tell bidders apart.
+	feed.publish(bid("0x2", 4), now)
+	require.NotEqual(t, *first.BidderHash, *(<-hashed).BidderHash)
+
+	unsubscribePlain()
+	require.Len(t, plain, 1)
+	feed.publish(bid("0x1", 5), now)
+	require.Len(t, plain, 1)
+}
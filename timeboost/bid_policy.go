@@ -0,0 +1,41 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var policyRejectedBidsCounter = metrics.NewRegisteredCounter("arb/validator/bids/policyrejected", nil)
+
+// BidPolicy enforces deployment specific rules on bids, e.g. allowlists of
+// bidders or express lane controllers. It is only consulted for bids that
+// already passed validation, and a non-nil error rejects the bid with it.
+type BidPolicy interface {
+	Allow(ctx context.Context, bid *ValidatedBid) error
+}
+
+// WithBidPolicy has every validated bid checked against the policy before
+// it is accepted.
+func WithBidPolicy(policy BidPolicy) BidValidatorOpt {
+	return func(bv *BidValidator) {
+		bv.bidPolicy = policy
+	}
+}
+
+// applyBidPolicy returns the error the bid policy rejects the bid with, if any.
+func (bv *BidValidator) applyBidPolicy(ctx context.Context, bid *JsonValidatedBid) error {
+	if bv.bidPolicy == nil {
+		return nil
+	}
+	if err := bv.bidPolicy.Allow(ctx, JsonValidatedBidToGo(bid)); err != nil {
+		policyRejectedBidsCounter.Inc(1)
+		log.Debug("Bid rejected by bid policy", "bidder", bid.Bidder.Hex(), "controller", bid.ExpressLaneController.Hex(), "round", bid.Round, "err", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package timeboost
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var errControllerNotAllowed = errors.New("express lane controller not allowed")
+
+type denyControllerPolicy struct {
+	denied common.Address
+}
+
+func (p *denyControllerPolicy) Allow(_ context.Context, bid *ValidatedBid) error {
+	if bid.ExpressLaneController == p.denied {
+		return errControllerNotAllowed
+	}
+	return nil
+}
+
+func TestBidPolicy(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	balanceCheckerFn := func(_ *bind.CallOpts, _ common.Address) (*big.Int, error) {
+		return big.NewInt(10), nil
+	}
+	auctionContractAddr := common.Address{'a'}
+	bv := &BidValidator{
+		chainId: big.NewInt(1),
+		roundTimingInfo: RoundTimingInfo{
+			Offset:         time.Now().Add(-time.Second),
+			Round:          time.Minute,
+			AuctionClosing: 45 * time.Second,
+		},
+		reservePrice:            big.NewInt(2),
+		bidsPerSenderInRound:    make(map[common.Address]uint8),
+		maxBidsPerSenderInRound: 5,
+		auctionContractAddr:     auctionContractAddr,
+	}
+	// Without a policy, every validated bid is allowed.
+	bid := buildValidBid(t, auctionContractAddr)
+	validated, err := bv.validateBid(bid, balanceCheckerFn)
+	require.NoError(t, err)
+	require.NoError(t, bv.applyBidPolicy(ctx, validated))
+
+	WithBidPolicy(&denyControllerPolicy{denied: bid.ExpressLaneController})(bv)
+	require.ErrorIs(t, bv.applyBidPolicy(ctx, validated), errControllerNotAllowed)
+
+	WithBidPolicy(&denyControllerPolicy{denied: common.Address{'c'}})(bv)
+	require.NoError(t, bv.applyBidPolicy(ctx, validated))
+}
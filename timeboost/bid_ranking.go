@@ -0,0 +1,198 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"container/heap"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type rankedBid struct {
+	bid *ValidatedBid
+	// hash breaks ties between bids of the same amount, see BigIntHash.
+	hash *big.Int
+	// index and lowIndex are the positions of the bid in the heap and in the
+	// lowest heap.
+	index    int
+	lowIndex int
+}
+
+// bidRanking keeps the bids of a bid cache in a max heap, ordered the way the
+// auction ranks them: by amount, and then by hash. Adding, replacing and
+// removing a bid takes logarithmic time, while the top two bids are always
+// at the root and one of its children. The same bids are also kept in a min
+// heap, whose root is the lowest ranked bid a full cache evicts first.
+type bidRanking struct {
+	domainSeparator [32]byte
+	heap            []*rankedBid
+	lowest          lowestBids
+	byController    map[common.Address]*rankedBid
+}
+
+func newBidRanking(domainSeparator [32]byte) *bidRanking {
+	return &bidRanking{
+		domainSeparator: domainSeparator,
+		byController:    make(map[common.Address]*rankedBid),
+	}
+}
+
+func (r *bidRanking) Len() int { return len(r.heap) }
+
+func (r *bidRanking) Less(i, j int) bool {
+	return r.heap[i].outranks(r.heap[j])
+}
+
+// outranks breaks ties the way the auction contract checks them when resolving,
+// so the ranking of a given set of bids never depends on chance or on the order
+// they were received in.
+func (b *rankedBid) outranks(other *rankedBid) bool {
+	if c := b.bid.Amount.Cmp(other.bid.Amount); c != 0 {
+		return c > 0
+	}
+	return b.hash.Cmp(other.hash) > 0
+}
+
+func (r *bidRanking) Swap(i, j int) {
+	r.heap[i], r.heap[j] = r.heap[j], r.heap[i]
+	r.heap[i].index = i
+	r.heap[j].index = j
+}
+
+func (r *bidRanking) Push(x any) {
+	ranked := x.(*rankedBid)
+	ranked.index = len(r.heap)
+	r.heap = append(r.heap, ranked)
+}
+
+func (r *bidRanking) Pop() any {
+	last := r.heap[len(r.heap)-1]
+	r.heap[len(r.heap)-1] = nil
+	r.heap = r.heap[:len(r.heap)-1]
+	return last
+}
+
+// lowestBids is the min heap of a bidRanking, ranking its bids the other way
+// around.
+type lowestBids []*rankedBid
+
+func (l lowestBids) Len() int { return len(l) }
+
+func (l lowestBids) Less(i, j int) bool {
+	return l[j].outranks(l[i])
+}
+
+func (l lowestBids) Swap(i, j int) {
+	l[i], l[j] = l[j], l[i]
+	l[i].lowIndex = i
+	l[j].lowIndex = j
+}
+
+func (l *lowestBids) Push(x any) {
+	ranked := x.(*rankedBid)
+	ranked.lowIndex = len(*l)
+	*l = append(*l, ranked)
+}
+
+func (l *lowestBids) Pop() any {
+	old := *l
+	last := old[len(old)-1]
+	old[len(old)-1] = nil
+	*l = old[:len(old)-1]
+	return last
+}
+
+// set ranks the bid, replacing any earlier bid for the same express lane
+// controller, whether it was higher or lower.
+func (r *bidRanking) set(bid *ValidatedBid) {
+	hash := bid.BigIntHash(r.domainSeparator)
+	if ranked, ok := r.byController[bid.ExpressLaneController]; ok {
+		ranked.bid = bid
+		ranked.hash = hash
+		heap.Fix(r, ranked.index)
+		heap.Fix(&r.lowest, ranked.lowIndex)
+		return
+	}
+	ranked := &rankedBid{bid: bid, hash: hash}
+	r.byController[bid.ExpressLaneController] = ranked
+	heap.Push(r, ranked)
+	heap.Push(&r.lowest, ranked)
+}
+
+// remove drops the bid for the given express lane controller, if any.
+func (r *bidRanking) remove(controller common.Address) {
+	ranked, ok := r.byController[controller]
+	if !ok {
+		return
+	}
+	delete(r.byController, controller)
+	heap.Remove(r, ranked.index)
+	heap.Remove(&r.lowest, ranked.lowIndex)
+}
+
+func (r *bidRanking) clear() {
+	r.heap = nil
+	r.lowest = nil
+	r.byController = make(map[common.Address]*rankedBid)
+}
+
+// setDomainSeparator re-ranks every bid, as the ties between them are broken
+// by hashes over the domain separator.
+func (r *bidRanking) setDomainSeparator(domainSeparator [32]byte) {
+	r.domainSeparator = domainSeparator
+	for _, ranked := range r.heap {
+		ranked.hash = ranked.bid.BigIntHash(domainSeparator)
+	}
+	heap.Init(r)
+	heap.Init(&r.lowest)
+}
+
+// lowestRanked returns the lowest ranked bid, or nil if there are none.
+func (r *bidRanking) lowestRanked() *ValidatedBid {
+	if len(r.lowest) == 0 {
+		return nil
+	}
+	return r.lowest[0].bid
+}
+
+// topTwo returns the two highest ranked bids. In a heap, the runner-up is
+// always one of the children of the root.
+func (r *bidRanking) topTwo() *auctionResult {
+	result := &auctionResult{}
+	if len(r.heap) == 0 {
+		return result
+	}
+	result.firstPlace = r.heap[0].bid
+	switch {
+	case len(r.heap) == 2 || len(r.heap) > 2 && r.Less(1, 2):
+		result.secondPlace = r.heap[1].bid
+	case len(r.heap) > 2:
+		result.secondPlace = r.heap[2].bid
+	}
+	return result
+}
+
+// bidderRank returns the rank of the bidder among all bidders with cached
+// bids, by their best bid and starting at 1. It returns 0 if the bidder has
+// no cached bid.
+func (r *bidRanking) bidderRank(bidder common.Address) int {
+	best := make(map[common.Address]*rankedBid)
+	for _, ranked := range r.heap {
+		if current, ok := best[ranked.bid.Bidder]; !ok || ranked.outranks(current) {
+			best[ranked.bid.Bidder] = ranked
+		}
+	}
+	own, ok := best[bidder]
+	if !ok {
+		return 0
+	}
+	rank := 1
+	for other, ranked := range best {
+		if other != bidder && ranked.outranks(own) {
+			rank++
+		}
+	}
+	return rank
+}
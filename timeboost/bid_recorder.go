@@ -0,0 +1,130 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// WithBidRecorder appends every validated bid the auctioneer receives to the
+// file at path, one JSON object per line, so that the bids of a round can later
+// be fed back into a fresh auctioneer with ReplayBids.
+func WithBidRecorder(path string) AuctioneerServerOpt {
+	return func(a *AuctioneerServer) {
+		a.bidRecorderPath = path
+	}
+}
+
+// Versions of the bid recording format. Bids recorded before the format was
+// versioned have no version, and are of version 1. Version 2 records the
+// reserve price each bid was validated against.
+const (
+	bidRecordingV1      = 1
+	bidRecordingVersion = 2
+)
+
+// recordedBid is a single line of a bid recording.
+type recordedBid struct {
+	Version    int               `json:"version"`
+	ReceivedAt time.Time         `json:"receivedAt"`
+	Bid        *JsonValidatedBid `json:"bid"`
+}
+
+// migrate upgrades a recorded bid to the current version of the format, and
+// reports whether it could.
+func (r *recordedBid) migrate() bool {
+	switch r.Version {
+	case 0, bidRecordingV1:
+		// Upgraded as is, leaving the reserve price of the bid unknown.
+		r.Version = bidRecordingVersion
+		return true
+	case bidRecordingVersion:
+		return true
+	default:
+		return false
+	}
+}
+
+type bidRecorder struct {
+	mutex sync.Mutex
+	file  *os.File
+	enc   *json.Encoder
+}
+
+func newBidRecorder(path string) (*bidRecorder, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("opening bid recording file: %w", err)
+	}
+	return &bidRecorder{
+		file: file,
+		enc:  json.NewEncoder(file),
+	}, nil
+}
+
+func (r *bidRecorder) record(bid *JsonValidatedBid, receivedAt time.Time) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.file == nil {
+		return
+	}
+	if err := r.enc.Encode(&recordedBid{Version: bidRecordingVersion, ReceivedAt: receivedAt, Bid: bid}); err != nil {
+		log.Error("Could not record validated bid", "err", err, "bidder", bid.Bidder, "round", bid.Round)
+	}
+}
+
+func (r *bidRecorder) close() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.file == nil {
+		return
+	}
+	if err := r.file.Close(); err != nil {
+		log.Error("Could not close bid recording file", "err", err)
+	}
+	r.file = nil
+}
+
+// ReplayBids feeds the bids recorded at path back into the auctioneer, in the
+// order they were recorded and as if received at the times they were recorded
+// at, without waiting out the intervals between them. Replayed bids are
+// neither recorded again nor persisted to the database. Bids recorded by
+// earlier releases are upgraded to the current format, and bids of a format
+// unknown to this release, e.g. recorded by a later one, are skipped with a
+// warning rather than replayed half understood.
+func (a *AuctioneerServer) ReplayBids(ctx context.Context, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening bid recording file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		var recorded recordedBid
+		if err := json.Unmarshal(scanner.Bytes(), &recorded); err != nil {
+			return fmt.Errorf("decoding recorded bid on line %d: %w", line, err)
+		}
+		if recorded.Bid == nil {
+			return fmt.Errorf("recorded bid on line %d is empty", line)
+		}
+		if !recorded.migrate() {
+			log.Warn("Skipping recorded bid of unknown format version", "line", line, "version", recorded.Version, "supportedVersion", bidRecordingVersion)
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		a.receiveValidatedBidAt(recorded.Bid, recorded.ReceivedAt)
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,128 @@
+package timeboost
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestBidRecorderReplay(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	path := filepath.Join(t.TempDir(), "bids.jsonl")
+
+	recorder, err := newBidRecorder(path)
+	require.NoError(t, err)
+	start := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+	var bids []*JsonValidatedBid
+	for i := int64(0); i < 3; i++ {
+		bid := (&ValidatedBid{
+			ChainId:                big.NewInt(1),
+			AuctionContractAddress: common.HexToAddress("0x1234"),
+			Signature:              []byte{byte(i)},
+			Bidder:                 common.BigToAddress(big.NewInt(i + 1)),
+			ExpressLaneController:  common.BigToAddress(big.NewInt(i + 1)),
+			Round:                  7,
+			Amount:                 big.NewInt(100 + i),
+		}).ToJson()
+		bids = append(bids, bid)
+		recorder.record(bid, start.Add(time.Duration(i)*30*time.Minute))
+	}
+	recorder.close()
+	// Recording after close is a no-op.
+	recorder.record(bids[0], time.Now())
+
+	// Bids spread over an hour are replayed at once, as received when they
+	// were recorded, and not recorded again.
+	rerecordPath := filepath.Join(t.TempDir(), "rerecorded.jsonl")
+	rerecorder, err := newBidRecorder(rerecordPath)
+	require.NoError(t, err)
+	a := &AuctioneerServer{
+		auctionContractAddr: common.HexToAddress("0x1234"),
+		bidCache:            newBidCache([32]byte{}),
+		bidRecorder:         rerecorder,
+	}
+	require.NoError(t, a.ReplayBids(ctx, path))
+	rerecorder.close()
+	require.Equal(t, len(bids), a.bidCache.size())
+	for i, want := range bids {
+		got := a.bidCache.bidsByExpressLaneControllerAddr[want.ExpressLaneController]
+		require.NotNil(t, got)
+		require.True(t, start.Add(time.Duration(i)*30*time.Minute).Equal(got.ReceivedAt))
+		got.ReceivedAt = time.Time{}
+		require.Equal(t, JsonValidatedBidToGo(want), got)
+	}
+	rerecorded, err := os.ReadFile(rerecordPath)
+	require.NoError(t, err)
+	require.Empty(t, rerecorded)
+
+	// Malformed recordings are rejected.
+	require.NoError(t, os.WriteFile(path, []byte("not json\n"), 0600))
+	require.Error(t, a.ReplayBids(ctx, path))
+}
+
+func TestBidRecorderFormatVersions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "bids.jsonl")
+	bid := func(i int64) *JsonValidatedBid {
+		return (&ValidatedBid{
+			ChainId:                big.NewInt(1),
+			AuctionContractAddress: common.HexToAddress("0x1234"),
+			Signature:              []byte{byte(i)},
+			Bidder:                 common.BigToAddress(big.NewInt(i)),
+			ExpressLaneController:  common.BigToAddress(big.NewInt(i)),
+			Round:                  7,
+			Amount:                 big.NewInt(100 + i),
+			ReservePrice:           big.NewInt(50),
+		}).ToJson()
+	}
+	encode := func(v any) string {
+		encoded, err := json.Marshal(v)
+		require.NoError(t, err)
+		return string(encoded) + "\n"
+	}
+	// Version 1 recordings have no version, and no reserve price.
+	v1 := bid(1)
+	v1.ReservePrice = nil
+	recording := encode(struct {
+		ReceivedAt time.Time         `json:"receivedAt"`
+		Bid        *JsonValidatedBid `json:"bid"`
+	}{time.Now(), v1})
+	recording += encode(&recordedBid{Version: bidRecordingVersion + 1, ReceivedAt: time.Now(), Bid: bid(2)})
+	recording += encode(&recordedBid{Version: bidRecordingVersion, ReceivedAt: time.Now(), Bid: bid(3)})
+	require.NoError(t, os.WriteFile(path, []byte(recording), 0600))
+
+	// The version 1 bid is upgraded, the bid of an unknown version skipped.
+	a := &AuctioneerServer{auctionContractAddr: common.HexToAddress("0x1234"), bidCache: newBidCache([32]byte{})}
+	require.NoError(t, a.ReplayBids(ctx, path))
+	require.Equal(t, 2, a.bidCache.size())
+	require.NotContains(t, a.bidCache.bidsByExpressLaneControllerAddr, common.BigToAddress(big.NewInt(2)))
+	upgraded := a.bidCache.bidsByExpressLaneControllerAddr[common.BigToAddress(big.NewInt(1))]
+	require.NotNil(t, upgraded)
+	require.Nil(t, upgraded.ReservePrice)
+	current := a.bidCache.bidsByExpressLaneControllerAddr[common.BigToAddress(big.NewInt(3))]
+	require.NotNil(t, current)
+	require.Equal(t, big.NewInt(50), current.ReservePrice)
+
+	// Bids are recorded in the current version.
+	path = filepath.Join(t.TempDir(), "bids.jsonl")
+	recorder, err := newBidRecorder(path)
+	require.NoError(t, err)
+	recorder.record(bid(4), time.Now())
+	recorder.close()
+	encoded, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var recorded recordedBid
+	require.NoError(t, json.Unmarshal(encoded, &recorded))
+	require.Equal(t, bidRecordingVersion, recorded.Version)
+}
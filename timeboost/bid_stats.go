@@ -0,0 +1,90 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// bidStats aggregate the amounts of the cached bids. The amounts are nil if
+// there are no bids.
+type bidStats struct {
+	count           int
+	distinctBidders int
+	min             *big.Int
+	max             *big.Int
+	median          *big.Int
+	mean            *big.Int
+}
+
+// stats aggregates the cached bids, taken under a single read lock so that
+// every statistic describes the same cache. Only aggregates are returned, so
+// that no amount can be told apart as some bidder's. The median of an even
+// number of bids is the mean of the middle two, and means are rounded down.
+func (bc *bidCache) stats() *bidStats {
+	bc.RLock()
+	amounts := make([]*big.Int, 0, len(bc.bidsByExpressLaneControllerAddr))
+	bidders := make(map[common.Address]struct{}, len(bc.bidsByExpressLaneControllerAddr))
+	for _, bid := range bc.bidsByExpressLaneControllerAddr {
+		amounts = append(amounts, new(big.Int).Set(bid.Amount))
+		bidders[bid.Bidder] = struct{}{}
+	}
+	bc.RUnlock()
+
+	stats := &bidStats{count: len(amounts), distinctBidders: len(bidders)}
+	if len(amounts) == 0 {
+		return stats
+	}
+	slices.SortFunc(amounts, func(x, y *big.Int) int { return x.Cmp(y) })
+	sum := new(big.Int)
+	for _, amount := range amounts {
+		sum.Add(sum, amount)
+	}
+	stats.min = amounts[0]
+	stats.max = amounts[len(amounts)-1]
+	stats.mean = sum.Div(sum, big.NewInt(int64(len(amounts))))
+	middle := len(amounts) / 2
+	if len(amounts)%2 == 1 {
+		stats.median = amounts[middle]
+	} else {
+		stats.median = new(big.Int).Add(amounts[middle-1], amounts[middle])
+		stats.median.Rsh(stats.median, 1)
+	}
+	return stats
+}
+
+// BidStatsResult describes how competitive the auction for the upcoming round
+// currently is, in aggregate, without revealing who bid or who bid what. It is
+// meant for public transparency endpoints.
+type BidStatsResult struct {
+	Round           hexutil.Uint64 `json:"round"`
+	Count           int            `json:"count"`
+	DistinctBidders int            `json:"distinctBidders"`
+	Min             *hexutil.Big   `json:"min,omitempty"`
+	Max             *hexutil.Big   `json:"max,omitempty"`
+	Median          *hexutil.Big   `json:"median,omitempty"`
+	Mean            *hexutil.Big   `json:"mean,omitempty"`
+}
+
+// BidStats returns aggregate statistics of the bids held for the upcoming round.
+func (a *AuctioneerServer) BidStats() *BidStatsResult {
+	stats := a.bidCache.stats()
+	return &BidStatsResult{
+		Round:           hexutil.Uint64(a.UpcomingRound()),
+		Count:           stats.count,
+		DistinctBidders: stats.distinctBidders,
+		Min:             (*hexutil.Big)(stats.min),
+		Max:             (*hexutil.Big)(stats.max),
+		Median:          (*hexutil.Big)(stats.median),
+		Mean:            (*hexutil.Big)(stats.mean),
+	}
+}
+
+func (api *AuctioneerBidderAPI) BidStats() *BidStatsResult {
+	return api.auctioneer.BidStats()
+}
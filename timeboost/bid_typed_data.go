@@ -0,0 +1,59 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// bidEIP712Type is the Bid struct of the auction contract that bids are signed
+// as. The chain id and auction contract are not part of it, but of the domain
+// it is signed under, see ComputeDomainSeparator.
+var bidEIP712Type = []apitypes.Type{
+	{Name: "round", Type: "uint64"},
+	{Name: "expressLaneController", Type: "address"},
+	{Name: "amount", Type: "uint256"},
+}
+
+// TypedData returns the bid as the complete EIP-712 typed data bidders sign,
+// domain included, so that wallets can sign it through eth_signTypedData_v4.
+// Its hash is the one returned by ToEIP712Hash under the domain separator of
+// the auction contract named by the bid.
+func (b *Bid) TypedData() apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": []apitypes.Type{
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"Bid": bidEIP712Type,
+		},
+		PrimaryType: "Bid",
+		Domain: apitypes.TypedDataDomain{
+			Name:              auctionDomainName,
+			Version:           auctionDomainVersion,
+			ChainId:           (*math.HexOrDecimal256)(b.ChainId),
+			VerifyingContract: b.AuctionContractAddress.Hex(),
+		},
+		// Integers are encoded as decimal strings, as JSON numbers can't hold
+		// every uint256.
+		Message: apitypes.TypedDataMessage{
+			"round":                 strconv.FormatUint(b.Round, 10),
+			"expressLaneController": b.ExpressLaneController.Hex(),
+			"amount":                b.Amount.String(),
+		},
+	}
+}
+
+// TypedDataJSON returns the typed data of the bid as the JSON clients pass to
+// eth_signTypedData_v4.
+func (b *Bid) TypedDataJSON() ([]byte, error) {
+	return json.Marshal(b.TypedData())
+}
@@ -0,0 +1,91 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"errors"
+	"runtime"
+)
+
+var errBidBeingValidated = errors.New("an identical bid is already being validated, retry later")
+
+// bidSubmission is a bid waiting for a validation worker, along with the
+// context of the request that submitted it.
+type bidSubmission struct {
+	ctx    context.Context
+	bid    *JsonBid
+	result chan error
+}
+
+// startValidationWorkers launches the pool of threads validating submitted bids.
+// Signature recovery dominates validation, so bids are validated concurrently
+// rather than queueing up behind each other near the auction close.
+func (bv *BidValidator) startValidationWorkers() {
+	workers := bv.validationWorkers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	for i := 0; i < workers; i++ {
+		bv.StopWaiter.LaunchThread(func(ctx context.Context) {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case submission := <-bv.bidsReceiver:
+					if submission.ctx.Err() != nil {
+						// The submitter is no longer waiting for the bid.
+						submission.result <- submission.ctx.Err()
+						continue
+					}
+					submission.result <- bv.processBid(submission.ctx, submission.bid)
+				}
+			}
+		})
+	}
+}
+
+// submitToWorkers queues a bid for validation and waits for its outcome.
+func (bv *BidValidator) submitToWorkers(ctx context.Context, bid *JsonBid) error {
+	submission := &bidSubmission{ctx: ctx, bid: bid, result: make(chan error, 1)}
+	select {
+	case bv.bidsReceiver <- submission:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-submission.result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// claimBid marks a bid as being validated, so that concurrent submissions of
+// the exact same signed bid aren't validated and published more than once.
+// It reports whether the bid was already published this round, and otherwise
+// whether it was claimed, as opposed to being validated by another worker.
+func (bv *BidValidator) claimBid(signature []byte) (seen bool, claimed bool) {
+	bv.Lock()
+	defer bv.Unlock()
+	if _, seen := bv.seenBidSignatures[string(signature)]; seen {
+		return true, false
+	}
+	if _, validating := bv.validatingBidSignatures[string(signature)]; validating {
+		return false, false
+	}
+	bv.validatingBidSignatures[string(signature)] = struct{}{}
+	return false, true
+}
+
+// releaseBid ends the validation of a claimed bid, recording it as seen if it
+// was published.
+func (bv *BidValidator) releaseBid(signature []byte, published bool) {
+	bv.Lock()
+	defer bv.Unlock()
+	delete(bv.validatingBidSignatures, string(signature))
+	if published {
+		bv.seenBidSignatures[string(signature)] = struct{}{}
+	}
+}
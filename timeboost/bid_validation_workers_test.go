@@ -0,0 +1,60 @@
+package timeboost
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBidValidatorClaimBid(t *testing.T) {
+	t.Parallel()
+	bv := &BidValidator{
+		seenBidSignatures:       make(map[string]struct{}),
+		validatingBidSignatures: make(map[string]struct{}),
+	}
+	signature := make([]byte, 65)
+
+	// Only one of many concurrent submissions of the same bid is validated.
+	var wg sync.WaitGroup
+	var claims atomic.Int64
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			seen, claimed := bv.claimBid(signature)
+			require.False(t, seen)
+			if claimed {
+				claims.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int64(1), claims.Load())
+
+	// A bid that failed validation may be submitted again.
+	bv.releaseBid(signature, false)
+	seen, claimed := bv.claimBid(signature)
+	require.False(t, seen)
+	require.True(t, claimed)
+
+	// A published bid is seen from then on.
+	bv.releaseBid(signature, true)
+	seen, claimed = bv.claimBid(signature)
+	require.True(t, seen)
+	require.False(t, claimed)
+	require.True(t, bv.bidSeen(signature))
+}
+
+func TestBidValidatorSubmitToWorkers(t *testing.T) {
+	t.Parallel()
+	bv := &BidValidator{bidsReceiver: make(chan *bidSubmission, 1)}
+
+	// Nothing is validated without workers, the submitter gives up on its context.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, bv.submitToWorkers(ctx, &JsonBid{}), context.DeadlineExceeded)
+}
@@ -10,21 +10,34 @@ import (
 	"github.com/pkg/errors"
 	"github.com/redis/go-redis/v9"
 	"github.com/spf13/pflag"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/offchainlabs/nitro/pubsub"
 	"github.com/offchainlabs/nitro/solgen/go/express_lane_auctiongen"
+	"github.com/offchainlabs/nitro/util/arbmath"
 	"github.com/offchainlabs/nitro/util/redisutil"
 	"github.com/offchainlabs/nitro/util/stopwaiter"
 )
 
+var (
+	sigRecoveryTimer   = metrics.NewRegisteredTimer("arb/validator/bids/sigrecovery/duration", nil)
+	sigRecoveryCounter = metrics.NewRegisteredCounter("arb/validator/bids/sigrecovery", nil)
+)
+
+// slowSigRecovery is how long recovering a bid's signer may take before it is
+// logged, as a sign that validation is CPU bound.
+const slowSigRecovery = 10 * time.Millisecond
+
 type BidValidatorConfigFetcher func() *BidValidatorConfig
 
 type BidValidatorConfig struct {
@@ -34,18 +47,49 @@ type BidValidatorConfig struct {
 	// Timeout on polling for existence of each redis stream.
 	SequencerEndpoint      string `koanf:"sequencer-endpoint"`
 	AuctionContractAddress string `koanf:"auction-contract-address"`
+	// Bids naming any of these addresses as express lane controller are rejected.
+	DeniedExpressLaneControllers []string `koanf:"denied-express-lane-controllers"`
+	MaxBidAmountGwei             uint64   `koanf:"max-bid-amount-gwei"`
+	// Only meant for test and staging deployments. Bids below the reserve price
+	// are flagged as such instead of being rejected, and are never resolved.
+	AcceptBidsBelowReservePrice bool            `koanf:"accept-bids-below-reserve-price"`
+	ClockSkew                   ClockSkewConfig `koanf:"clock-skew"`
+	// Optional anti-spam floor above the reserve price. Bids must be at least
+	// the larger of the reserve price times the multiplier and the reserve
+	// price plus the delta. Both are disabled when zero.
+	BidFloorMultiplierBips uint64 `koanf:"bid-floor-multiplier-bips"`
+	BidFloorDeltaGwei      uint64 `koanf:"bid-floor-delta-gwei"`
+	// Number of bids validated concurrently, 0 defaults to GOMAXPROCS.
+	ValidationWorkers int `koanf:"validation-workers"`
+	// Bids may name any express lane controller by default. If set, they must
+	// be signed by it, or by the transferor it set on the auction contract.
+	RequireControllerSignature bool `koanf:"require-controller-signature"`
+	// Bids for a round whose auction closed are rejected as naming the wrong
+	// round by default. If set, they are rejected as arriving too late.
+	RejectLateBids bool `koanf:"reject-late-bids"`
+	// Logs everything known about rejected bids at debug level, under an id
+	// returned to the submitter with the rejection.
+	LogRejectedBids bool `koanf:"log-rejected-bids"`
+	// Randomizes the interval of the clock drift check, see jitter.
+	PollJitter float64 `koanf:"poll-jitter"`
 }
 
 var DefaultBidValidatorConfig = BidValidatorConfig{
-	Enable:         true,
-	RedisURL:       "",
-	ProducerConfig: pubsub.DefaultProducerConfig,
+	Enable:           true,
+	RedisURL:         "",
+	ProducerConfig:   pubsub.DefaultProducerConfig,
+	MaxBidAmountGwei: 1_000_000_000_000_000, // 1M tokens of a token with 18 decimals.
+	ClockSkew:        DefaultClockSkewConfig,
+	PollJitter:       DefaultPollJitter,
 }
 
 var TestBidValidatorConfig = BidValidatorConfig{
-	Enable:         true,
-	RedisURL:       "",
-	ProducerConfig: pubsub.TestProducerConfig,
+	Enable:           true,
+	RedisURL:         "",
+	ProducerConfig:   pubsub.TestProducerConfig,
+	MaxBidAmountGwei: 1_000_000_000_000_000,
+	ClockSkew:        DefaultClockSkewConfig,
+	PollJitter:       DefaultPollJitter,
 }
 
 func BidValidatorConfigAddOptions(prefix string, f *pflag.FlagSet) {
@@ -54,6 +98,17 @@ func BidValidatorConfigAddOptions(prefix string, f *pflag.FlagSet) {
 	pubsub.ProducerAddConfigAddOptions(prefix+".producer-config", f)
 	f.String(prefix+".sequencer-endpoint", DefaultAuctioneerServerConfig.SequencerEndpoint, "sequencer RPC endpoint")
 	f.String(prefix+".auction-contract-address", DefaultAuctioneerServerConfig.AuctionContractAddress, "express lane auction contract address")
+	f.StringSlice(prefix+".denied-express-lane-controllers", DefaultBidValidatorConfig.DeniedExpressLaneControllers, "express lane controller addresses that bids are not allowed to name")
+	f.Uint64(prefix+".max-bid-amount-gwei", DefaultBidValidatorConfig.MaxBidAmountGwei, "bids above this amount in gwei are rejected as malformed (0 = no limit)")
+	f.Bool(prefix+".accept-bids-below-reserve-price", DefaultBidValidatorConfig.AcceptBidsBelowReservePrice, "accept and flag bids below the reserve price instead of rejecting them, for test and staging deployments only")
+	ClockSkewConfigAddOptions(prefix+".clock-skew", f)
+	f.Uint64(prefix+".bid-floor-multiplier-bips", DefaultBidValidatorConfig.BidFloorMultiplierBips, "reject bids below the reserve price times this multiplier in basis points (0 = disabled)")
+	f.Uint64(prefix+".bid-floor-delta-gwei", DefaultBidValidatorConfig.BidFloorDeltaGwei, "reject bids below the reserve price plus this amount in gwei (0 = disabled)")
+	f.Int(prefix+".validation-workers", DefaultBidValidatorConfig.ValidationWorkers, "number of bids to validate concurrently (0 = GOMAXPROCS)")
+	f.Bool(prefix+".require-controller-signature", DefaultBidValidatorConfig.RequireControllerSignature, "reject bids not signed by the express lane controller they name or its transferor on the auction contract")
+	f.Bool(prefix+".reject-late-bids", DefaultBidValidatorConfig.RejectLateBids, "log and reject bids that arrived after the auction of the round they declare closed with BID_ARRIVED_TOO_LATE, rather than BAD_ROUND_NUMBER")
+	f.Float64(prefix+".poll-jitter", DefaultBidValidatorConfig.PollJitter, "randomize the interval of the clock drift check by up to this fraction either way, to spread the RPC load of several bid validators (0 = disabled, at most 0.5)")
+	f.Bool(prefix+".log-rejected-bids", DefaultBidValidatorConfig.LogRejectedBids, "log rejected bids with their signer, rounds, reserve price and deposit at debug level, under an id returned to the submitter")
 }
 
 type BidValidator struct {
@@ -69,18 +124,55 @@ type BidValidator struct {
 	auctionContract                *express_lane_auctiongen.ExpressLaneAuction
 	auctionContractAddr            common.Address
 	auctionContractDomainSeparator [32]byte
-	bidsReceiver                   chan *Bid
+	bidsReceiver                   chan *bidSubmission
 	roundTimingInfo                RoundTimingInfo
 	reservePriceLock               sync.RWMutex
 	reservePrice                   *big.Int
+	reserveOverrides               map[uint64]*big.Int
+	operatorReservePrice           *big.Int
+	minReservePriceLock            sync.RWMutex
+	minReservePrice                *big.Int
 	bidsPerSenderInRound           map[common.Address]uint8
 	maxBidsPerSenderInRound        uint8
+	deniedExpressLaneControllers   map[common.Address]struct{}
+	maxBidAmount                   *big.Int
+	// Signatures of the bids already validated and published this round.
+	seenBidSignatures           map[string]struct{}
+	acceptBidsBelowReservePrice bool
+	clock                       clockSkewMonitor
+	reservePriceOracle          ReservePriceOracle
+	bidFloorMultiplier          arbmath.Bips
+	bidFloorDelta               *big.Int
+	validationWorkers           int
+	// Signatures of the bids currently being validated by a worker.
+	validatingBidSignatures map[string]struct{}
+	// The round the reserve price was last read for at its reserve submission deadline.
+	finalReservePriceRound uint64
+	bidPolicy              BidPolicy
+	// Whether bids must be signed by their express lane controller.
+	requireControllerSignature bool
+	controllerTransferor       controllerTransferorFn
+	tracer                     trace.Tracer
+	bidFeed                    bidFeed
+	// Whether bids arrived too late for their round are told apart from bids
+	// for the wrong round.
+	rejectLateBids bool
+	// Overrides the auction closing duration of the contract, see
+	// WithValidatorClosingDurationForRound.
+	closingDurationForRound ClosingDurationForRound
+	// Whether rejected bids are logged in full at debug level.
+	logRejectedBids bool
+	pollJitter      float64
+	// Confirmations deposits need to be counted, see WithDepositConfirmations.
+	depositConfirmations uint64
+	headBlockNumber      func(ctx context.Context) (uint64, error)
 }
 
 func NewBidValidator(
 	ctx context.Context,
 	stack *node.Node,
 	configFetcher BidValidatorConfigFetcher,
+	opts ...BidValidatorOpt,
 ) (*BidValidator, error) {
 	cfg := configFetcher()
 	if cfg.RedisURL == "" {
@@ -89,7 +181,17 @@ func NewBidValidator(
 	if cfg.AuctionContractAddress == "" {
 		return nil, fmt.Errorf("auction contract address cannot be empty")
 	}
+	if err := validatePollJitter(cfg.PollJitter); err != nil {
+		return nil, err
+	}
 	auctionContractAddr := common.HexToAddress(cfg.AuctionContractAddress)
+	deniedExpressLaneControllers := make(map[common.Address]struct{}, len(cfg.DeniedExpressLaneControllers))
+	for _, addr := range cfg.DeniedExpressLaneControllers {
+		if !common.IsHexAddress(addr) {
+			return nil, fmt.Errorf("invalid denied express lane controller address %q", addr)
+		}
+		deniedExpressLaneControllers[common.HexToAddress(addr)] = struct{}{}
+	}
 	redisClient, err := redisutil.RedisClientFromURL(cfg.RedisURL)
 	if err != nil {
 		return nil, err
@@ -104,6 +206,9 @@ func NewBidValidator(
 	if err != nil {
 		return nil, err
 	}
+	if err := validateChainId(chainId); err != nil {
+		return nil, err
+	}
 	auctionContract, err := express_lane_auctiongen.NewExpressLaneAuction(auctionContractAddr, sequencerClient)
 	if err != nil {
 		return nil, err
@@ -116,11 +221,22 @@ func NewBidValidator(
 	if err != nil {
 		return nil, err
 	}
+	if err = cfg.ClockSkew.Validate(roundTimingInfo); err != nil {
+		return nil, err
+	}
 
 	reservePrice, err := auctionContract.ReservePrice(&bind.CallOpts{})
 	if err != nil {
 		return nil, err
 	}
+	minReservePrice, err := auctionContract.MinReservePrice(&bind.CallOpts{})
+	if err != nil {
+		return nil, err
+	}
+	reservePrice, err = enforceMinReservePrice(reservePrice, minReservePrice)
+	if err != nil {
+		return nil, err
+	}
 
 	domainSeparator, err := auctionContract.DomainSeparator(&bind.CallOpts{
 		Context: ctx,
@@ -128,6 +244,14 @@ func NewBidValidator(
 	if err != nil {
 		return nil, err
 	}
+	if expected := ComputeDomainSeparator(chainId, auctionContractAddr); domainSeparator != expected {
+		log.Warn("Auction contract domain separator does not match the documented EIP-712 domain, clients computing it locally will sign invalid bids", "contract", common.Hash(domainSeparator), "expected", common.Hash(expected))
+	}
+
+	var maxBidAmount *big.Int
+	if cfg.MaxBidAmountGwei != 0 {
+		maxBidAmount = new(big.Int).Mul(new(big.Int).SetUint64(cfg.MaxBidAmountGwei), big.NewInt(params.GWei))
+	}
 
 	bidValidator := &BidValidator{
 		chainId:                        chainId,
@@ -137,13 +261,38 @@ func NewBidValidator(
 		auctionContract:                auctionContract,
 		auctionContractAddr:            auctionContractAddr,
 		auctionContractDomainSeparator: domainSeparator,
-		bidsReceiver:                   make(chan *Bid, 10_000),
+		bidsReceiver:                   make(chan *bidSubmission, 10_000),
 		roundTimingInfo:                *roundTimingInfo,
 		reservePrice:                   reservePrice,
+		minReservePrice:                minReservePrice,
 		domainValue:                    domainValue,
 		bidsPerSenderInRound:           make(map[common.Address]uint8),
 		maxBidsPerSenderInRound:        5, // 5 max bids per sender address in a round.
+		deniedExpressLaneControllers:   deniedExpressLaneControllers,
+		seenBidSignatures:              make(map[string]struct{}),
+		validatingBidSignatures:        make(map[string]struct{}),
+		validationWorkers:              cfg.ValidationWorkers,
+		acceptBidsBelowReservePrice:    cfg.AcceptBidsBelowReservePrice,
+		clock:                          clockSkewMonitor{config: cfg.ClockSkew},
+		maxBidAmount:                   maxBidAmount,
+		bidFloorMultiplier:             arbmath.SaturatingCast[arbmath.Bips](cfg.BidFloorMultiplierBips),
+		bidFloorDelta:                  new(big.Int).Mul(new(big.Int).SetUint64(cfg.BidFloorDeltaGwei), big.NewInt(params.GWei)),
 		producerCfg:                    &cfg.ProducerConfig,
+		requireControllerSignature:     cfg.RequireControllerSignature,
+		controllerTransferor:           auctionContractTransferorOf(auctionContract),
+		rejectLateBids:                 cfg.RejectLateBids,
+		logRejectedBids:                cfg.LogRejectedBids,
+		pollJitter:                     cfg.PollJitter,
+	}
+	for _, opt := range opts {
+		opt(bidValidator)
+	}
+	reservePrice = bidValidator.applyReservePriceOracle(ctx, reservePrice)
+	if bidValidator.InReserveSubmissionWindow() {
+		// Started after the reserve submission deadline, the reserve price is final.
+		bidValidator.setFinalReservePrice(bidValidator.upcomingRound(), reservePrice)
+	} else {
+		bidValidator.SetReservePrice(reservePrice)
 	}
 	api := &BidValidatorAPI{bidValidator}
 	valAPIs := []rpc.API{{
@@ -151,6 +300,12 @@ func NewBidValidator(
 		Version:   "1.0",
 		Service:   api,
 		Public:    true,
+	}, {
+		Namespace:     AuctioneerAdminNamespace,
+		Version:       "1.0",
+		Service:       &BidValidatorAdminAPI{bidValidator},
+		Public:        false,
+		Authenticated: true,
 	}}
 	stack.RegisterAPIs(valAPIs)
 	return bidValidator, nil
@@ -167,6 +322,17 @@ func EnsureBidValidatorExposedViaRPC(stackConf *node.Config) {
 	if !found {
 		stackConf.HTTPModules = append(stackConf.HTTPModules, AuctioneerNamespace)
 	}
+	// The bid feed is a subscription, which is only served over websockets.
+	found = false
+	for _, module := range stackConf.WSModules {
+		if module == AuctioneerNamespace {
+			found = true
+			break
+		}
+	}
+	if !found {
+		stackConf.WSModules = append(stackConf.WSModules, AuctioneerNamespace)
+	}
 }
 
 func (bv *BidValidator) Initialize(ctx context.Context) error {
@@ -193,13 +359,20 @@ func (bv *BidValidator) Start(ctx_in context.Context) {
 		log.Crit("Bid validator not yet initialized by calling Initialize(ctx)")
 	}
 	bv.producer.Start(ctx_in)
+	bv.startValidationWorkers()
+
+	if bv.clock.config.CheckInterval > 0 {
+		bv.StopWaiter.CallIteratively(func(ctx context.Context) time.Duration {
+			return jitter(bv.clock.checkDrift(ctx, bv.client.HeaderByNumber), bv.pollJitter)
+		})
+	}
 
 	// Thread to set reserve price and clear per-round map of bid count per account.
 	bv.StopWaiter.LaunchThread(func(ctx context.Context) {
 		reservePriceTicker := newRoundTicker(bv.roundTimingInfo)
-		go reservePriceTicker.tickAtReserveSubmissionDeadline()
-		auctionCloseTicker := newRoundTicker(bv.roundTimingInfo)
-		go auctionCloseTicker.tickAtAuctionClose()
+		bv.StopWaiter.LaunchThread(reservePriceTicker.tickAtReserveSubmissionDeadline)
+		auctionCloseTicker := newAuctionCloseTickerFor(bv.roundTimingInfo, bv.closingDurationForRound)
+		bv.StopWaiter.LaunchThread(auctionCloseTicker.tickAtAuctionClose)
 
 		for {
 			select {
@@ -212,69 +385,342 @@ func (bv *BidValidator) Start(ctx_in context.Context) {
 					log.Error("Could not get reserve price", "error", err)
 					continue
 				}
+				rp, err = enforceMinReservePrice(rp, bv.MinReservePrice())
+				if err != nil {
+					log.Error("Invalid reserve price", "error", err)
+					continue
+				}
+				rp = bv.applyReservePriceOracle(ctx, rp)
 
-				currentReservePrice := bv.fetchReservePrice()
+				currentReservePrice := bv.ReservePrice()
+				bv.setFinalReservePrice(bv.upcomingRound(), rp)
 				if currentReservePrice.Cmp(rp) == 0 {
 					continue
 				}
 
 				log.Info("Reserve price updated", "old", currentReservePrice.String(), "new", rp.String())
-				bv.setReservePrice(rp)
 
 			case <-auctionCloseTicker.c:
-				bv.Lock()
-				bv.bidsPerSenderInRound = make(map[common.Address]uint8)
-				bv.Unlock()
+				bv.clearReserveOverrides(bv.upcomingRound())
+				bv.resetRound()
 			}
 		}
 	})
 }
 
+// BidValidatorAPI is the public API of the bid validator. It wraps rather than
+// embeds the bid validator, so that only the methods below are exposed over
+// RPC, and none of the setters of the bid validator.
 type BidValidatorAPI struct {
-	*BidValidator
+	bidValidator *BidValidator
 }
 
-func (bv *BidValidatorAPI) SubmitBid(ctx context.Context, bid *JsonBid) error {
-	start := time.Now()
+// SubmitBid validates a bid and publishes it to the auctioneer. Clients that
+// need the correlation id the bid can be tracked by use
+// SubmitBidWithCorrelationId instead, so that the result of
+// auctioneer_submitBid stays null.
+func (api *BidValidatorAPI) SubmitBid(ctx context.Context, bid *JsonBid) error {
+	_, err := api.SubmitBidWithCorrelationId(ctx, bid)
+	return err
+}
+
+// SubmitBidWithCorrelationId is SubmitBid, exposed as
+// auctioneer_submitBidWithCorrelationId, returning the correlation id the bid
+// can be tracked by, the one supplied with the bid if any.
+func (api *BidValidatorAPI) SubmitBidWithCorrelationId(ctx context.Context, bid *JsonBid) (string, error) {
 	receivedBidsCounter.Inc(1)
-	validatedBid, err := bv.validateBid(
-		&Bid{
-			ChainId:                bid.ChainId.ToInt(),
-			ExpressLaneController:  bid.ExpressLaneController,
-			AuctionContractAddress: bid.AuctionContractAddress,
-			Round:                  uint64(bid.Round),
-			Amount:                 bid.Amount.ToInt(),
-			Signature:              bid.Signature,
-		},
-		bv.auctionContract.BalanceOf,
-	)
+	if bid == nil {
+		return "", errors.Wrap(ErrMalformedData, "nil bid")
+	}
+	if err := assignCorrelationId(bid); err != nil {
+		return "", err
+	}
+	bv := api.bidValidator
+	ctx, span := bv.getTracer().Start(ctx, "timeboost.SubmitBid", trace.WithSpanKind(trace.SpanKindServer), bidSpanAttributes(uint64(bid.Round), bid.ExpressLaneController.Hex(), bid.CorrelationId))
+	err := bv.submitToWorkers(ctx, bid)
+	endSpan(span, err)
 	if err != nil {
-		return err
+		return "", err
+	}
+	return bid.CorrelationId, nil
+}
+
+// processBid validates a bid and publishes it to the auctioneer. It is run by
+// the validation workers, concurrently with other bids.
+func (bv *BidValidator) processBid(ctx context.Context, bid *JsonBid) (err error) {
+	ctx, span := bv.getTracer().Start(ctx, "timeboost.validateBid")
+	defer func() { endSpan(span, err) }()
+	start := time.Now()
+	seen, claimed := bv.claimBid(bid.Signature)
+	if seen {
+		// The exact same signed bid was already accepted, e.g. a client retry.
+		log.Debug("Ignoring resubmitted bid", "controller", bid.ExpressLaneController.Hex(), "round", uint64(bid.Round), "correlationId", bid.CorrelationId)
+		return nil
+	}
+	if !claimed {
+		return errBidBeingValidated
+	}
+	published := false
+	defer func() { bv.releaseBid(bid.Signature, published) }()
+	details := &bidCheckDetails{}
+	goBid, err := bidFromJson(bid)
+	if err != nil {
+		return bv.logRejectedBid(bid, details, err)
+	}
+	validatedBid, err := bv.checkBidWithDetails(goBid, bv.auctionContract.BalanceOf, true, details)
+	if err != nil {
+		return bv.logRejectedBid(bid, details, err)
+	}
+	if err = bv.applyBidPolicy(ctx, validatedBid); err != nil {
+		return bv.logRejectedBid(bid, details, err)
 	}
 	validatedBidsCounter.Inc(1)
-	log.Info("Validated bid", "bidder", validatedBid.Bidder.Hex(), "amount", validatedBid.Amount.String(), "round", validatedBid.Round, "elapsed", time.Since(start))
+	log.Info("Validated bid", "bidder", validatedBid.Bidder.Hex(), "amount", validatedBid.Amount.String(), "round", validatedBid.Round, "correlationId", validatedBid.CorrelationId, "elapsed", time.Since(start))
+	injectBidTrace(ctx, validatedBid)
 	_, err = bv.producer.Produce(ctx, validatedBid)
 	if err != nil {
 		return err
 	}
+	published = true
+	bv.bidFeed.publish(validatedBid, time.Now())
 	return nil
 }
 
-func (bv *BidValidator) setReservePrice(p *big.Int) {
+// CheckBid reports whether a bid would be accepted if submitted now, with the
+// same errors SubmitBid would return, without submitting it. Checking a bid
+// doesn't count towards the bidder's per-round bid limit.
+func (api *BidValidatorAPI) CheckBid(ctx context.Context, bid *JsonBid) error {
+	if bid == nil {
+		return errors.Wrap(ErrMalformedData, "nil bid")
+	}
+	goBid, err := bidFromJson(bid)
+	if err != nil {
+		return err
+	}
+	bv := api.bidValidator
+	validatedBid, err := bv.checkBid(goBid, bv.auctionContract.BalanceOf, false)
+	if err != nil {
+		return err
+	}
+	return bv.applyBidPolicy(ctx, validatedBid)
+}
+
+func bidFromJson(bid *JsonBid) (*Bid, error) {
+	amount := bid.Amount.ToInt()
+	if bid.AmountDecimal != "" {
+		decimal, err := ParseBidAmount(bid.AmountDecimal)
+		if err != nil {
+			return nil, err
+		}
+		if amount != nil && amount.Cmp(decimal) != 0 {
+			return nil, errors.Wrapf(ErrMalformedData, "bid amount %s differs from decimal amount %q", amount.String(), bid.AmountDecimal)
+		}
+		amount = decimal
+	}
+	return &Bid{
+		ChainId:                bid.ChainId.ToInt(),
+		ExpressLaneController:  bid.ExpressLaneController,
+		AuctionContractAddress: bid.AuctionContractAddress,
+		Round:                  uint64(bid.Round),
+		Amount:                 amount,
+		Signature:              bid.Signature,
+		CorrelationId:          bid.CorrelationId,
+	}, nil
+}
+
+// resetRound forgets the bids of the round whose auction just closed. Every
+// structure tracking the bids of a round is reset here, so that none of them
+// grows over the lifetime of the bid validator.
+func (bv *BidValidator) resetRound() {
+	bv.Lock()
+	defer bv.Unlock()
+	bv.bidsPerSenderInRound = make(map[common.Address]uint8)
+	bv.seenBidSignatures = make(map[string]struct{})
+	bv.validatingBidSignatures = make(map[string]struct{})
+}
+
+// checkBidsPerSender rejects bids from a bidder that already sent the maximum
+// allowed bids this round. It doesn't track bidders that sent no bid yet, so
+// that bids signed by arbitrary keys don't add to the tracked bidders.
+func (bv *BidValidator) checkBidsPerSender(bidder common.Address) error {
+	bv.RLock()
+	defer bv.RUnlock()
+	if numBids := bv.bidsPerSenderInRound[bidder]; numBids >= bv.maxBidsPerSenderInRound {
+		return errors.Wrapf(ErrTooManyBids, "bidder %s has already sent the maximum allowed bids = %d in this round", bidder.Hex(), numBids)
+	}
+	return nil
+}
+
+// countBidOfSender counts a bid towards its bidder's limit for the round. Only
+// bids backed by a deposit are counted, which bounds the bidders tracked in a
+// round by the depositors of the auction contract.
+func (bv *BidValidator) countBidOfSender(bidder common.Address) error {
+	bv.Lock()
+	defer bv.Unlock()
+	// Checked again, as other bids of the bidder may have been counted since.
+	if numBids := bv.bidsPerSenderInRound[bidder]; numBids >= bv.maxBidsPerSenderInRound {
+		return errors.Wrapf(ErrTooManyBids, "bidder %s has already sent the maximum allowed bids = %d in this round", bidder.Hex(), numBids)
+	}
+	bv.bidsPerSenderInRound[bidder]++
+	return nil
+}
+
+// bidSeen reports whether a bid with this exact signature was already
+// published this round. A different bid, even from the same bidder, is
+// signed differently and is never considered seen.
+func (bv *BidValidator) bidSeen(signature []byte) bool {
+	bv.RLock()
+	defer bv.RUnlock()
+	_, seen := bv.seenBidSignatures[string(signature)]
+	return seen
+}
+
+func (bv *BidValidator) markBidSeen(signature []byte) {
+	bv.Lock()
+	defer bv.Unlock()
+	bv.seenBidSignatures[string(signature)] = struct{}{}
+}
+
+// SetReservePrice sets the reserve price bids are validated against.
+func (bv *BidValidator) SetReservePrice(p *big.Int) {
 	bv.reservePriceLock.Lock()
 	defer bv.reservePriceLock.Unlock()
 	bv.reservePrice = p
 }
 
-func (bv *BidValidator) fetchReservePrice() *big.Int {
+// ReservePrice returns the reserve price bids are validated against.
+func (bv *BidValidator) ReservePrice() *big.Int {
 	bv.reservePriceLock.RLock()
 	defer bv.reservePriceLock.RUnlock()
 	return bv.reservePrice
 }
 
+// setFinalReservePrice sets the reserve price read for the given round after
+// its reserve submission deadline, when it can no longer change.
+func (bv *BidValidator) setFinalReservePrice(round uint64, p *big.Int) {
+	bv.reservePriceLock.Lock()
+	defer bv.reservePriceLock.Unlock()
+	bv.reservePrice = p
+	bv.finalReservePriceRound = round
+}
+
+// hasFinalReservePrice reports whether the reserve price bids are validated
+// against is the final one of the given round.
+func (bv *BidValidator) hasFinalReservePrice(round uint64) bool {
+	bv.reservePriceLock.RLock()
+	defer bv.reservePriceLock.RUnlock()
+	return bv.finalReservePriceRound == round
+}
+
+// InReserveSubmissionWindow reports whether the reserve submission deadline
+// of the upcoming round has passed, but its auction is not yet closed.
+func (bv *BidValidator) InReserveSubmissionWindow() bool {
+	return bv.roundTimingInfo.InReserveSubmissionWindowAt(bv.clock.now())
+}
+
+func (bv *BidValidator) upcomingRound() uint64 {
+	return bv.roundTimingInfo.RoundNumberAt(bv.clock.now()) + 1
+}
+
+// SetMinReservePrice sets the floor enforced on the reserve price.
+func (bv *BidValidator) SetMinReservePrice(p *big.Int) {
+	bv.minReservePriceLock.Lock()
+	defer bv.minReservePriceLock.Unlock()
+	bv.minReservePrice = p
+}
+
+// MinReservePrice returns the floor enforced on the reserve price.
+func (bv *BidValidator) MinReservePrice() *big.Int {
+	bv.minReservePriceLock.RLock()
+	defer bv.minReservePriceLock.RUnlock()
+	return bv.minReservePrice
+}
+
+// enforceMinReservePrice returns the reserve price that bids are validated against.
+// The contract should never report a reserve price below its min reserve price,
+// but if it does, the min reserve price is enforced instead so that bids the
+// contract would reject are not accepted.
+func enforceMinReservePrice(reservePrice, minReservePrice *big.Int) (*big.Int, error) {
+	if reservePrice == nil || minReservePrice == nil {
+		return nil, errors.New("reserve price and min reserve price must be set")
+	}
+	if reservePrice.Cmp(minReservePrice) < 0 {
+		log.Warn("Reserve price is below min reserve price, enforcing min reserve price instead", "reservePrice", reservePrice.String(), "minReservePrice", minReservePrice.String())
+		return new(big.Int).Set(minReservePrice), nil
+	}
+	return reservePrice, nil
+}
+
+// bidFloor returns the minimum amount bids must meet given the reserve price,
+// which is never below the reserve price itself.
+func (bv *BidValidator) bidFloor(reservePrice *big.Int) *big.Int {
+	floor := reservePrice
+	if bv.bidFloorMultiplier > 0 {
+		floor = arbmath.BigMax(floor, arbmath.BigMulByBips(reservePrice, bv.bidFloorMultiplier))
+	}
+	if bv.bidFloorDelta != nil && bv.bidFloorDelta.Sign() > 0 {
+		floor = arbmath.BigMax(floor, new(big.Int).Add(reservePrice, bv.bidFloorDelta))
+	}
+	return floor
+}
+
+// meetsReservePrice reports whether a bid amount meets the reserve price. The
+// boundary is inclusive, as in the auction contract: a bid of exactly the
+// reserve price is eligible, whether it is the only bid of its round or one of
+// the two bids the round is resolved with. The auctioneer never compares bids
+// to the reserve price itself, it only relies on the BelowReservePrice flag
+// set from this check.
+//
+// Bids are judged against the reserve price of their round at the time they
+// are validated, which is recorded with them. A reserve price raised later in
+// the round, by the contract, an oracle or an override, only applies to bids
+// validated after it, and never makes an accepted bid ineligible. The
+// contract's reserve price can't change once the reserve submission deadline
+// passed, and bids arriving after it wait for its final value, see
+// ErrReservePriceNotFinal, so only bids placed before the deadline can be
+// below the reserve price the contract resolves the round with, in which case
+// the resolution reverts.
+func meetsReservePrice(amount, reservePrice *big.Int) bool {
+	return amount.Cmp(reservePrice) >= 0
+}
+
+// validateBidAmount rejects bid amounts that can only be the result of a
+// malformed client: missing, non-positive, or above the configured maximum.
+func validateBidAmount(amount, maxBidAmount *big.Int) error {
+	if amount == nil {
+		return errors.Wrap(ErrMalformedData, "empty bid amount")
+	}
+	if amount.Sign() <= 0 {
+		return errors.Wrapf(ErrMalformedData, "bid amount %s is not positive", amount.String())
+	}
+	if maxBidAmount != nil && amount.Cmp(maxBidAmount) > 0 {
+		return errors.Wrapf(ErrMalformedData, "bid amount %s exceeds maximum %s", amount.String(), maxBidAmount.String())
+	}
+	return nil
+}
+
 func (bv *BidValidator) validateBid(
 	bid *Bid,
 	balanceCheckerFn func(opts *bind.CallOpts, account common.Address) (*big.Int, error)) (*JsonValidatedBid, error) {
+	return bv.checkBid(bid, balanceCheckerFn, true)
+}
+
+// checkBid validates a bid. Unless countBid is set, the bid is not counted
+// towards the bidder's per-round bid limit, although the limit is still enforced.
+func (bv *BidValidator) checkBid(
+	bid *Bid,
+	balanceCheckerFn func(opts *bind.CallOpts, account common.Address) (*big.Int, error),
+	countBid bool) (*JsonValidatedBid, error) {
+	return bv.checkBidWithDetails(bid, balanceCheckerFn, countBid, &bidCheckDetails{})
+}
+
+// checkBidWithDetails checks a bid like checkBid, recording what it found out
+// about the bid in details as it goes.
+func (bv *BidValidator) checkBidWithDetails(
+	bid *Bid,
+	balanceCheckerFn func(opts *bind.CallOpts, account common.Address) (*big.Int, error),
+	countBid bool,
+	details *bidCheckDetails) (*JsonValidatedBid, error) {
 	// Check basic integrity.
 	if bid == nil {
 		return nil, errors.Wrap(ErrMalformedData, "nil bid")
@@ -285,6 +731,9 @@ func (bv *BidValidator) validateBid(
 	if bid.ExpressLaneController == (common.Address{}) {
 		return nil, errors.Wrap(ErrMalformedData, "empty express lane controller address")
 	}
+	if _, denied := bv.deniedExpressLaneControllers[bid.ExpressLaneController]; denied {
+		return nil, errors.Wrapf(ErrDeniedController, "express lane controller %s", bid.ExpressLaneController.Hex())
+	}
 	if bid.ChainId == nil {
 		return nil, errors.Wrap(ErrMalformedData, "empty chain id")
 	}
@@ -295,19 +744,47 @@ func (bv *BidValidator) validateBid(
 	}
 
 	// Check if the bid is intended for upcoming round.
-	upcomingRound := bv.roundTimingInfo.RoundNumber() + 1
+	now := bv.clock.now()
+	upcomingRound := bv.roundTimingInfo.RoundNumberAt(now) + 1
+	details.expectedRound = upcomingRound
 	if bid.Round != upcomingRound {
+		if bv.rejectLateBids && bid.Round+1 == upcomingRound {
+			// The round it declares started while the bid was on its way.
+			return nil, bv.lateBidError(bid, now)
+		}
 		return nil, errors.Wrapf(ErrBadRoundNumber, "wanted %d, got %d", upcomingRound, bid.Round)
 	}
 
-	// Check if the auction is closed.
-	if bv.roundTimingInfo.isAuctionRoundClosed() {
+	// Check if the auction is closed, tolerating a local clock running ahead.
+	if isAuctionClosedAt(bv.roundTimingInfo, bv.closingDurationForRound, now) &&
+		isAuctionClosedAt(bv.roundTimingInfo, bv.closingDurationForRound, now.Add(-bv.clock.config.Tolerance)) {
+		if bv.rejectLateBids {
+			return nil, bv.lateBidError(bid, now)
+		}
 		return nil, errors.Wrap(ErrBadRoundNumber, "auction is closed")
 	}
 
-	// Check bid is higher than or equal to reserve price.
-	if bid.Amount.Cmp(bv.reservePrice) == -1 {
-		return nil, errors.Wrapf(ErrReservePriceNotMet, "reserve price %s, bid %s", bv.reservePrice.String(), bid.Amount.String())
+	if err := validateBidAmount(bid.Amount, bv.maxBidAmount); err != nil {
+		return nil, err
+	}
+
+	// The contract resolves with the reserve price read after the reserve
+	// submission deadline, so bids arriving after it must be checked against
+	// that price rather than one that may still have changed.
+	if bv.roundTimingInfo.InReserveSubmissionWindowAt(now) && !bv.hasFinalReservePrice(upcomingRound) {
+		return nil, errors.Wrapf(ErrReservePriceNotFinal, "reserve price of round %d not read yet", upcomingRound)
+	}
+
+	// Check bid is higher than or equal to reserve price. The reserve price is
+	// never below the min reserve price, see enforceMinReservePrice.
+	reservePrice := bv.reservePriceOfRound(upcomingRound)
+	details.reservePrice = reservePrice
+	belowReservePrice := !meetsReservePrice(bid.Amount, reservePrice)
+	if belowReservePrice && !bv.acceptBidsBelowReservePrice {
+		return nil, errors.Wrapf(ErrReservePriceNotMet, "reserve price %s, bid %s", reservePrice.String(), bid.Amount.String())
+	}
+	if floor := bv.bidFloor(reservePrice); !belowReservePrice && bid.Amount.Cmp(floor) < 0 {
+		return nil, errors.Wrapf(ErrBidFloorNotMet, "bid floor %s, reserve price %s, bid %s", floor.String(), reservePrice.String(), bid.Amount.String())
 	}
 
 	// Validate the signature.
@@ -328,36 +805,48 @@ func (bv *BidValidator) validateBid(
 
 	bidHash, err := bid.ToEIP712Hash(bv.auctionContractDomainSeparator)
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrapf(ErrMalformedData, "could not hash bid: %v", err)
 	}
+	recoveryStart := time.Now()
 	pubkey, err := crypto.SigToPub(bidHash[:], sigItem)
+	recoveryTime := time.Since(recoveryStart)
+	sigRecoveryTimer.Update(recoveryTime)
+	sigRecoveryCounter.Inc(1)
+	if recoveryTime > slowSigRecovery {
+		log.Debug("Slow bid signature recovery", "duration", recoveryTime, "round", bid.Round)
+	}
 	if err != nil {
-		return nil, ErrMalformedData
+		return nil, errors.Wrapf(ErrWrongSignature, "could not recover bidder: %v", err)
 	}
-	// Check how many bids the bidder has sent in this round and cap according to a limit.
 	bidder := crypto.PubkeyToAddress(*pubkey)
-	bv.Lock()
-	numBids, ok := bv.bidsPerSenderInRound[bidder]
-	if !ok {
-		bv.bidsPerSenderInRound[bidder] = 0
+	details.signer = &bidder
+	if err := bv.checkControllerSignature(bid, bidder); err != nil {
+		return nil, err
 	}
-	if numBids >= bv.maxBidsPerSenderInRound {
-		bv.Unlock()
-		return nil, errors.Wrapf(ErrTooManyBids, "bidder %s has already sent the maximum allowed bids = %d in this round", bidder.Hex(), numBids)
+	// Check how many bids the bidder has sent in this round and cap according to a limit.
+	if err := bv.checkBidsPerSender(bidder); err != nil {
+		return nil, err
 	}
-	bv.bidsPerSenderInRound[bidder]++
-	bv.Unlock()
 
-	depositBal, err := balanceCheckerFn(&bind.CallOpts{}, bidder)
+	depositBal, confirmedBal, err := bv.depositBalances(balanceCheckerFn, bidder)
 	if err != nil {
 		return nil, err
 	}
+	details.depositBalance = depositBal
 	if depositBal.Cmp(new(big.Int)) == 0 {
 		return nil, errors.Wrapf(ErrNotDepositor, "bidder %s", bidder.Hex())
 	}
 	if depositBal.Cmp(bid.Amount) < 0 {
 		return nil, errors.Wrapf(ErrInsufficientBalance, "bidder %s, onchain balance %#x, bid amount %#x", bidder.Hex(), depositBal, bid.Amount)
 	}
+	if confirmedBal.Cmp(bid.Amount) < 0 {
+		return nil, errors.Wrapf(ErrInsufficientBalance, "bidder %s, onchain balance %#x of which %#x has %d confirmations, bid amount %#x", bidder.Hex(), depositBal, confirmedBal, bv.depositConfirmations, bid.Amount)
+	}
+	if countBid {
+		if err := bv.countBidOfSender(bidder); err != nil {
+			return nil, err
+		}
+	}
 	vb := &ValidatedBid{
 		ExpressLaneController:  bid.ExpressLaneController,
 		Amount:                 bid.Amount,
@@ -366,6 +855,9 @@ func (bv *BidValidator) validateBid(
 		AuctionContractAddress: bid.AuctionContractAddress,
 		Round:                  bid.Round,
 		Bidder:                 bidder,
+		BelowReservePrice:      belowReservePrice,
+		ReservePrice:           reservePrice,
+		CorrelationId:          bid.CorrelationId,
 	}
 	return vb.ToJson(), nil
 }
@@ -3,6 +3,7 @@ package timeboost
 import (
 	"context"
 	"math/big"
+	"sync"
 	"testing"
 	"time"
 
@@ -10,7 +11,11 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/offchainlabs/nitro/util/arbmath"
 )
 
 func TestBidValidator_validateBid(t *testing.T) {
@@ -35,6 +40,24 @@ func TestBidValidator_validateBid(t *testing.T) {
 			expectedErr: ErrMalformedData,
 			errMsg:      "incorrect auction contract address",
 		},
+		{
+			name: "zero express lane controller address",
+			bid: &Bid{
+				AuctionContractAddress: setup.expressLaneAuctionAddr,
+			},
+			expectedErr: ErrMalformedData,
+			errMsg:      "empty express lane controller address",
+		},
+		{
+			name: "denied express lane controller address",
+			bid: &Bid{
+				ExpressLaneController:  common.Address{'d'},
+				AuctionContractAddress: setup.expressLaneAuctionAddr,
+				ChainId:                big.NewInt(1),
+			},
+			expectedErr: ErrDeniedController,
+			errMsg:      common.Address{'d'}.Hex(),
+		},
 		{
 			name: "incorrect chain id",
 			bid: &Bid{
@@ -92,6 +115,19 @@ func TestBidValidator_validateBid(t *testing.T) {
 			expectedErr: ErrMalformedData,
 			errMsg:      "signature length is not 65",
 		},
+		{
+			name: "unrecoverable signature",
+			bid: &Bid{
+				ExpressLaneController:  common.Address{'b'},
+				AuctionContractAddress: setup.expressLaneAuctionAddr,
+				ChainId:                big.NewInt(1),
+				Round:                  1,
+				Amount:                 big.NewInt(3),
+				Signature:              make([]byte, 65),
+			},
+			expectedErr: ErrWrongSignature,
+			errMsg:      "could not recover bidder",
+		},
 		{
 			name:        "not a depositor",
 			bid:         buildValidBid(t, setup.expressLaneAuctionAddr),
@@ -112,6 +148,9 @@ func TestBidValidator_validateBid(t *testing.T) {
 			auctionContractAddr:     setup.expressLaneAuctionAddr,
 			bidsPerSenderInRound:    make(map[common.Address]uint8),
 			maxBidsPerSenderInRound: 5,
+			deniedExpressLaneControllers: map[common.Address]struct{}{
+				{'d'}: {},
+			},
 		}
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.auctionClosed {
@@ -141,26 +180,12 @@ func TestBidValidator_validateBid_perRoundBidLimitReached(t *testing.T) {
 		bidsPerSenderInRound:           make(map[common.Address]uint8),
 		maxBidsPerSenderInRound:        5,
 		auctionContractAddr:            auctionContractAddr,
-		auctionContractDomainSeparator: common.Hash{},
+		auctionContractDomainSeparator: ComputeDomainSeparator(big.NewInt(1), auctionContractAddr),
 	}
 	privateKey, err := crypto.GenerateKey()
 	require.NoError(t, err)
-	bid := &Bid{
-		ExpressLaneController:  common.Address{'b'},
-		AuctionContractAddress: auctionContractAddr,
-		ChainId:                big.NewInt(1),
-		Round:                  1,
-		Amount:                 big.NewInt(3),
-		Signature:              []byte{'a'},
-	}
-
-	bidHash, err := bid.ToEIP712Hash(bv.auctionContractDomainSeparator)
-	require.NoError(t, err)
-
-	signature, err := crypto.Sign(bidHash[:], privateKey)
+	bid, err := NewSignedBid(privateKey, 1, big.NewInt(3), common.Address{'b'}, big.NewInt(1), auctionContractAddr)
 	require.NoError(t, err)
-
-	bid.Signature = signature
 	for i := 0; i < int(bv.maxBidsPerSenderInRound); i++ {
 		_, err := bv.validateBid(bid, balanceCheckerFn)
 		require.NoError(t, err)
@@ -170,25 +195,674 @@ func TestBidValidator_validateBid_perRoundBidLimitReached(t *testing.T) {
 
 }
 
-func buildValidBid(t *testing.T, auctionContractAddr common.Address) *Bid {
+func TestBidValidator_checkBid_doesNotCountBid(t *testing.T) {
+	t.Parallel()
+	balanceCheckerFn := func(_ *bind.CallOpts, _ common.Address) (*big.Int, error) {
+		return big.NewInt(10), nil
+	}
+	auctionContractAddr := common.Address{'a'}
+	bv := BidValidator{
+		chainId: big.NewInt(1),
+		roundTimingInfo: RoundTimingInfo{
+			Offset:         time.Now().Add(-time.Second),
+			Round:          time.Minute,
+			AuctionClosing: 45 * time.Second,
+		},
+		reservePrice:            big.NewInt(2),
+		bidsPerSenderInRound:    make(map[common.Address]uint8),
+		maxBidsPerSenderInRound: 1,
+		auctionContractAddr:     auctionContractAddr,
+	}
+	bid := buildValidBid(t, auctionContractAddr)
+
+	// Checking a bid any number of times leaves the bidder's bid limit alone.
+	for i := 0; i < 3; i++ {
+		_, err := bv.checkBid(bid, balanceCheckerFn, false)
+		require.NoError(t, err)
+	}
+	_, err := bv.validateBid(bid, balanceCheckerFn)
+	require.NoError(t, err)
+
+	// Once the limit is reached, checking reports the same error as submitting.
+	_, err = bv.checkBid(bid, balanceCheckerFn, false)
+	require.ErrorIs(t, err, ErrTooManyBids)
+
+	// Invalid bids are reported with the errors of the submit path.
+	tooLow := *bid
+	tooLow.Amount = big.NewInt(1)
+	_, err = bv.checkBid(&tooLow, balanceCheckerFn, false)
+	require.ErrorIs(t, err, ErrReservePriceNotMet)
+}
+
+func buildValidBid(t testing.TB, auctionContractAddr common.Address) *Bid {
+	return buildValidBidForChain(t, auctionContractAddr, big.NewInt(1))
+}
+
+func buildValidBidForChain(t testing.TB, auctionContractAddr common.Address, chainId *big.Int) *Bid {
 	privateKey, err := crypto.GenerateKey()
 	require.NoError(t, err)
-	bid := &Bid{
-		ExpressLaneController:  common.Address{'b'},
-		AuctionContractAddress: auctionContractAddr,
-		ChainId:                big.NewInt(1),
-		Round:                  1,
-		Amount:                 big.NewInt(3),
-		Signature:              []byte{'a'},
+	bid, err := NewSignedBid(privateKey, 1, big.NewInt(3), common.Address{'b'}, chainId, auctionContractAddr)
+	require.NoError(t, err)
+	return bid
+}
+
+// Each chain runs its own bid validator and auctioneer. Bids must only ever
+// reach the cache of the chain they were signed for.
+func TestBidValidator_validateBid_chainIsolation(t *testing.T) {
+	t.Parallel()
+	balanceCheckerFn := func(_ *bind.CallOpts, _ common.Address) (*big.Int, error) {
+		return big.NewInt(10), nil
+	}
+	type chain struct {
+		validator  *BidValidator
+		auctioneer *AuctioneerServer
+	}
+	newChain := func(chainId int64, auctionContractAddr common.Address) *chain {
+		return &chain{
+			validator: &BidValidator{
+				chainId: big.NewInt(chainId),
+				roundTimingInfo: RoundTimingInfo{
+					Offset:         time.Now().Add(-time.Second),
+					Round:          time.Minute,
+					AuctionClosing: 45 * time.Second,
+				},
+				reservePrice:            big.NewInt(2),
+				bidsPerSenderInRound:    make(map[common.Address]uint8),
+				maxBidsPerSenderInRound: 5,
+				auctionContractAddr:     auctionContractAddr,
+			},
+			auctioneer: &AuctioneerServer{bidCache: newBidCache([32]byte{}), auctionContractAddr: auctionContractAddr},
+		}
+	}
+	chains := []*chain{
+		newChain(1, common.Address{'a'}),
+		newChain(2, common.Address{'c'}),
+	}
+	submit := func(bid *Bid) []error {
+		var errs []error
+		for _, c := range chains {
+			validated, err := c.validator.validateBid(bid, balanceCheckerFn)
+			errs = append(errs, err)
+			if err == nil {
+				c.auctioneer.receiveValidatedBid(validated)
+			}
+		}
+		return errs
+	}
+
+	errs := submit(buildValidBidForChain(t, common.Address{'a'}, big.NewInt(1)))
+	require.NoError(t, errs[0])
+	require.ErrorIs(t, errs[1], ErrMalformedData)
+	errs = submit(buildValidBidForChain(t, common.Address{'c'}, big.NewInt(2)))
+	require.ErrorIs(t, errs[0], ErrMalformedData)
+	require.NoError(t, errs[1])
+	// A bid for a chain without an auctioneer is rejected everywhere, even if
+	// it names the auction contract of a configured chain.
+	for _, auctionContractAddr := range []common.Address{{'a'}, {'c'}} {
+		for _, err := range submit(buildValidBidForChain(t, auctionContractAddr, big.NewInt(3))) {
+			require.Error(t, err)
+		}
+	}
+	_, err := chains[0].validator.validateBid(buildValidBidForChain(t, common.Address{'a'}, big.NewInt(3)), balanceCheckerFn)
+	require.ErrorIs(t, err, ErrWrongChainId)
+
+	for i, c := range chains {
+		require.Equal(t, 1, c.auctioneer.bidCache.size())
+		result := c.auctioneer.bidCache.topTwoBids()
+		require.Nil(t, result.secondPlace)
+		require.Equal(t, big.NewInt(int64(i+1)), result.firstPlace.ChainId)
 	}
+}
 
-	bidHash, err := bid.ToEIP712Hash(common.Hash{})
+func TestEnforceMinReservePrice(t *testing.T) {
+	t.Parallel()
+	// A reserve price above the min reserve price is used as-is.
+	rp, err := enforceMinReservePrice(big.NewInt(5), big.NewInt(2))
 	require.NoError(t, err)
+	require.Equal(t, big.NewInt(5), rp)
 
-	signature, err := crypto.Sign(bidHash[:], privateKey)
+	// A reserve price equal to the min reserve price is used as-is.
+	rp, err = enforceMinReservePrice(big.NewInt(2), big.NewInt(2))
 	require.NoError(t, err)
+	require.Equal(t, big.NewInt(2), rp)
 
-	bid.Signature = signature
+	// A misconfigured reserve price below the min reserve price is clamped.
+	minReservePrice := big.NewInt(3)
+	rp, err = enforceMinReservePrice(big.NewInt(1), minReservePrice)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(3), rp)
+	rp.SetInt64(10)
+	require.Equal(t, big.NewInt(3), minReservePrice, "clamped reserve price must not alias the min reserve price")
 
-	return bid
+	_, err = enforceMinReservePrice(nil, big.NewInt(1))
+	require.Error(t, err)
+	_, err = enforceMinReservePrice(big.NewInt(1), nil)
+	require.Error(t, err)
+}
+
+func TestBidValidatorReservePriceAccessors(t *testing.T) {
+	t.Parallel()
+	bv := &BidValidator{
+		reservePrice:    big.NewInt(2),
+		minReservePrice: big.NewInt(1),
+	}
+	// Run with -race to check that concurrent reads and writes are safe.
+	// Prices are read on spawned goroutines but checked on the test goroutine.
+	var wg sync.WaitGroup
+	read := make(chan [2]*big.Int, 10)
+	for i := int64(0); i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			bv.SetReservePrice(big.NewInt(i + 2))
+			bv.SetMinReservePrice(big.NewInt(i + 1))
+		}()
+		go func() {
+			defer wg.Done()
+			read <- [2]*big.Int{bv.ReservePrice(), bv.MinReservePrice()}
+		}()
+	}
+	wg.Wait()
+	close(read)
+	for prices := range read {
+		require.NotNil(t, prices[0])
+		require.NotNil(t, prices[1])
+	}
+
+	bv.SetReservePrice(big.NewInt(20))
+	bv.SetMinReservePrice(big.NewInt(10))
+	require.Equal(t, big.NewInt(20), bv.ReservePrice())
+	require.Equal(t, big.NewInt(10), bv.MinReservePrice())
+}
+
+func TestValidateBidAmount(t *testing.T) {
+	t.Parallel()
+	maxBidAmount := big.NewInt(1000)
+	tests := []struct {
+		name   string
+		amount *big.Int
+		errMsg string
+	}{
+		{name: "nil", amount: nil, errMsg: "empty bid amount"},
+		{name: "negative", amount: big.NewInt(-1), errMsg: "bid amount -1 is not positive"},
+		{name: "zero", amount: big.NewInt(0), errMsg: "bid amount 0 is not positive"},
+		{name: "one", amount: big.NewInt(1)},
+		{name: "maximum", amount: big.NewInt(1000)},
+		{name: "above maximum", amount: big.NewInt(1001), errMsg: "bid amount 1001 exceeds maximum 1000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBidAmount(tt.amount, maxBidAmount)
+			if tt.errMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrMalformedData)
+			require.ErrorContains(t, err, tt.errMsg)
+		})
+	}
+	// Without a maximum, any positive amount is accepted.
+	require.NoError(t, validateBidAmount(new(big.Int).Lsh(big.NewInt(1), 255), nil))
+}
+
+func TestBidValidator_validateBid_bidFloor(t *testing.T) {
+	t.Parallel()
+	balanceCheckerFn := func(_ *bind.CallOpts, _ common.Address) (*big.Int, error) {
+		return big.NewInt(10), nil
+	}
+	auctionContractAddr := common.Address{'a'}
+	newBidValidator := func(multiplier arbmath.Bips, delta int64) *BidValidator {
+		return &BidValidator{
+			chainId: big.NewInt(1),
+			roundTimingInfo: RoundTimingInfo{
+				Offset:         time.Now().Add(-time.Second),
+				Round:          time.Minute,
+				AuctionClosing: 45 * time.Second,
+			},
+			reservePrice:            big.NewInt(3),
+			bidsPerSenderInRound:    make(map[common.Address]uint8),
+			maxBidsPerSenderInRound: 5,
+			auctionContractAddr:     auctionContractAddr,
+			bidFloorMultiplier:      multiplier,
+			bidFloorDelta:           big.NewInt(delta),
+		}
+	}
+	// The bid amount is 3, exactly the reserve price.
+	bid := buildValidBid(t, auctionContractAddr)
+
+	tests := []struct {
+		name       string
+		multiplier arbmath.Bips
+		delta      int64
+		wantErr    error
+	}{
+		{name: "disabled"},
+		{name: "multiplier floor equals reserve", multiplier: arbmath.OneInBips},
+		{name: "multiplier floor above reserve", multiplier: 2 * arbmath.OneInBips, wantErr: ErrBidFloorNotMet},
+		{name: "multiplier below one is ignored", multiplier: arbmath.OneInBips / 2},
+		{name: "delta floor above reserve", delta: 1, wantErr: ErrBidFloorNotMet},
+		{name: "larger floor applies", multiplier: arbmath.OneInBips, delta: 1, wantErr: ErrBidFloorNotMet},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newBidValidator(tt.multiplier, tt.delta).validateBid(bid, balanceCheckerFn)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				reason, ok := BidRejectionReason(err)
+				require.True(t, ok)
+				require.Equal(t, "BID_FLOOR_NOT_MET", reason)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBidValidatorSeenBidSignatures(t *testing.T) {
+	t.Parallel()
+	bv := &BidValidator{
+		bidsPerSenderInRound: make(map[common.Address]uint8),
+		seenBidSignatures:    make(map[string]struct{}),
+	}
+	signature := make([]byte, 65)
+	signature[0] = 1
+	higherBidSignature := make([]byte, 65)
+	higherBidSignature[0] = 2
+
+	require.False(t, bv.bidSeen(signature))
+	bv.markBidSeen(signature)
+	require.True(t, bv.bidSeen(signature))
+	require.True(t, bv.bidSeen(common.CopyBytes(signature)))
+	// A different bid from the same bidder is signed differently.
+	require.False(t, bv.bidSeen(higherBidSignature))
+	// Signatures differing only in their last byte are distinct.
+	require.False(t, bv.bidSeen(append(common.CopyBytes(signature[:64]), 1)))
+
+	bv.resetRound()
+	require.False(t, bv.bidSeen(signature))
+}
+
+func TestBidValidatorRoundTrackingIsReset(t *testing.T) {
+	t.Parallel()
+	auctionContractAddr := common.Address{'a'}
+	bv := &BidValidator{
+		chainId: big.NewInt(1),
+		roundTimingInfo: RoundTimingInfo{
+			Offset:         time.Now().Add(-time.Second),
+			Round:          time.Minute,
+			AuctionClosing: 45 * time.Second,
+		},
+		reservePrice:                   big.NewInt(2),
+		bidsPerSenderInRound:           make(map[common.Address]uint8),
+		maxBidsPerSenderInRound:        5,
+		auctionContractAddr:            auctionContractAddr,
+		auctionContractDomainSeparator: ComputeDomainSeparator(big.NewInt(1), auctionContractAddr),
+		seenBidSignatures:              make(map[string]struct{}),
+		validatingBidSignatures:        make(map[string]struct{}),
+	}
+	depositors := make(map[common.Address]bool)
+	balanceCheckerFn := func(_ *bind.CallOpts, bidder common.Address) (*big.Int, error) {
+		if depositors[bidder] {
+			return big.NewInt(10), nil
+		}
+		return big.NewInt(0), nil
+	}
+
+	// A flood of bids from distinct bidders, only some of which have deposits.
+	for i := 0; i < 64; i++ {
+		bid := buildValidBid(t, auctionContractAddr)
+		bidHash, err := bid.ToEIP712Hash(bv.auctionContractDomainSeparator)
+		require.NoError(t, err)
+		sig := common.CopyBytes(bid.Signature)
+		sig[64] -= 27
+		pubkey, err := crypto.SigToPub(bidHash[:], sig)
+		require.NoError(t, err)
+		depositors[crypto.PubkeyToAddress(*pubkey)] = i%8 == 0
+
+		seen, claimed := bv.claimBid(bid.Signature)
+		require.False(t, seen)
+		require.True(t, claimed)
+		_, err = bv.validateBid(bid, balanceCheckerFn)
+		if i%8 == 0 {
+			require.NoError(t, err)
+			bv.releaseBid(bid.Signature, true)
+		} else {
+			require.ErrorIs(t, err, ErrNotDepositor)
+		}
+	}
+	// Bids without a deposit behind them aren't counted, and the rejected ones
+	// are left claimed, as if the round closed while they were validated.
+	require.Len(t, bv.bidsPerSenderInRound, 8)
+	require.Len(t, bv.seenBidSignatures, 8)
+	require.Len(t, bv.validatingBidSignatures, 56)
+
+	// The next round starts from scratch.
+	bv.resetRound()
+	require.Empty(t, bv.bidsPerSenderInRound)
+	require.Empty(t, bv.seenBidSignatures)
+	require.Empty(t, bv.validatingBidSignatures)
+}
+
+func TestBidValidator_validateBid_reservePriceEnforcement(t *testing.T) {
+	t.Parallel()
+	balanceCheckerFn := func(_ *bind.CallOpts, _ common.Address) (*big.Int, error) {
+		return big.NewInt(10), nil
+	}
+	auctionContractAddr := common.Address{'a'}
+	newBidValidator := func(reservePrice int64, lenient bool) *BidValidator {
+		return &BidValidator{
+			chainId: big.NewInt(1),
+			roundTimingInfo: RoundTimingInfo{
+				Offset:         time.Now().Add(-time.Second),
+				Round:          time.Minute,
+				AuctionClosing: 45 * time.Second,
+			},
+			reservePrice:                big.NewInt(reservePrice),
+			bidsPerSenderInRound:        make(map[common.Address]uint8),
+			maxBidsPerSenderInRound:     5,
+			auctionContractAddr:         auctionContractAddr,
+			acceptBidsBelowReservePrice: lenient,
+		}
+	}
+	// The bid amount is 3.
+	bid := buildValidBid(t, auctionContractAddr)
+
+	// Strict, the default.
+	_, err := newBidValidator(5, false).validateBid(bid, balanceCheckerFn)
+	require.ErrorIs(t, err, ErrReservePriceNotMet)
+	validated, err := newBidValidator(2, false).validateBid(bid, balanceCheckerFn)
+	require.NoError(t, err)
+	require.False(t, validated.BelowReservePrice)
+
+	// Lenient, bids below the reserve price are accepted but flagged.
+	validated, err = newBidValidator(5, true).validateBid(bid, balanceCheckerFn)
+	require.NoError(t, err)
+	require.True(t, validated.BelowReservePrice)
+	validated, err = newBidValidator(2, true).validateBid(bid, balanceCheckerFn)
+	require.NoError(t, err)
+	require.False(t, validated.BelowReservePrice)
+
+	// Flagged bids are never cached for resolution by the auctioneer.
+	a := &AuctioneerServer{bidCache: newBidCache([32]byte{})}
+	flagged, err := newBidValidator(5, true).validateBid(bid, balanceCheckerFn)
+	require.NoError(t, err)
+	a.receiveValidatedBid(flagged)
+	require.Equal(t, 0, a.bidCache.size())
+	a.receiveValidatedBid(validated)
+	require.Equal(t, 1, a.bidCache.size())
+}
+
+func TestBidValidator_validateBid_reservePriceBoundary(t *testing.T) {
+	t.Parallel()
+	balanceCheckerFn := func(_ *bind.CallOpts, _ common.Address) (*big.Int, error) {
+		return big.NewInt(10_000), nil
+	}
+	auctionContractAddr := common.Address{'a'}
+	reservePrice := big.NewInt(1_000)
+	bv := &BidValidator{
+		chainId: big.NewInt(1),
+		roundTimingInfo: RoundTimingInfo{
+			Offset:         time.Now().Add(-time.Second),
+			Round:          time.Minute,
+			AuctionClosing: 45 * time.Second,
+		},
+		reservePrice:            reservePrice,
+		bidsPerSenderInRound:    make(map[common.Address]uint8),
+		maxBidsPerSenderInRound: 5,
+		auctionContractAddr:     auctionContractAddr,
+	}
+	signBid := func(controller byte, amount *big.Int) *Bid {
+		privateKey, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		bid, err := NewSignedBid(privateKey, 1, amount, common.Address{controller}, big.NewInt(1), auctionContractAddr)
+		require.NoError(t, err)
+		return bid
+	}
+	oneWeiBelow := new(big.Int).Sub(reservePrice, common.Big1)
+	oneWeiAbove := new(big.Int).Add(reservePrice, common.Big1)
+
+	_, err := bv.validateBid(signBid('b', oneWeiBelow), balanceCheckerFn)
+	require.ErrorIs(t, err, ErrReservePriceNotMet)
+	atReserve, err := bv.validateBid(signBid('b', reservePrice), balanceCheckerFn)
+	require.NoError(t, err)
+	require.False(t, atReserve.BelowReservePrice)
+	aboveReserve, err := bv.validateBid(signBid('c', oneWeiAbove), balanceCheckerFn)
+	require.NoError(t, err)
+	require.False(t, aboveReserve.BelowReservePrice)
+
+	// A bid of exactly the reserve price wins a round on its own.
+	a := &AuctioneerServer{bidCache: newBidCache([32]byte{})}
+	a.receiveValidatedBid(atReserve)
+	result := a.bidCache.topTwoBids()
+	require.Equal(t, reservePrice, result.firstPlace.Amount)
+	require.Nil(t, result.secondPlace)
+
+	// And sets the price paid by a higher bid.
+	a.receiveValidatedBid(aboveReserve)
+	result = a.bidCache.topTwoBids()
+	require.Equal(t, oneWeiAbove, result.firstPlace.Amount)
+	require.Equal(t, reservePrice, result.secondPlace.Amount)
+}
+
+func TestBidValidator_roundReserveOverride(t *testing.T) {
+	t.Parallel()
+	balanceCheckerFn := func(_ *bind.CallOpts, _ common.Address) (*big.Int, error) {
+		return big.NewInt(10_000), nil
+	}
+	auctionContractAddr := common.Address{'a'}
+	bv := &BidValidator{
+		chainId: big.NewInt(1),
+		roundTimingInfo: RoundTimingInfo{
+			Offset:         time.Now().Add(-time.Second),
+			Round:          time.Minute,
+			AuctionClosing: 45 * time.Second,
+		},
+		reservePrice:            big.NewInt(1_000),
+		bidsPerSenderInRound:    make(map[common.Address]uint8),
+		maxBidsPerSenderInRound: 5,
+		auctionContractAddr:     auctionContractAddr,
+	}
+	signBid := func(controller byte, amount int64) *Bid {
+		privateKey, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		bid, err := NewSignedBid(privateKey, 1, big.NewInt(amount), common.Address{controller}, big.NewInt(1), auctionContractAddr)
+		require.NoError(t, err)
+		return bid
+	}
+	upcomingRound := bv.upcomingRound()
+	require.Error(t, bv.SetRoundReserveOverride(upcomingRound-1, big.NewInt(2_000)))
+	require.Error(t, bv.SetRoundReserveOverride(upcomingRound, big.NewInt(0)))
+
+	// The override only applies to its own round.
+	require.NoError(t, bv.SetRoundReserveOverride(upcomingRound+1, big.NewInt(2_000)))
+	_, err := bv.validateBid(signBid('b', 1_500), balanceCheckerFn)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(2_000), bv.reservePriceOfRound(upcomingRound+1))
+
+	require.NoError(t, bv.SetRoundReserveOverride(upcomingRound, big.NewInt(2_000)))
+	_, err = bv.validateBid(signBid('c', 1_500), balanceCheckerFn)
+	require.ErrorIs(t, err, ErrReservePriceNotMet)
+	_, err = bv.validateBid(signBid('c', 2_000), balanceCheckerFn)
+	require.NoError(t, err)
+
+	// An override below the standard reserve price doesn't lower it.
+	require.NoError(t, bv.SetRoundReserveOverride(upcomingRound, big.NewInt(500)))
+	_, err = bv.validateBid(signBid('d', 800), balanceCheckerFn)
+	require.ErrorIs(t, err, ErrReservePriceNotMet)
+
+	// Removing the override restores the standard reserve price.
+	require.NoError(t, bv.SetRoundReserveOverride(upcomingRound, nil))
+	require.Equal(t, big.NewInt(1_000), bv.reservePriceOfRound(upcomingRound))
+
+	// Overrides are forgotten once their round's auction closes.
+	require.NoError(t, bv.SetRoundReserveOverride(upcomingRound, big.NewInt(3_000)))
+	bv.clearReserveOverrides(upcomingRound)
+	require.Equal(t, big.NewInt(1_000), bv.reservePriceOfRound(upcomingRound))
+	require.Equal(t, big.NewInt(2_000), bv.reservePriceOfRound(upcomingRound+1))
+}
+
+func TestBidValidator_validateBid_reserveSubmissionWindow(t *testing.T) {
+	t.Parallel()
+	balanceCheckerFn := func(_ *bind.CallOpts, _ common.Address) (*big.Int, error) {
+		return big.NewInt(10), nil
+	}
+	auctionContractAddr := common.Address{'a'}
+	// With a round of a minute, the reserve submission window of round 1 is
+	// from 30 to 45 seconds into round 0.
+	newBidValidator := func(intoRound time.Duration) *BidValidator {
+		return &BidValidator{
+			chainId: big.NewInt(1),
+			roundTimingInfo: RoundTimingInfo{
+				Offset:            time.Now().Add(-intoRound),
+				Round:             time.Minute,
+				AuctionClosing:    15 * time.Second,
+				ReserveSubmission: 15 * time.Second,
+			},
+			reservePrice:            big.NewInt(2),
+			bidsPerSenderInRound:    make(map[common.Address]uint8),
+			maxBidsPerSenderInRound: 5,
+			auctionContractAddr:     auctionContractAddr,
+		}
+	}
+	// The bid amount is 3.
+	bid := buildValidBid(t, auctionContractAddr)
+
+	// Before the window, the reserve price may still change.
+	bv := newBidValidator(time.Second)
+	require.False(t, bv.InReserveSubmissionWindow())
+	_, err := bv.validateBid(bid, balanceCheckerFn)
+	require.NoError(t, err)
+
+	// Inside the window, bids wait for the final reserve price.
+	bv = newBidValidator(35 * time.Second)
+	require.True(t, bv.InReserveSubmissionWindow())
+	_, err = bv.validateBid(bid, balanceCheckerFn)
+	require.ErrorIs(t, err, ErrReservePriceNotFinal)
+
+	bv.setFinalReservePrice(1, big.NewInt(2))
+	_, err = bv.validateBid(bid, balanceCheckerFn)
+	require.NoError(t, err)
+
+	bv = newBidValidator(35 * time.Second)
+	bv.setFinalReservePrice(1, big.NewInt(5))
+	_, err = bv.validateBid(bid, balanceCheckerFn)
+	require.ErrorIs(t, err, ErrReservePriceNotMet)
+
+	// The final reserve price of a previous round doesn't carry over.
+	bv = newBidValidator(35 * time.Second)
+	bv.setFinalReservePrice(0, big.NewInt(2))
+	_, err = bv.validateBid(bid, balanceCheckerFn)
+	require.ErrorIs(t, err, ErrReservePriceNotFinal)
+
+	// Once the auction is closed, the window is over.
+	bv = newBidValidator(50 * time.Second)
+	require.False(t, bv.InReserveSubmissionWindow())
+}
+
+// Raising the reserve price mid-round only applies to bids validated after it,
+// bids already accepted remain eligible.
+func TestBidValidator_reservePriceRaisedMidRound(t *testing.T) {
+	t.Parallel()
+	balanceCheckerFn := func(_ *bind.CallOpts, _ common.Address) (*big.Int, error) {
+		return big.NewInt(10_000), nil
+	}
+	auctionContractAddr := common.Address{'a'}
+	bv := &BidValidator{
+		chainId: big.NewInt(1),
+		roundTimingInfo: RoundTimingInfo{
+			Offset:         time.Now().Add(-time.Second),
+			Round:          time.Minute,
+			AuctionClosing: 45 * time.Second,
+		},
+		reservePrice:            big.NewInt(1_000),
+		bidsPerSenderInRound:    make(map[common.Address]uint8),
+		maxBidsPerSenderInRound: 5,
+		auctionContractAddr:     auctionContractAddr,
+	}
+	signBid := func(controller byte, amount int64) *Bid {
+		privateKey, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		bid, err := NewSignedBid(privateKey, 1, big.NewInt(amount), common.Address{controller}, big.NewInt(1), auctionContractAddr)
+		require.NoError(t, err)
+		return bid
+	}
+	early, err := bv.validateBid(signBid('b', 1_500), balanceCheckerFn)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1_000), early.ReservePrice.ToInt())
+
+	bv.SetReservePrice(big.NewInt(2_000))
+	_, err = bv.validateBid(signBid('c', 1_500), balanceCheckerFn)
+	require.ErrorIs(t, err, ErrReservePriceNotMet)
+	late, err := bv.validateBid(signBid('d', 2_500), balanceCheckerFn)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(2_000), late.ReservePrice.ToInt())
+
+	// The early bid reaches the auctioneer after the reserve price rose, and is
+	// still cached and ranked.
+	a := &AuctioneerServer{bidCache: newBidCache([32]byte{})}
+	a.receiveValidatedBid(late)
+	a.receiveValidatedBid(early)
+	require.Equal(t, 2, a.bidCache.size())
+	result := a.bidCache.topTwoBids()
+	require.Equal(t, big.NewInt(2_500), result.firstPlace.Amount)
+	require.Equal(t, big.NewInt(1_500), result.secondPlace.Amount)
+	require.Equal(t, big.NewInt(1_000), result.secondPlace.ReservePrice)
+}
+
+func TestBidValidator_operatorReservePrice(t *testing.T) {
+	t.Parallel()
+	balanceCheckerFn := func(_ *bind.CallOpts, _ common.Address) (*big.Int, error) {
+		return big.NewInt(10_000), nil
+	}
+	auctionContractAddr := common.Address{'a'}
+	bv := &BidValidator{
+		chainId: big.NewInt(1),
+		roundTimingInfo: RoundTimingInfo{
+			Offset:         time.Now().Add(-time.Second),
+			Round:          time.Minute,
+			AuctionClosing: 45 * time.Second,
+		},
+		reservePrice:            big.NewInt(1_000),
+		minReservePrice:         big.NewInt(1_000),
+		bidsPerSenderInRound:    make(map[common.Address]uint8),
+		maxBidsPerSenderInRound: 5,
+		auctionContractAddr:     auctionContractAddr,
+	}
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	bid, err := NewSignedBid(privateKey, 1, big.NewInt(1_500), common.Address{'b'}, big.NewInt(1), auctionContractAddr)
+	require.NoError(t, err)
+
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName(AuctioneerNamespace, &BidValidatorAPI{bv}))
+	require.NoError(t, server.RegisterName(AuctioneerAdminNamespace, &BidValidatorAdminAPI{bv}))
+	client := rpc.DialInProc(server)
+	defer client.Close()
+	ctx := context.Background()
+
+	// The setters of the bid validator aren't part of its public API.
+	for _, method := range []string{"auctioneer_setReservePrice", "auctioneer_setMinReservePrice", "auctioneer_stopAndWait"} {
+		require.Error(t, client.CallContext(ctx, nil, method, (*hexutil.Big)(big.NewInt(1))), method)
+	}
+
+	// The operator's reserve price can't be below the min reserve price.
+	require.ErrorContains(t, client.CallContext(ctx, nil, "auctioneeradmin_setReservePrice", (*hexutil.Big)(big.NewInt(500))), "below min reserve price")
+	require.Error(t, client.CallContext(ctx, nil, "auctioneeradmin_setReservePrice", (*hexutil.Big)(big.NewInt(0))))
+
+	require.NoError(t, client.CallContext(ctx, nil, "auctioneeradmin_setReservePrice", (*hexutil.Big)(big.NewInt(2_000))))
+	var reservePrice *big.Int
+	require.NoError(t, client.CallContext(ctx, &reservePrice, "auctioneer_reservePrice"))
+	require.Equal(t, big.NewInt(2_000), reservePrice)
+	_, err = bv.validateBid(bid, balanceCheckerFn)
+	require.ErrorIs(t, err, ErrReservePriceNotMet)
+
+	// Refreshing the reserve price from the contract doesn't undo it.
+	bv.SetReservePrice(big.NewInt(1_200))
+	require.Equal(t, big.NewInt(2_000), bv.reservePriceOfRound(bv.upcomingRound()))
+
+	// Unsetting it restores the standard reserve price.
+	require.NoError(t, client.CallContext(ctx, nil, "auctioneeradmin_setReservePrice", nil))
+	require.Equal(t, big.NewInt(1_200), bv.reservePriceOfRound(bv.upcomingRound()))
+	_, err = bv.validateBid(bid, balanceCheckerFn)
+	require.NoError(t, err)
 }
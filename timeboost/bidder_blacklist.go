@@ -0,0 +1,117 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var errResolutionReverted = errors.New("auction resolution reverted")
+
+// isRevertError reports whether err, possibly relayed as a string by the
+// sequencer's RPC, is caused by the resolution reverting, e.g. when estimating
+// its gas.
+func isRevertError(err error) bool {
+	return err != nil && (errors.Is(err, vm.ErrExecutionReverted) || strings.Contains(err.Error(), vm.ErrExecutionReverted.Error()))
+}
+
+// bidderBlacklist temporarily blocks bidders whose bids repeatedly make the
+// resolution of their round revert, e.g. by pulling their deposit between
+// bidding and resolution, so that they can't stall the rounds that follow.
+// Its zero value never blocks any bidder.
+type bidderBlacklist struct {
+	threshold uint64
+	duration  time.Duration
+	lock      sync.Mutex
+	// Failures attributed to each bidder since it last won a round, and the
+	// last round each was attributed for, so that retries count once.
+	failures   map[common.Address]uint64
+	lastFailed map[common.Address]uint64
+	until      map[common.Address]time.Time
+}
+
+func newBidderBlacklist(threshold uint64, duration time.Duration) bidderBlacklist {
+	return bidderBlacklist{
+		threshold:  threshold,
+		duration:   duration,
+		failures:   make(map[common.Address]uint64),
+		lastFailed: make(map[common.Address]uint64),
+		until:      make(map[common.Address]time.Time),
+	}
+}
+
+// recordFailure attributes the failed resolution of a round to a bidder, and
+// reports whether that blacklisted it.
+func (b *bidderBlacklist) recordFailure(round uint64, bidder common.Address, now time.Time, err error) bool {
+	if b.threshold == 0 {
+		return false
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.failures[bidder] > 0 && b.lastFailed[bidder] == round {
+		return false
+	}
+	b.failures[bidder]++
+	b.lastFailed[bidder] = round
+	if b.failures[bidder] < b.threshold {
+		return false
+	}
+	delete(b.failures, bidder)
+	delete(b.lastFailed, bidder)
+	b.until[bidder] = now.Add(b.duration)
+	log.Warn("Blacklisting bidder after repeated resolution failures", "bidder", bidder, "round", round, "failures", b.threshold, "until", b.until[bidder], "err", err)
+	return true
+}
+
+// recordSuccess forgets the failures attributed to a bidder once a round is
+// resolved with its bid.
+func (b *bidderBlacklist) recordSuccess(bidder common.Address) {
+	if b.threshold == 0 {
+		return
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	delete(b.failures, bidder)
+	delete(b.lastFailed, bidder)
+}
+
+// blocked reports whether a bidder is blacklisted, and lets it out once its
+// blacklisting expired.
+func (b *bidderBlacklist) blocked(bidder common.Address, now time.Time) bool {
+	if b.threshold == 0 {
+		return false
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	until, ok := b.until[bidder]
+	if !ok {
+		return false
+	}
+	if now.Before(until) {
+		return true
+	}
+	delete(b.until, bidder)
+	log.Info("Removing bidder from blacklist", "bidder", bidder, "blacklistedUntil", until)
+	return false
+}
+
+// attributeResolutionFailure blames the bidder whose bid won the round for its
+// resolution reverting, and drops its cached bids once that blacklists it.
+func (a *AuctioneerServer) attributeResolutionFailure(round uint64, result *auctionResult, err error) {
+	if result == nil || result.firstPlace == nil {
+		return
+	}
+	bidder := result.firstPlace.Bidder
+	if a.bidderBlacklist.recordFailure(round, bidder, time.Now(), err) {
+		a.getMetrics().blacklistedBidders.Inc(1)
+		a.bidCache.remove(bidder)
+	}
+}
@@ -0,0 +1,111 @@
+package timeboost
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+func TestBidderBlacklist(t *testing.T) {
+	t.Parallel()
+	bidder, other := common.HexToAddress("0x1"), common.HexToAddress("0x2")
+	now := time.Now()
+	blacklist := newBidderBlacklist(2, time.Minute)
+
+	// Retries of a round count once.
+	require.False(t, blacklist.recordFailure(1, bidder, now, nil))
+	require.False(t, blacklist.recordFailure(1, bidder, now, nil))
+	// Winning a round forgets earlier failures.
+	blacklist.recordSuccess(bidder)
+	require.False(t, blacklist.recordFailure(2, bidder, now, nil))
+	require.False(t, blacklist.blocked(bidder, now))
+	require.True(t, blacklist.recordFailure(3, bidder, now, nil))
+
+	require.True(t, blacklist.blocked(bidder, now.Add(time.Second)))
+	require.False(t, blacklist.blocked(other, now.Add(time.Second)))
+	// Once expired the bidder starts over.
+	require.False(t, blacklist.blocked(bidder, now.Add(time.Minute)))
+	require.False(t, blacklist.recordFailure(4, bidder, now, nil))
+
+	// The zero value never blacklists.
+	var disabled bidderBlacklist
+	require.False(t, disabled.recordFailure(1, bidder, now, nil))
+	require.False(t, disabled.recordFailure(2, bidder, now, nil))
+	require.False(t, disabled.blocked(bidder, now))
+}
+
+func TestIsRevertError(t *testing.T) {
+	t.Parallel()
+	require.True(t, isRevertError(vm.ErrExecutionReverted))
+	require.True(t, isRevertError(errors.New("execution reverted: InsufficientBalance")))
+	require.False(t, isRevertError(errors.New("connection refused")))
+	require.False(t, isRevertError(nil))
+}
+
+func TestResolutionRevertsBlacklistBidder(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	chainId := big.NewInt(1)
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+	require.NoError(t, err)
+	txOpts.GasLimit = 1_000_000
+	a := &AuctioneerServer{
+		txOpts:          txOpts,
+		chainId:         chainId,
+		bidCache:        newBidCache([32]byte{}),
+		bidderBlacklist: newBidderBlacklist(2, time.Hour),
+		roundTimingInfo: RoundTimingInfo{
+			Offset:            time.Now(),
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+	}
+	WithMetricsRegistry(metrics.NewRegistry(), "arb/auctioneer/")(a)
+	failing, other := common.HexToAddress("0x1"), common.HexToAddress("0x2")
+	bid := func(bidder common.Address, amount int64) *JsonValidatedBid {
+		return &JsonValidatedBid{
+			ChainId:               (*hexutil.Big)(chainId),
+			Bidder:                bidder,
+			ExpressLaneController: bidder,
+			Round:                 hexutil.Uint64(a.UpcomingRound()),
+			Amount:                (*hexutil.Big)(big.NewInt(amount)),
+			Signature:             make([]byte, 65),
+		}
+	}
+	// The bidder keeps outbidding the other, and keeps pulling its deposit.
+	client := &fakeAuctioneerClient{baseFee: big.NewInt(1), revertReceipts: true}
+	for i := 0; i < 2; i++ {
+		a.receiveValidatedBid(bid(failing, 10))
+		a.receiveValidatedBid(bid(other, 5))
+		_, err := a.resolveAuctionWithClient(ctx, client, true)
+		require.ErrorIs(t, err, errResolutionReverted)
+		// On to the next round.
+		a.closeRound(a.UpcomingRound())
+		a.roundTimingInfo.Offset = a.roundTimingInfo.Offset.Add(-a.roundTimingInfo.Round)
+	}
+	require.Equal(t, int64(1), a.getMetrics().blacklistedBidders.Snapshot().Count())
+
+	// Its bids are dropped while it is blacklisted, and the round goes to the other bidder.
+	client.revertReceipts = false
+	a.receiveValidatedBid(bid(failing, 10))
+	a.receiveValidatedBid(bid(other, 5))
+	require.Equal(t, int64(1), a.getMetrics().blacklistedBids.Snapshot().Count())
+	result, err := a.resolveAuctionWithClient(ctx, client, true)
+	require.NoError(t, err)
+	require.Equal(t, RoundStatusResolved, result.Outcome)
+	require.Equal(t, other, result.FirstPlace.Bidder)
+}
@@ -89,6 +89,9 @@ func NewBidderClient(
 	if err != nil {
 		return nil, err
 	}
+	if err := validateChainId(chainId); err != nil {
+		return nil, err
+	}
 	auctionContract, err := express_lane_auctiongen.NewExpressLaneAuction(auctionContractAddr, arbClient)
 	if err != nil {
 		return nil, err
@@ -216,15 +219,31 @@ func (bd *BidderClient) Bid(
 	newBid.Signature = sig
 
 	promise := bd.submitBid(newBid)
-	if _, err := promise.Await(ctx); err != nil {
+	correlationId, err := promise.Await(ctx)
+	if err != nil {
 		return nil, err
 	}
+	// The id the bid can be tracked by in the auctioneer's logs.
+	newBid.CorrelationId = correlationId
 	return newBid, nil
 }
 
-func (bd *BidderClient) submitBid(bid *Bid) containers.PromiseInterface[struct{}] {
-	return stopwaiter.LaunchPromiseThread[struct{}](bd, func(ctx context.Context) (struct{}, error) {
-		err := bd.auctioneerClient.CallContext(ctx, nil, "auctioneer_submitBid", bid.ToJson())
-		return struct{}{}, err
+// BidDecimal bids an amount written as a decimal string with an optional unit,
+// e.g. "1.5 gwei", see ParseBidAmount.
+func (bd *BidderClient) BidDecimal(
+	ctx context.Context, amount string, expressLaneController common.Address,
+) (*Bid, error) {
+	parsed, err := ParseBidAmount(amount)
+	if err != nil {
+		return nil, err
+	}
+	return bd.Bid(ctx, parsed, expressLaneController)
+}
+
+func (bd *BidderClient) submitBid(bid *Bid) containers.PromiseInterface[string] {
+	return stopwaiter.LaunchPromiseThread[string](bd, func(ctx context.Context) (string, error) {
+		var correlationId string
+		err := bd.auctioneerClient.CallContext(ctx, &correlationId, "auctioneer_submitBidWithCorrelationId", bid.ToJson())
+		return correlationId, err
 	})
 }
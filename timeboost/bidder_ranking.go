@@ -0,0 +1,32 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// BidderRanking returns where the bidder currently stands in the auction for
+// the upcoming round: its rank among all bidders by their best bid, starting
+// at 1, and whether that puts it in the top two. The rank is 0 if the bidder
+// has no bid for the round. Nothing about the bids of others is revealed.
+func (a *AuctioneerServer) BidderRanking(bidder common.Address) (rank int, isTopTwo bool) {
+	rank = a.bidCache.bidderRank(bidder)
+	return rank, rank > 0 && rank <= 2
+}
+
+// BidderRankingResult is the RPC representation of BidderRanking.
+type BidderRankingResult struct {
+	Rank     int  `json:"rank"`
+	IsTopTwo bool `json:"isTopTwo"`
+}
+
+type AuctioneerBidderAPI struct {
+	auctioneer *AuctioneerServer
+}
+
+func (api *AuctioneerBidderAPI) BidderRanking(bidder common.Address) *BidderRankingResult {
+	rank, isTopTwo := api.auctioneer.BidderRanking(bidder)
+	return &BidderRankingResult{Rank: rank, IsTopTwo: isTopTwo}
+}
@@ -0,0 +1,48 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// consumeValidatedBid caches a bid received from the bid validators, and
+// persists it to the database. It persists on the calling thread, so that a
+// burst of bids can't pile up writes in flight, and time spent persisting bids
+// drained before a resolution counts against the drain timeout.
+func (a *AuctioneerServer) consumeValidatedBid(bid *JsonValidatedBid) {
+	log.Info("Consumed validated bid", "bidder", bid.Bidder, "amount", bid.Amount, "round", bid.Round, "correlationId", bid.CorrelationId)
+	a.receiveValidatedBid(bid)
+	a.persistValidatedBid(bid)
+}
+
+// drainReceivedBids consumes the bids already received from the bid validators
+// but still queued for the bid receiver thread, so that bids validated before
+// the auction closed aren't left out of its resolution for arriving in a
+// burst. It stops once the queue is empty, or past the drain timeout so that a
+// flood of bids can't hold up the resolution, and returns the number of bids
+// consumed. It must be called before the round is being resolved, as bids are
+// no longer cached then.
+func (a *AuctioneerServer) drainReceivedBids() int {
+	if a.bidsDrainTimeout <= 0 {
+		return 0
+	}
+	deadline := time.Now().Add(a.bidsDrainTimeout)
+	drained := 0
+	for time.Now().Before(deadline) {
+		select {
+		case bid := <-a.bidsReceiver:
+			a.consumeValidatedBid(bid)
+			drained++
+		default:
+			return drained
+		}
+	}
+	if left := len(a.bidsReceiver); left > 0 {
+		log.Warn("Resolving round without queued bids, draining them timed out", "drained", drained, "left", left, "timeout", a.bidsDrainTimeout)
+	}
+	return drained
+}
@@ -0,0 +1,70 @@
+package timeboost
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestResolveUpcomingRoundDrainsQueuedBids(t *testing.T) {
+	t.Parallel()
+	newAuctioneer := func(drainTimeout time.Duration) *AuctioneerServer {
+		database, err := NewDatabase(t.TempDir())
+		require.NoError(t, err)
+		return &AuctioneerServer{
+			database:         database,
+			bidsReceiver:     make(chan *JsonValidatedBid, 10),
+			bidCache:         newBidCache([32]byte{}),
+			bidsDrainTimeout: drainTimeout,
+			roundTimingInfo: RoundTimingInfo{
+				Offset:            time.Now().Add(-50 * time.Second),
+				Round:             time.Minute,
+				AuctionClosing:    15 * time.Second,
+				ReserveSubmission: 15 * time.Second,
+			},
+		}
+	}
+	queueBid := func(a *AuctioneerServer) {
+		a.bidsReceiver <- (&ValidatedBid{
+			ChainId:                big.NewInt(1),
+			AuctionContractAddress: common.HexToAddress("0x1234"),
+			Bidder:                 common.HexToAddress("0x1"),
+			ExpressLaneController:  common.HexToAddress("0x1"),
+			Round:                  a.UpcomingRound(),
+			Amount:                 big.NewInt(100),
+			Signature:              []byte{1},
+		}).ToJson()
+	}
+
+	// A bid validated before the auction closed, but still queued when the
+	// round is resolved, is resolved with.
+	a := newAuctioneer(time.Second)
+	queueBid(a)
+	var resolvedWith *auctionResult
+	require.NoError(t, a.resolveUpcomingRound(context.Background(), func(context.Context) error {
+		resolvedWith = a.bidCache.topTwoBids()
+		return nil
+	}))
+	require.Empty(t, a.bidsReceiver)
+	require.NotNil(t, resolvedWith.firstPlace)
+	require.Equal(t, common.HexToAddress("0x1"), resolvedWith.firstPlace.Bidder)
+	// It is persisted as well.
+	_, maxRound, err := a.database.GetBids(0)
+	require.NoError(t, err)
+	require.Equal(t, a.UpcomingRound(), maxRound)
+
+	// Without a drain timeout, the bid is left for the bid receiver thread.
+	a = newAuctioneer(0)
+	queueBid(a)
+	require.NoError(t, a.resolveUpcomingRound(context.Background(), func(context.Context) error {
+		resolvedWith = a.bidCache.topTwoBids()
+		return nil
+	}))
+	require.Len(t, a.bidsReceiver, 1)
+	require.Nil(t, resolvedWith.firstPlace)
+}
@@ -0,0 +1,25 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var errInvalidChainId = errors.New("invalid chain id")
+
+// validateChainId checks the chain id reported by the node is plausible.
+// Bids are signed over the chain id, so with a missing or zero one no bid could
+// ever be validated or resolved, and the deployment would be silently broken.
+func validateChainId(chainId *big.Int) error {
+	if chainId == nil {
+		return fmt.Errorf("%w: node reported no chain id", errInvalidChainId)
+	}
+	if chainId.Sign() <= 0 {
+		return fmt.Errorf("%w: node reported chain id %s, expected a positive one", errInvalidChainId, chainId)
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package timeboost
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateChainId(t *testing.T) {
+	t.Parallel()
+	require.NoError(t, validateChainId(big.NewInt(1)))
+	require.NoError(t, validateChainId(big.NewInt(42161)))
+	for _, chainId := range []*big.Int{nil, big.NewInt(0), big.NewInt(-1)} {
+		require.ErrorIs(t, validateChainId(chainId), errInvalidChainId, "chain id %v", chainId)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ClearingPrice returns the price the winner of a round resolved with the given
+// bids pays, for reporting. It is the amount of the second bid, or, for a round
+// with a single bid, the reserve price, as that is what the auction contract
+// charges it. It is nil for a round without bids, and for a single bid round
+// whose reserve price is unknown.
+func ClearingPrice(first, second *ValidatedBid, reservePrice *big.Int) *big.Int {
+	if first == nil {
+		return nil
+	}
+	if second != nil {
+		return new(big.Int).Set(second.Amount)
+	}
+	if reservePrice == nil {
+		return nil
+	}
+	return new(big.Int).Set(reservePrice)
+}
+
+// clearingPrice returns the clearing price of the round resolved with result.
+func (a *AuctioneerServer) clearingPrice(result *auctionResult) *big.Int {
+	if result == nil {
+		return nil
+	}
+	return ClearingPrice(result.firstPlace, result.secondPlace, result.reservePrice)
+}
+
+// readReservePrice reads the reserve price a round with a single bid is
+// resolved at. Bidding on the round is closed, so it is final. Failing to read
+// it only leaves the clearing price of the round unknown.
+func (a *AuctioneerServer) readReservePrice(ctx context.Context, round uint64, result *auctionResult) {
+	if result.firstPlace == nil || result.secondPlace != nil {
+		return
+	}
+	auctionContract := a.getAuctionContract()
+	if auctionContract == nil {
+		return
+	}
+	reservePrice, err := auctionContract.ReservePrice(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		log.Warn("Could not read reserve price for the clearing price of the round", "round", round, "err", err)
+		return
+	}
+	result.reservePrice = reservePrice
+}
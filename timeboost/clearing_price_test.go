@@ -0,0 +1,39 @@
+package timeboost
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClearingPrice(t *testing.T) {
+	t.Parallel()
+	first := &ValidatedBid{Amount: big.NewInt(20)}
+	second := &ValidatedBid{Amount: big.NewInt(10)}
+	reservePrice := big.NewInt(4)
+	tests := []struct {
+		name         string
+		first        *ValidatedBid
+		second       *ValidatedBid
+		reservePrice *big.Int
+		expected     *big.Int
+	}{
+		{"multi bid", first, second, reservePrice, big.NewInt(10)},
+		// The contract charges a single bid the reserve price, not its amount.
+		{"single bid", first, nil, reservePrice, big.NewInt(4)},
+		{"single bid, reserve price unknown", first, nil, nil, nil},
+		{"no bids", nil, nil, reservePrice, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearingPrice := ClearingPrice(tt.first, tt.second, tt.reservePrice)
+			require.Equal(t, tt.expected, clearingPrice)
+		})
+	}
+
+	// The clearing price is a copy, reporting it can't alter the bids.
+	clearingPrice := ClearingPrice(first, second, reservePrice)
+	clearingPrice.SetInt64(0)
+	require.Equal(t, big.NewInt(10), second.Amount)
+}
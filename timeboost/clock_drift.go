@@ -0,0 +1,50 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var errClockDrift = errors.New("local clock drifted too far from the chain")
+
+func validateMaxClockDrift(maxClockDrift time.Duration) error {
+	if maxClockDrift < 0 {
+		return fmt.Errorf("max clock drift (%v) must not be negative", maxClockDrift)
+	}
+	return nil
+}
+
+// updateClockDrift judges the head lag just measured by checkHeadLag as a
+// drift of the local clock. The auctioneer times resolutions by its local
+// clock, so with the local clock behind the chain by more than the max clock
+// drift, it enters a safe mode in which it stops resolving rounds and fails
+// readiness, until a check finds the drift corrected. A local clock ahead of
+// the chain can't be told apart from a stale head, see clockSkewMonitor.now,
+// and is left to the max head lag.
+func (a *AuctioneerServer) updateClockDrift(lag time.Duration, block *big.Int) {
+	if a.maxClockDrift <= 0 {
+		return
+	}
+	exceeded := lag < -a.maxClockDrift
+	if wasExceeded := a.clockDriftExceeded.Swap(exceeded); exceeded && !wasExceeded {
+		log.Root().Write(log.LevelCrit, "Local clock is behind the chain, auctioneer stops resolving until it is corrected, check NTP", "drift", -lag, "maxClockDrift", a.maxClockDrift, "block", block)
+	} else if !exceeded && wasExceeded {
+		log.Info("Local clock drift corrected, auctioneer resumes resolving", "lag", lag, "block", block)
+	}
+}
+
+// clockDriftError returns an error while the auctioneer is in safe mode, as of
+// the last head lag check.
+func (a *AuctioneerServer) clockDriftError() error {
+	if !a.clockDriftExceeded.Load() {
+		return nil
+	}
+	return fmt.Errorf("%w: local clock %v behind the latest block", errClockDrift, (-time.Duration(a.headLag.Load())).Truncate(time.Millisecond))
+}
@@ -0,0 +1,79 @@
+package timeboost
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestClockDriftSafeMode(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	chainId := big.NewInt(1)
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+	require.NoError(t, err)
+	txOpts.GasLimit = 1_000_000
+	a := &AuctioneerServer{
+		txOpts:   txOpts,
+		chainId:  chainId,
+		bidCache: newBidCache([32]byte{}),
+		roundTimingInfo: RoundTimingInfo{
+			Offset:            time.Now(),
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+		maxClockDrift: 10 * time.Second,
+	}
+	a.receiveValidatedBid(&JsonValidatedBid{
+		ChainId:               (*hexutil.Big)(chainId),
+		Bidder:                common.HexToAddress("0x1"),
+		ExpressLaneController: common.HexToAddress("0x1"),
+		Round:                 1,
+		Amount:                (*hexutil.Big)(big.NewInt(10)),
+		Signature:             make([]byte, 65),
+	})
+
+	// The local clock is an hour behind the chain.
+	client := &fakeAuctioneerClient{baseFee: big.NewInt(1), headTime: uint64(time.Now().Add(time.Hour).Unix())}
+	_, err = a.resolveAuctionWithClient(ctx, client, true)
+	require.ErrorIs(t, err, errClockDrift)
+	require.Empty(t, client.submitted)
+	require.ErrorIs(t, a.clockDriftError(), errClockDrift)
+	// Safe mode holds until a check finds the drift corrected.
+	require.NoError(t, a.checkHeadLag(ctx, client))
+	require.ErrorIs(t, a.clockDriftError(), errClockDrift)
+
+	// An hour old head is no sign of a drifted clock, but of a stale head.
+	client = &fakeAuctioneerClient{baseFee: big.NewInt(1), headTime: uint64(time.Now().Add(-time.Hour).Unix())}
+	require.NoError(t, a.checkHeadLag(ctx, client))
+	require.NoError(t, a.clockDriftError())
+
+	client = &fakeAuctioneerClient{baseFee: big.NewInt(1), headTime: uint64(time.Now().Unix())}
+	require.NoError(t, a.checkHeadLag(ctx, client))
+	require.NoError(t, a.clockDriftError())
+	result, err := a.resolveAuctionWithClient(ctx, client, true)
+	require.NoError(t, err)
+	require.Equal(t, RoundStatusResolved, result.Outcome)
+	require.Len(t, client.submitted, 1)
+}
+
+func TestClockDriftCheckDisabled(t *testing.T) {
+	t.Parallel()
+	a := &AuctioneerServer{}
+	client := &fakeAuctioneerClient{headTime: uint64(time.Now().Add(time.Hour).Unix())}
+	require.NoError(t, a.checkHeadLag(context.Background(), client))
+	require.NoError(t, a.clockDriftError())
+	require.Error(t, validateMaxClockDrift(-time.Second))
+	require.NoError(t, validateMaxClockDrift(0))
+}
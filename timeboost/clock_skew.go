@@ -0,0 +1,84 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/offchainlabs/nitro/util/arbmath"
+)
+
+type ClockSkewConfig struct {
+	Tolerance     time.Duration `koanf:"tolerance"`
+	CheckInterval time.Duration `koanf:"check-interval"`
+	WarnThreshold time.Duration `koanf:"warn-threshold"`
+	Reconcile     bool          `koanf:"reconcile"`
+}
+
+var DefaultClockSkewConfig = ClockSkewConfig{
+	WarnThreshold: 2 * time.Second,
+}
+
+func ClockSkewConfigAddOptions(prefix string, f *pflag.FlagSet) {
+	f.Duration(prefix+".tolerance", DefaultClockSkewConfig.Tolerance, "keep accepting bids for this long after the local clock says the auction closed, must be less than half the auction closing time and should not exceed the auctioneer's resolution wait time")
+	f.Duration(prefix+".check-interval", DefaultClockSkewConfig.CheckInterval, "if non-zero, how often to measure the local clock's drift against the latest block timestamp")
+	f.Duration(prefix+".warn-threshold", DefaultClockSkewConfig.WarnThreshold, "log a warning when the measured clock drift exceeds this")
+	f.Bool(prefix+".reconcile", DefaultClockSkewConfig.Reconcile, "correct round calculations for a local clock measured to be behind the chain")
+}
+
+// Validate the tolerance against the timing of the auction it applies to.
+func (c *ClockSkewConfig) Validate(info *RoundTimingInfo) error {
+	if c.Tolerance < 0 || c.Tolerance >= info.AuctionClosing/2 {
+		return fmt.Errorf("clock skew tolerance (%v) must be non-negative and less than 50%% of auction closing time (%v)", c.Tolerance, info.AuctionClosing)
+	}
+	return nil
+}
+
+// clockSkewMonitor tracks the drift of the local clock against the chain.
+// Its zero value uses the local clock as-is.
+type clockSkewMonitor struct {
+	config ClockSkewConfig
+	// Local clock minus chain clock, in nanoseconds, as of the last check.
+	drift atomic.Int64
+}
+
+// now returns the local time, corrected for measured drift if reconciling.
+// Blocks are only produced so often, so the drift measured against the latest
+// block also includes that block's age. Only a local clock behind the chain is
+// therefore known to be wrong, and only that case is corrected.
+func (m *clockSkewMonitor) now() time.Time {
+	now := time.Now()
+	if drift := time.Duration(m.drift.Load()); m.config.Reconcile && drift < 0 {
+		now = now.Add(-drift)
+	}
+	return now
+}
+
+// checkDrift measures the drift of the local clock against the latest block,
+// to be called iteratively.
+func (m *clockSkewMonitor) checkDrift(ctx context.Context, headerByNumber func(context.Context, *big.Int) (*types.Header, error)) time.Duration {
+	header, err := headerByNumber(ctx, nil)
+	if err != nil {
+		log.Warn("Could not get latest header to check clock drift", "err", err)
+		return m.config.CheckInterval
+	}
+	blockTime := time.Unix(arbmath.SaturatingCast[int64](header.Time), 0)
+	drift := time.Since(blockTime)
+	m.drift.Store(int64(drift))
+	if drift < -m.config.WarnThreshold {
+		log.Warn("Local clock is behind the latest block timestamp, check NTP", "drift", drift, "block", header.Number)
+	} else if drift > m.config.WarnThreshold {
+		log.Warn("Latest block timestamp is behind the local clock, check NTP if blocks are produced regularly", "drift", drift, "block", header.Number)
+	}
+	return m.config.CheckInterval
+}
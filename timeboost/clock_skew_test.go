@@ -0,0 +1,92 @@
+package timeboost
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestClockSkewMonitor(t *testing.T) {
+	t.Parallel()
+	headerAt := func(offset time.Duration) func(context.Context, *big.Int) (*types.Header, error) {
+		return func(context.Context, *big.Int) (*types.Header, error) {
+			return &types.Header{Number: big.NewInt(1), Time: uint64(time.Now().Add(offset).Unix())}, nil
+		}
+	}
+	tests := []struct {
+		name        string
+		blockOffset time.Duration
+		reconcile   bool
+		corrected   bool
+	}{
+		{name: "local clock behind, reconciled", blockOffset: time.Minute, reconcile: true, corrected: true},
+		{name: "local clock behind, not reconciled", blockOffset: time.Minute},
+		{name: "local clock ahead", blockOffset: -time.Minute, reconcile: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &clockSkewMonitor{config: ClockSkewConfig{CheckInterval: time.Second, Reconcile: tt.reconcile}}
+			require.Equal(t, time.Second, m.checkDrift(context.Background(), headerAt(tt.blockOffset)))
+			drift := time.Until(m.now())
+			if tt.corrected {
+				require.InDelta(t, time.Minute, drift, float64(2*time.Second))
+			} else {
+				require.InDelta(t, 0, drift, float64(time.Second))
+			}
+		})
+	}
+}
+
+func TestClockSkewConfigValidate(t *testing.T) {
+	t.Parallel()
+	info := &RoundTimingInfo{Round: time.Minute, AuctionClosing: 10 * time.Second}
+	require.NoError(t, (&ClockSkewConfig{}).Validate(info))
+	require.NoError(t, (&ClockSkewConfig{Tolerance: 4 * time.Second}).Validate(info))
+	require.Error(t, (&ClockSkewConfig{Tolerance: 5 * time.Second}).Validate(info))
+	require.Error(t, (&ClockSkewConfig{Tolerance: -time.Second}).Validate(info))
+}
+
+func TestBidValidator_validateBid_clockSkewTolerance(t *testing.T) {
+	t.Parallel()
+	balanceCheckerFn := func(_ *bind.CallOpts, _ common.Address) (*big.Int, error) {
+		return big.NewInt(10), nil
+	}
+	bid := &Bid{
+		ExpressLaneController:  common.Address{'b'},
+		AuctionContractAddress: common.Address{'a'},
+		ChainId:                big.NewInt(1),
+		Round:                  1,
+		Amount:                 big.NewInt(1),
+	}
+	for _, tolerance := range []time.Duration{0, 2 * time.Second} {
+		bv := &BidValidator{
+			chainId:             big.NewInt(1),
+			auctionContractAddr: common.Address{'a'},
+			roundTimingInfo: RoundTimingInfo{
+				// The auction closed a second ago.
+				Offset:         time.Now().Add(-6 * time.Second),
+				Round:          10 * time.Second,
+				AuctionClosing: 5 * time.Second,
+			},
+			reservePrice:            big.NewInt(2),
+			bidsPerSenderInRound:    make(map[common.Address]uint8),
+			maxBidsPerSenderInRound: 5,
+			clock:                   clockSkewMonitor{config: ClockSkewConfig{Tolerance: tolerance}},
+		}
+		_, err := bv.validateBid(bid, balanceCheckerFn)
+		if tolerance == 0 {
+			require.ErrorIs(t, err, ErrBadRoundNumber)
+			require.ErrorContains(t, err, "auction is closed")
+		} else {
+			// The bid makes it past the round checks.
+			require.ErrorIs(t, err, ErrReservePriceNotMet)
+		}
+	}
+}
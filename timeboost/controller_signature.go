@@ -0,0 +1,46 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/offchainlabs/nitro/solgen/go/express_lane_auctiongen"
+)
+
+// controllerTransferorFn looks up the transferor an express lane controller
+// has set on the auction contract, the zero address if none.
+type controllerTransferorFn func(opts *bind.CallOpts, controller common.Address) (common.Address, error)
+
+func auctionContractTransferorOf(auctionContract *express_lane_auctiongen.ExpressLaneAuction) controllerTransferorFn {
+	return func(opts *bind.CallOpts, controller common.Address) (common.Address, error) {
+		transferor, err := auctionContract.TransferorOf(opts, controller)
+		if err != nil {
+			return common.Address{}, err
+		}
+		return transferor.Addr, nil
+	}
+}
+
+// checkControllerSignature rejects bids that weren't signed by the express
+// lane controller they name, nor by the transferor the controller delegated
+// control of its express lane to, if that is required.
+func (bv *BidValidator) checkControllerSignature(bid *Bid, bidder common.Address) error {
+	if !bv.requireControllerSignature || bidder == bid.ExpressLaneController {
+		return nil
+	}
+	if bv.controllerTransferor != nil {
+		transferor, err := bv.controllerTransferor(&bind.CallOpts{}, bid.ExpressLaneController)
+		if err != nil {
+			return err
+		}
+		if transferor != (common.Address{}) && transferor == bidder {
+			return nil
+		}
+	}
+	return errors.Wrapf(ErrControllerMismatch, "bid for express lane controller %s signed by %s", bid.ExpressLaneController.Hex(), bidder.Hex())
+}
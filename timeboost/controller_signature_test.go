@@ -0,0 +1,78 @@
+package timeboost
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestBidValidator_validateBid_controllerSignature(t *testing.T) {
+	t.Parallel()
+	balanceCheckerFn := func(_ *bind.CallOpts, _ common.Address) (*big.Int, error) {
+		return big.NewInt(10), nil
+	}
+	auctionContractAddr := common.Address{'a'}
+	controllerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	controller := crypto.PubkeyToAddress(controllerKey.PublicKey)
+	delegateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	delegate := crypto.PubkeyToAddress(delegateKey.PublicKey)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	newBidValidator := func(required bool) *BidValidator {
+		return &BidValidator{
+			chainId: big.NewInt(1),
+			roundTimingInfo: RoundTimingInfo{
+				Offset:         time.Now().Add(-time.Second),
+				Round:          time.Minute,
+				AuctionClosing: 45 * time.Second,
+			},
+			reservePrice:                   big.NewInt(2),
+			bidsPerSenderInRound:           make(map[common.Address]uint8),
+			maxBidsPerSenderInRound:        5,
+			auctionContractAddr:            auctionContractAddr,
+			auctionContractDomainSeparator: ComputeDomainSeparator(big.NewInt(1), auctionContractAddr),
+			requireControllerSignature:     required,
+			controllerTransferor: func(_ *bind.CallOpts, c common.Address) (common.Address, error) {
+				if c == controller {
+					return delegate, nil
+				}
+				return common.Address{}, nil
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		key         *ecdsa.PrivateKey
+		required    bool
+		expectedErr error
+	}{
+		{name: "matched", key: controllerKey, required: true},
+		{name: "delegated", key: delegateKey, required: true},
+		{name: "mismatched", key: otherKey, required: true, expectedErr: ErrControllerMismatch},
+		{name: "mismatched but not required", key: otherKey, required: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bid, err := NewSignedBid(tt.key, 1, big.NewInt(3), controller, big.NewInt(1), auctionContractAddr)
+			require.NoError(t, err)
+			validated, err := newBidValidator(tt.required).validateBid(bid, balanceCheckerFn)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, crypto.PubkeyToAddress(tt.key.PublicKey), validated.Bidder)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"crypto/rand"
+
+	"github.com/pkg/errors"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// maxCorrelationIdLength bounds the correlation ids clients may supply, as
+// they are logged with every line about their bid.
+const maxCorrelationIdLength = 64
+
+// newCorrelationId returns a random id for a bid submitted without one.
+func newCorrelationId() string {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return ""
+	}
+	return hexutil.Encode(id)
+}
+
+// validateCorrelationId rejects correlation ids that are too long, or hold
+// anything but letters, digits and the separators '-', '_', '.' and ':', so
+// that they can't break up or forge the log lines they are written to.
+func validateCorrelationId(id string) error {
+	if len(id) > maxCorrelationIdLength {
+		return errors.Wrapf(ErrMalformedData, "correlation id longer than %d characters", maxCorrelationIdLength)
+	}
+	for _, c := range id {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return errors.Wrapf(ErrMalformedData, "correlation id holds invalid character %q", c)
+		}
+	}
+	return nil
+}
+
+// assignCorrelationId makes sure a submitted bid has a correlation id, which
+// identifies it in every log line and event about it, from its submission
+// through its validation and caching to the resolution of its round if it
+// wins. The id the client supplied is kept, and one is generated otherwise.
+// The id isn't signed, and bidders may reuse it across bids.
+func assignCorrelationId(bid *JsonBid) error {
+	if bid.CorrelationId == "" {
+		bid.CorrelationId = newCorrelationId()
+		return nil
+	}
+	return validateCorrelationId(bid.CorrelationId)
+}
@@ -0,0 +1,125 @@
+package timeboost
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestAssignCorrelationId(t *testing.T) {
+	t.Parallel()
+	// An id is generated for bids submitted without one.
+	first, second := &JsonBid{}, &JsonBid{}
+	require.NoError(t, assignCorrelationId(first))
+	require.NoError(t, assignCorrelationId(second))
+	require.NotEmpty(t, first.CorrelationId)
+	require.NotEqual(t, first.CorrelationId, second.CorrelationId)
+
+	// The id supplied is kept.
+	supplied := &JsonBid{CorrelationId: "client-42:retry.1_a"}
+	require.NoError(t, assignCorrelationId(supplied))
+	require.Equal(t, "client-42:retry.1_a", supplied.CorrelationId)
+
+	for _, id := range []string{
+		strings.Repeat("a", maxCorrelationIdLength+1),
+		"bid\nlvl=crit msg=forged",
+		"bid id",
+		`bid"id`,
+	} {
+		require.ErrorIs(t, assignCorrelationId(&JsonBid{CorrelationId: id}), ErrMalformedData, id)
+	}
+	require.NoError(t, assignCorrelationId(&JsonBid{CorrelationId: strings.Repeat("a", maxCorrelationIdLength)}))
+}
+
+func TestCorrelationIdThroughPipeline(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	chainId := big.NewInt(1)
+	auctionContractAddr := common.Address{'a'}
+	bv := &BidValidator{
+		chainId: chainId,
+		roundTimingInfo: RoundTimingInfo{
+			Offset:         time.Now().Add(-time.Second),
+			Round:          time.Minute,
+			AuctionClosing: 45 * time.Second,
+		},
+		reservePrice:            big.NewInt(2),
+		bidsPerSenderInRound:    make(map[common.Address]uint8),
+		maxBidsPerSenderInRound: 5,
+		auctionContractAddr:     auctionContractAddr,
+	}
+	balanceCheckerFn := func(_ *bind.CallOpts, _ common.Address) (*big.Int, error) {
+		return big.NewInt(10), nil
+	}
+
+	// The id supplied at submission is carried by the validated bid.
+	submitted := buildValidBid(t, auctionContractAddr).ToJson()
+	submitted.CorrelationId = "track-me"
+	require.NoError(t, assignCorrelationId(submitted))
+	goBid, err := bidFromJson(submitted)
+	require.NoError(t, err)
+	validated, err := bv.checkBid(goBid, balanceCheckerFn, false)
+	require.NoError(t, err)
+	require.Equal(t, "track-me", validated.CorrelationId)
+
+	// It is published in the bid feed, unless bidders are hashed.
+	plain, unsubscribePlain := bv.bidFeed.subscribe(1, false)
+	defer unsubscribePlain()
+	hashed, unsubscribeHashed := bv.bidFeed.subscribe(1, true)
+	defer unsubscribeHashed()
+	bv.bidFeed.publish(validated, time.Now())
+	require.Equal(t, "track-me", (<-plain).CorrelationId)
+	require.Empty(t, (<-hashed).CorrelationId)
+
+	// It survives the trip to the auctioneer.
+	encoded, err := json.Marshal(validated)
+	require.NoError(t, err)
+	var consumed JsonValidatedBid
+	require.NoError(t, json.Unmarshal(encoded, &consumed))
+	require.Equal(t, "track-me", consumed.CorrelationId)
+
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+	require.NoError(t, err)
+	txOpts.GasLimit = 1_000_000
+	a := &AuctioneerServer{
+		txOpts:              txOpts,
+		chainId:             chainId,
+		auctionContractAddr: auctionContractAddr,
+		bidCache:            newBidCache([32]byte{}),
+		roundTimingInfo: RoundTimingInfo{
+			// Bidding on round 1 is closed.
+			Offset:            time.Now().Add(-50 * time.Second),
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+	}
+	WithAuditSink(&strings.Builder{})(a)
+	a.receiveValidatedBid(&consumed)
+
+	// The winning bid's id is reported with the resolution, and audited.
+	var result *ResolutionResult
+	err = a.resolveUpcomingRound(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = a.resolveAuctionWithClient(ctx, &fakeAuctioneerClient{baseFee: big.NewInt(1)}, true)
+		return err
+	})
+	require.NoError(t, err)
+	require.Equal(t, RoundStatusResolved, result.Outcome)
+	require.Equal(t, "track-me", result.FirstPlace.CorrelationId)
+	record := <-a.auditor.pending
+	require.Len(t, record.Bids, 1)
+	require.Equal(t, "track-me", record.Bids[0].CorrelationId)
+	require.Equal(t, "track-me", record.FirstPlace.CorrelationId)
+}
@@ -4,13 +4,18 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io/fs"
+	"math/big"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 const sqliteFileName = "validated_bids.db?_journal_mode=WAL"
@@ -167,3 +172,124 @@ func (d *SqliteDatabase) DeleteBids(round uint64) error {
 	_, err := d.sqlDB.Exec(query, round)
 	return err
 }
+
+// sqliteRoundOutcome is a row of the AuctionHistory table. Prices that are
+// not set are stored as empty strings.
+type sqliteRoundOutcome struct {
+	Id           uint64 `db:"Id"`
+	Round        uint64 `db:"Round"`
+	Status       string `db:"Status"`
+	Winner       string `db:"Winner"`
+	WinnerBidder string `db:"WinnerBidder"`
+	FirstPrice   string `db:"FirstPrice"`
+	SecondPrice  string `db:"SecondPrice"`
+	NumBids      uint64 `db:"NumBids"`
+	TxHash       string `db:"TxHash"`
+	Time         int64  `db:"Time"`
+	// Empty for rounds recorded before clearing prices were.
+	ClearingPrice string `db:"ClearingPrice"`
+	// Empty if unknown, or for rounds recorded before fees were.
+	BaseFee           string `db:"BaseFee"`
+	EffectiveGasPrice string `db:"EffectiveGasPrice"`
+	// Empty for rounds recorded before revenues were.
+	Revenue string `db:"Revenue"`
+}
+
+func (d *SqliteDatabase) AppendRoundOutcome(o *RoundOutcome) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	query := `INSERT INTO AuctionHistory (
+        Round, Status, Winner, WinnerBidder, FirstPrice, SecondPrice, NumBids, TxHash, Time, ClearingPrice, BaseFee, EffectiveGasPrice, Revenue
+    ) VALUES (
+        :Round, :Status, :Winner, :WinnerBidder, :FirstPrice, :SecondPrice, :NumBids, :TxHash, :Time, :ClearingPrice, :BaseFee, :EffectiveGasPrice, :Revenue
+    )`
+	params := map[string]interface{}{
+		"Round":        o.Round,
+		"Status":       string(o.Status),
+		"Winner":       o.Winner.Hex(),
+		"WinnerBidder": o.WinnerBidder.Hex(),
+		"FirstPrice":   bigToDbString(o.FirstPrice),
+		"SecondPrice":  bigToDbString(o.SecondPrice),
+		"NumBids":      o.NumBids,
+		"TxHash":       o.TxHash.Hex(),
+		"Time":         o.Time.UnixNano(),
+		// Empty if unknown, as are the prices above if not set.
+		"ClearingPrice":     bigToDbString(o.ClearingPrice),
+		"BaseFee":           bigToDbString(o.BaseFee),
+		"EffectiveGasPrice": bigToDbString(o.EffectiveGasPrice),
+		"Revenue":           bigToDbString(o.Revenue),
+	}
+	_, err := d.sqlDB.NamedExec(query, params)
+	return err
+}
+
+func (d *SqliteDatabase) HistorySince(round uint64) ([]*RoundOutcome, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	var rows []*sqliteRoundOutcome
+	if err := d.sqlDB.Select(&rows, "SELECT * FROM AuctionHistory WHERE Round >= ? ORDER BY Id ASC", round); err != nil {
+		return nil, err
+	}
+	outcomes := make([]*RoundOutcome, 0, len(rows))
+	for _, row := range rows {
+		firstPrice, err := bigFromDbString(row.FirstPrice)
+		if err != nil {
+			return nil, err
+		}
+		secondPrice, err := bigFromDbString(row.SecondPrice)
+		if err != nil {
+			return nil, err
+		}
+		clearingPrice, err := bigFromDbString(row.ClearingPrice)
+		if err != nil {
+			return nil, err
+		}
+		baseFee, err := bigFromDbString(row.BaseFee)
+		if err != nil {
+			return nil, err
+		}
+		effectiveGasPrice, err := bigFromDbString(row.EffectiveGasPrice)
+		if err != nil {
+			return nil, err
+		}
+		revenue, err := bigFromDbString(row.Revenue)
+		if err != nil {
+			return nil, err
+		}
+		outcomes = append(outcomes, &RoundOutcome{
+			Round:        row.Round,
+			Status:       RoundStatus(row.Status),
+			Winner:       common.HexToAddress(row.Winner),
+			WinnerBidder: common.HexToAddress(row.WinnerBidder),
+			FirstPrice:   firstPrice,
+			SecondPrice:  secondPrice,
+			NumBids:      row.NumBids,
+			TxHash:       common.HexToHash(row.TxHash),
+			Time:         time.Unix(0, row.Time),
+			// Nil for rounds recorded before clearing prices were.
+			ClearingPrice:     clearingPrice,
+			BaseFee:           baseFee,
+			EffectiveGasPrice: effectiveGasPrice,
+			Revenue:           revenue,
+		})
+	}
+	return outcomes, nil
+}
+
+func bigToDbString(value *hexutil.Big) string {
+	if value == nil {
+		return ""
+	}
+	return value.ToInt().String()
+}
+
+func bigFromDbString(value string) (*hexutil.Big, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %q in auction history", value)
+	}
+	return (*hexutil.Big)(parsed), nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// WithDepositConfirmations only counts deposits towards the balance bids are
+// checked against once they have at least the given number of confirmations,
+// the block including a deposit being its first. A bidder depositing and bidding
+// right away could otherwise win with a deposit a reorg then removes. Deposits
+// are counted as soon as they are included by default, or if zero.
+func WithDepositConfirmations(confirmations uint64) BidValidatorOpt {
+	return func(bv *BidValidator) {
+		bv.depositConfirmations = confirmations
+	}
+}
+
+// depositBalances returns the deposit balance of the bidder, and the part of it
+// that is confirmed. That is its balance as of the newest block with enough
+// confirmations, as deposits since are not, capped by its current balance, as
+// it may have withdrawn since.
+func (bv *BidValidator) depositBalances(
+	balanceCheckerFn func(opts *bind.CallOpts, account common.Address) (*big.Int, error),
+	bidder common.Address,
+) (*big.Int, *big.Int, error) {
+	if bv.depositConfirmations == 0 {
+		balance, err := balanceCheckerFn(&bind.CallOpts{}, bidder)
+		return balance, balance, err
+	}
+	headBlockNumber := bv.headBlockNumber
+	if headBlockNumber == nil {
+		headBlockNumber = bv.client.BlockNumber
+	}
+	head, err := headBlockNumber(context.Background())
+	if err != nil {
+		return nil, nil, err
+	}
+	// Both balances are read at a fixed block, so that a block arriving in
+	// between can't make the confirmed balance newer than the current one.
+	balance, err := balanceCheckerFn(&bind.CallOpts{BlockNumber: new(big.Int).SetUint64(head)}, bidder)
+	if err != nil {
+		return nil, nil, err
+	}
+	if head+1 < bv.depositConfirmations {
+		// No block has enough confirmations yet.
+		return balance, new(big.Int), nil
+	}
+	confirmedBlock := head + 1 - bv.depositConfirmations
+	confirmed, err := balanceCheckerFn(&bind.CallOpts{BlockNumber: new(big.Int).SetUint64(confirmedBlock)}, bidder)
+	if err != nil {
+		return nil, nil, err
+	}
+	if confirmed.Cmp(balance) > 0 {
+		confirmed = balance
+	}
+	return balance, confirmed, nil
+}
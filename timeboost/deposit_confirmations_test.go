@@ -0,0 +1,90 @@
+package timeboost
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestBidValidator_depositConfirmations(t *testing.T) {
+	t.Parallel()
+	auctionContractAddr := common.Address{'a'}
+	// The bidder deposits 2 at block 90, and 8 more at block 100.
+	balanceAt := func(block uint64) *big.Int {
+		switch {
+		case block >= 100:
+			return big.NewInt(10)
+		case block >= 90:
+			return big.NewInt(2)
+		default:
+			return new(big.Int)
+		}
+	}
+	newValidator := func(confirmations, head uint64) *BidValidator {
+		bv := &BidValidator{
+			chainId: big.NewInt(1),
+			roundTimingInfo: RoundTimingInfo{
+				Offset:         time.Now().Add(-time.Second),
+				Round:          time.Minute,
+				AuctionClosing: 45 * time.Second,
+			},
+			reservePrice:            big.NewInt(2),
+			bidsPerSenderInRound:    make(map[common.Address]uint8),
+			maxBidsPerSenderInRound: 5,
+			auctionContractAddr:     auctionContractAddr,
+			headBlockNumber: func(context.Context) (uint64, error) {
+				return head, nil
+			},
+		}
+		WithDepositConfirmations(confirmations)(bv)
+		return bv
+	}
+	var head uint64
+	balanceCheckerFn := func(opts *bind.CallOpts, _ common.Address) (*big.Int, error) {
+		if opts.BlockNumber == nil {
+			return balanceAt(head), nil
+		}
+		return balanceAt(opts.BlockNumber.Uint64()), nil
+	}
+	bid := buildValidBid(t, auctionContractAddr) // Bids 3.
+
+	for _, tt := range []struct {
+		name          string
+		confirmations uint64
+		head          uint64
+		wantErr       error
+	}{
+		{name: "disabled", confirmations: 0, head: 100},
+		{name: "included", confirmations: 1, head: 100},
+		{name: "one confirmation short", confirmations: 3, head: 101, wantErr: ErrInsufficientBalance},
+		{name: "enough confirmations", confirmations: 3, head: 102},
+		{name: "only older deposit confirmed", confirmations: 12, head: 105, wantErr: ErrInsufficientBalance},
+		{name: "no block confirmed yet", confirmations: 200, head: 105, wantErr: ErrInsufficientBalance},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			head = tt.head
+			bv := newValidator(tt.confirmations, tt.head)
+			_, err := bv.checkBid(bid, balanceCheckerFn, false)
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Contains(t, err.Error(), "confirmations")
+			}
+		})
+	}
+
+	// Failing to read the chain head fails the check.
+	bv := newValidator(3, 102)
+	headErr := errors.New("head unavailable")
+	bv.headBlockNumber = func(context.Context) (uint64, error) { return 0, headErr }
+	_, err := bv.checkBid(bid, balanceCheckerFn, false)
+	require.ErrorIs(t, err, headErr)
+}
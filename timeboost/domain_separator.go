@@ -0,0 +1,79 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// The EIP-712 domain the express lane auction contract signs bids under.
+const (
+	auctionDomainName    = "ExpressLaneAuction"
+	auctionDomainVersion = "1"
+)
+
+var eip712DomainTypeHash = crypto.Keccak256([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+
+// ComputeDomainSeparator returns the EIP-712 domain separator of the express
+// lane auction contract deployed at auctionContract on the given chain, which
+// is the keccak256 hash of the 160 byte preimage
+//
+//	keccak256("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)") |
+//	keccak256("ExpressLaneAuction") | keccak256("1") |
+//	chain id as a big endian uint256 | auction contract address left padded to 32 bytes
+//
+// A bid is signed over keccak256("\x19\x01" | domain separator | hashStruct(bid)),
+// see Bid.ToEIP712Hash. The validator uses the separator reported by the
+// contract itself, which clients can fetch via auctioneer_domainSeparator.
+func ComputeDomainSeparator(chainId *big.Int, auctionContract common.Address) [32]byte {
+	return crypto.Keccak256Hash(
+		eip712DomainTypeHash,
+		crypto.Keccak256([]byte(auctionDomainName)),
+		crypto.Keccak256([]byte(auctionDomainVersion)),
+		padBigInt(chainId),
+		common.LeftPadBytes(auctionContract.Bytes(), 32),
+	)
+}
+
+// NewSignedBid returns a bid for the round signed by key under the domain of
+// the auction contract at auctionContractAddr on the given chain, as the bidder
+// client signs its bids, with a recovery id of 27 or 28.
+func NewSignedBid(key *ecdsa.PrivateKey, round uint64, amount *big.Int, expressLaneController common.Address, chainId *big.Int, auctionContractAddr common.Address) (*Bid, error) {
+	bid := &Bid{
+		ChainId:                chainId,
+		ExpressLaneController:  expressLaneController,
+		AuctionContractAddress: auctionContractAddr,
+		Round:                  round,
+		Amount:                 amount,
+	}
+	bidHash, err := bid.ToEIP712Hash(ComputeDomainSeparator(chainId, auctionContractAddr))
+	if err != nil {
+		return nil, err
+	}
+	sig, err := crypto.Sign(bidHash.Bytes(), key)
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	bid.Signature = sig
+	return bid, nil
+}
+
+// DomainSeparator returns the domain separator bids must be signed under to
+// be accepted by the validator.
+func (bv *BidValidator) DomainSeparator() common.Hash {
+	return bv.auctionContractDomainSeparator
+}
+
+// DomainSeparator returns the domain separator of the auction contract the
+// auctioneer currently resolves auctions on.
+func (a *AuctioneerServer) DomainSeparator() common.Hash {
+	a.auctionContractLock.RLock()
+	defer a.auctionContractLock.RUnlock()
+	return a.auctionContractDomainSeparator
+}
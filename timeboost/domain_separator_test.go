@@ -0,0 +1,137 @@
+package timeboost
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Test vectors for client libraries reproducing the signature scheme of bids.
+// The bidder key is the well known funded account of the nitro testnode.
+var (
+	domainVectorChainId         = big.NewInt(412346)
+	domainVectorAuctionContract = common.HexToAddress("0xEe2E5E5e8dc3cE73a1E1c2B9A0E2C5F96B7D1E85")
+	domainVectorSeparator       = common.HexToHash("0x0244d710d7a94138b89aa8987d03cfb87e5a09359d5b9a076e9839dbb6646532")
+	domainVectorBidderKey       = "b6b15c8cb491557369f3c7d2c287b053eb229daa9c22138887752191c9520659"
+	domainVectorBidder          = common.HexToAddress("0x3f1Eae7D46d88F08fc2F8ed27FCb2AB183EB2d0E")
+	domainVectorBidHash         = common.HexToHash("0x5cc85ffc17b1c82ae66c1a13db2601a52b179b46b98013b68f291fc30469a77a")
+	domainVectorSignature       = hexutil.MustDecode("0x1d04ae20c9d337771706733274aad39234ec5744204e6337b4a74536c6da725b462a1daa80246fdbcb3b29fd402a62711e68fb41aa3840b4109cb6de271058c41b")
+)
+
+func TestComputeDomainSeparator(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, [32]byte(domainVectorSeparator), ComputeDomainSeparator(domainVectorChainId, domainVectorAuctionContract))
+	// The separator commits to both the chain and the contract.
+	require.NotEqual(t, [32]byte(domainVectorSeparator), ComputeDomainSeparator(big.NewInt(1), domainVectorAuctionContract))
+	require.NotEqual(t, [32]byte(domainVectorSeparator), ComputeDomainSeparator(domainVectorChainId, common.Address{}))
+}
+
+func TestBidSignatureVector(t *testing.T) {
+	t.Parallel()
+	privateKey, err := crypto.HexToECDSA(domainVectorBidderKey)
+	require.NoError(t, err)
+	require.Equal(t, domainVectorBidder, crypto.PubkeyToAddress(privateKey.PublicKey))
+
+	bid := &Bid{
+		ChainId:                domainVectorChainId,
+		ExpressLaneController:  common.HexToAddress("0x5E1497dD1f08C87b2d8FE23e9AAB6c1De833D927"),
+		AuctionContractAddress: domainVectorAuctionContract,
+		Round:                  42,
+		Amount:                 big.NewInt(1_000_000_000_000_000_000),
+		Signature:              domainVectorSignature,
+	}
+	bidHash, err := bid.ToEIP712Hash(domainVectorSeparator)
+	require.NoError(t, err)
+	require.Equal(t, domainVectorBidHash, bidHash)
+
+	// Signing is deterministic, so the key reproduces the known-good signature.
+	signature, err := crypto.Sign(bidHash[:], privateKey)
+	require.NoError(t, err)
+	signature[64] += 27
+	require.Equal(t, domainVectorSignature, signature)
+	signed, err := NewSignedBid(privateKey, bid.Round, bid.Amount, bid.ExpressLaneController, domainVectorChainId, domainVectorAuctionContract)
+	require.NoError(t, err)
+	require.Equal(t, bid, signed)
+
+	// The validator recovers the bidder from the signature under its separator.
+	bv := &BidValidator{auctionContractDomainSeparator: domainVectorSeparator}
+	require.Equal(t, domainVectorSeparator, bv.DomainSeparator())
+	sig := common.CopyBytes(bid.Signature)
+	sig[64] -= 27
+	validatorHash, err := bid.ToEIP712Hash(bv.DomainSeparator())
+	require.NoError(t, err)
+	pubkey, err := crypto.SigToPub(validatorHash[:], sig)
+	require.NoError(t, err)
+	require.Equal(t, domainVectorBidder, crypto.PubkeyToAddress(*pubkey))
+}
+
+// bidTypedDataVector is the vector bid as eth_signTypedData_v4 typed data.
+const bidTypedDataVector = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Bid": [
+			{"name": "round", "type": "uint64"},
+			{"name": "expressLaneController", "type": "address"},
+			{"name": "amount", "type": "uint256"}
+		]
+	},
+	"primaryType": "Bid",
+	"domain": {
+		"name": "ExpressLaneAuction",
+		"version": "1",
+		"chainId": "412346",
+		"verifyingContract": "0xEe2E5E5e8dc3cE73a1E1c2B9A0E2C5F96B7D1E85"
+	},
+	"message": {
+		"round": "42",
+		"expressLaneController": "0x5E1497dD1f08C87b2d8FE23e9AAB6c1De833D927",
+		"amount": "1000000000000000000"
+	}
+}`
+
+func TestBidTypedData(t *testing.T) {
+	t.Parallel()
+	bid := &Bid{
+		ChainId:                domainVectorChainId,
+		ExpressLaneController:  common.HexToAddress("0x5E1497dD1f08C87b2d8FE23e9AAB6c1De833D927"),
+		AuctionContractAddress: domainVectorAuctionContract,
+		Round:                  42,
+		Amount:                 big.NewInt(1_000_000_000_000_000_000),
+	}
+
+	// What a wallet signs is the hash the validator recovers bidders from.
+	var vector apitypes.TypedData
+	require.NoError(t, json.Unmarshal([]byte(bidTypedDataVector), &vector))
+	for _, typedData := range []apitypes.TypedData{vector, bid.TypedData()} {
+		hash, _, err := apitypes.TypedDataAndHash(typedData)
+		require.NoError(t, err)
+		require.Equal(t, domainVectorBidHash.Bytes(), hash)
+		domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
+		require.NoError(t, err)
+		require.Equal(t, domainVectorSeparator.Bytes(), []byte(domainSeparator))
+	}
+
+	encoded, err := bid.TypedDataJSON()
+	require.NoError(t, err)
+	var decoded apitypes.TypedData
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	hash, _, err := apitypes.TypedDataAndHash(decoded)
+	require.NoError(t, err)
+	sig := common.CopyBytes(domainVectorSignature)
+	sig[64] -= 27
+	pubkey, err := crypto.SigToPub(hash, sig)
+	require.NoError(t, err)
+	require.Equal(t, domainVectorBidder, crypto.PubkeyToAddress(*pubkey))
+}
@@ -0,0 +1,159 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// EffectiveConfig is every setting the auctioneer runs with, as resolved from
+// its config and options, for diagnostics. Durations and enumerated settings
+// are given as strings. Secrets, such as the keys of the signers, the JWT of
+// the sequencer or credentials of the S3 storage, are never held by the
+// auctioneer past construction, and are left out.
+type EffectiveConfig struct {
+	ChainId                *hexutil.Big     `json:"chainId"`
+	AuctionContract        common.Address   `json:"auctionContract"`
+	AuctionContractVersion uint8            `json:"auctionContractVersion"`
+	DomainSeparator        common.Hash      `json:"domainSeparator"`
+	Signers                []common.Address `json:"signers"`
+	ObserverMode           bool             `json:"observerMode"`
+	Paused                 bool             `json:"paused"`
+	// Round timing as of now, which may have been refreshed since startup.
+	InitialRoundTimestamp      time.Time `json:"initialRoundTimestamp"`
+	RoundDuration              string    `json:"roundDuration"`
+	AuctionClosing             string    `json:"auctionClosing"`
+	ReserveSubmission          string    `json:"reserveSubmission"`
+	RoundTimingRefreshInterval string    `json:"roundTimingRefreshInterval"`
+	// Bids.
+	StreamTimeout            string `json:"streamTimeout"`
+	MaxCachedBids            int    `json:"maxCachedBids"`
+	BidBackpressure          string `json:"bidBackpressure"`
+	BidQueueTimeout          string `json:"bidQueueTimeout"`
+	BidsDrainTimeout         string `json:"bidsDrainTimeout"`
+	BidderBlacklistThreshold uint64 `json:"bidderBlacklistThreshold"`
+	BidderBlacklistDuration  string `json:"bidderBlacklistDuration"`
+	// Resolution.
+	AuctionResolutionWaitTime string       `json:"auctionResolutionWaitTime"`
+	MinBidsToResolve          uint64       `json:"minBidsToResolve"`
+	SingleBidFallback         string       `json:"singleBidFallback"`
+	ResolutionTxType          string       `json:"resolutionTxType"`
+	ResolutionConfirmations   uint64       `json:"resolutionConfirmations"`
+	MaxResolutionGas          uint64       `json:"maxResolutionGas"`
+	MinSignerBalance          *hexutil.Big `json:"minSignerBalance,omitempty"`
+	MinedTimeout              string       `json:"minedTimeout"`
+	MinedTimeoutAction        string       `json:"minedTimeoutAction"`
+	ReceiptPollInterval       string       `json:"receiptPollInterval"`
+	RevertPolicy              string       `json:"revertPolicy"`
+	FailureAlertThreshold     uint64       `json:"failureAlertThreshold"`
+	SequencerBlockTime        string       `json:"sequencerBlockTime"`
+	// Chain health.
+	PollJitter       float64 `json:"pollJitter"`
+	MaxHeadLag       string  `json:"maxHeadLag"`
+	StaleHeadRejects string  `json:"staleHeadRejects"`
+	MaxClockDrift    string  `json:"maxClockDrift"`
+	// Outputs, empty if disabled.
+	HealthcheckAddr string `json:"healthcheckAddr,omitempty"`
+	BidRecorderPath string `json:"bidRecorderPath,omitempty"`
+	RoundReceiptDir string `json:"roundReceiptDir,omitempty"`
+	S3Storage       bool   `json:"s3Storage"`
+	// Extensions names the options set in code, whose behavior can't be
+	// described, e.g. "gas-pricing-strategy".
+	Extensions []string `json:"extensions"`
+}
+
+// EffectiveConfig returns the settings the auctioneer runs with right now.
+func (a *AuctioneerServer) EffectiveConfig() *EffectiveConfig {
+	a.auctionContractLock.RLock()
+	auctionContract := a.auctionContractAddr
+	auctionContractVersion := a.auctionContractVersion
+	domainSeparator := a.auctionContractDomainSeparator
+	a.auctionContractLock.RUnlock()
+	roundTimingInfo := a.getRoundTimingInfo()
+
+	config := &EffectiveConfig{
+		AuctionContract:            auctionContract,
+		AuctionContractVersion:     uint8(auctionContractVersion),
+		DomainSeparator:            domainSeparator,
+		ObserverMode:               a.observerMode,
+		Paused:                     a.Paused(),
+		InitialRoundTimestamp:      roundTimingInfo.Offset,
+		RoundDuration:              roundTimingInfo.Round.String(),
+		AuctionClosing:             roundTimingInfo.AuctionClosing.String(),
+		ReserveSubmission:          roundTimingInfo.ReserveSubmission.String(),
+		RoundTimingRefreshInterval: a.roundTimingRefreshInterval.String(),
+		StreamTimeout:              a.streamTimeout.String(),
+		BidBackpressure:            a.bidBackpressure.String(),
+		BidQueueTimeout:            a.bidQueueTimeout.String(),
+		BidsDrainTimeout:           a.bidsDrainTimeout.String(),
+		BidderBlacklistThreshold:   a.bidderBlacklist.threshold,
+		BidderBlacklistDuration:    a.bidderBlacklist.duration.String(),
+		AuctionResolutionWaitTime:  a.auctionResolutionWaitTime.String(),
+		MinBidsToResolve:           a.minBidsToResolve,
+		SingleBidFallback:          a.singleBidFallback.String(),
+		ResolutionTxType:           a.resolutionTxType,
+		ResolutionConfirmations:    a.resolutionConfirmations,
+		MaxResolutionGas:           a.maxResolutionGas,
+		MinedTimeout:               a.minedTimeout.String(),
+		MinedTimeoutAction:         a.minedTimeoutAction,
+		ReceiptPollInterval:        a.receiptPollingInterval().String(),
+		RevertPolicy:               a.revertPolicy.String(),
+		FailureAlertThreshold:      a.failureAlertThreshold,
+		SequencerBlockTime:         a.sequencerBlockTime.String(),
+		PollJitter:                 a.pollJitter,
+		MaxHeadLag:                 a.maxHeadLag.String(),
+		StaleHeadRejects:           a.staleHeadRejects,
+		MaxClockDrift:              a.maxClockDrift.String(),
+		HealthcheckAddr:            a.healthcheckAddr,
+		BidRecorderPath:            a.bidRecorderPath,
+		RoundReceiptDir:            a.roundReceiptDir,
+		S3Storage:                  a.s3StorageService != nil,
+		Extensions:                 []string{},
+	}
+	if a.chainId != nil {
+		config.ChainId = (*hexutil.Big)(new(big.Int).Set(a.chainId))
+	}
+	if a.bidCache != nil {
+		config.MaxCachedBids = a.bidCache.maxBids
+	}
+	if a.minSignerBalance != nil {
+		config.MinSignerBalance = (*hexutil.Big)(new(big.Int).Set(a.minSignerBalance))
+	}
+	signers := a.signers
+	if len(signers) == 0 && a.txOpts != nil {
+		signers = []*bind.TransactOpts{a.txOpts}
+	}
+	for _, signer := range signers {
+		config.Signers = append(config.Signers, signer.From)
+	}
+	for _, extension := range []struct {
+		name string
+		set  bool
+	}{
+		{"gas-pricing-strategy", a.gasPricingStrategy != nil},
+		{"resolution-submitter", a.resolutionSubmitter != nil},
+		{"resolution-access-list", a.resolutionAccessList != nil},
+		{"closing-duration-for-round", a.closingDurationForRound != nil},
+		{"sequencer-health", a.sequencerHealth != nil},
+		{"failure-alerter", a.failureAlerter != nil},
+		{"resolution-listeners", len(a.resolutionListeners) > 0},
+		{"result-publisher", a.resultPublisher != nil},
+		{"audit-sink", a.auditor != nil},
+	} {
+		if extension.set {
+			config.Extensions = append(config.Extensions, extension.name)
+		}
+	}
+	return config
+}
+
+// EffectiveConfig returns the settings the auctioneer runs with right now.
+func (api *AuctioneerAdminAPI) EffectiveConfig() *EffectiveConfig {
+	return api.auctioneer.EffectiveConfig()
+}
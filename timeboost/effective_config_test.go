@@ -0,0 +1,58 @@
+package timeboost
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestEffectiveConfig(t *testing.T) {
+	t.Parallel()
+	a := &AuctioneerServer{
+		txOpts:              &bind.TransactOpts{From: common.HexToAddress("0x1")},
+		chainId:             big.NewInt(42161),
+		auctionContractAddr: common.HexToAddress("0x1234"),
+		bidCache:            newBidCache([32]byte{}),
+		roundTimingInfo: RoundTimingInfo{
+			Offset:            time.Unix(1_700_000_000, 0),
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+		minBidsToResolve: 2,
+		maxHeadLag:       10 * time.Second,
+		staleHeadRejects: StaleHeadRejectAll,
+	}
+	a.bidCache.maxBids = 100
+	config := a.EffectiveConfig()
+	require.Equal(t, (*hexutil.Big)(big.NewInt(42161)), config.ChainId)
+	require.Equal(t, common.HexToAddress("0x1234"), config.AuctionContract)
+	require.Equal(t, []common.Address{common.HexToAddress("0x1")}, config.Signers)
+	require.Equal(t, "1m0s", config.RoundDuration)
+	require.Equal(t, "15s", config.AuctionClosing)
+	require.Equal(t, time.Unix(1_700_000_000, 0), config.InitialRoundTimestamp)
+	require.Equal(t, uint64(2), config.MinBidsToResolve)
+	require.Equal(t, 100, config.MaxCachedBids)
+	require.Equal(t, "10s", config.MaxHeadLag)
+	require.Equal(t, StaleHeadRejectAll, config.StaleHeadRejects)
+	// Defaults that apply when unset are reported as resolved.
+	require.Equal(t, confirmationPollInterval.String(), config.ReceiptPollInterval)
+	require.Empty(t, config.Extensions)
+
+	// Options are reflected as they are applied, and never with their secrets.
+	WithResolutionSigners(&bind.TransactOpts{From: common.HexToAddress("0x2")}, &bind.TransactOpts{From: common.HexToAddress("0x3")})(a)
+	WithGasPricingStrategy(func(*bind.TransactOpts, *big.Int, time.Duration) error { return nil })(a)
+	WithBidBackpressure(DropOnFullQueue, 0)(a)
+	a.Pause()
+	config = (&AuctioneerAdminAPI{a}).EffectiveConfig()
+	require.Equal(t, []common.Address{common.HexToAddress("0x2"), common.HexToAddress("0x3")}, config.Signers)
+	require.Equal(t, []string{"gas-pricing-strategy"}, config.Extensions)
+	require.Equal(t, "drop", config.BidBackpressure)
+	require.True(t, config.Paused)
+}
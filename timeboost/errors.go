@@ -10,6 +10,8 @@ var (
 	ErrBadRoundNumber           = errors.New("BAD_ROUND_NUMBER")
 	ErrInsufficientBalance      = errors.New("INSUFFICIENT_BALANCE")
 	ErrReservePriceNotMet       = errors.New("RESERVE_PRICE_NOT_MET")
+	ErrBidFloorNotMet           = errors.New("BID_FLOOR_NOT_MET")
+	ErrReservePriceNotFinal     = errors.New("RESERVE_PRICE_NOT_FINAL")
 	ErrNoOnchainController      = errors.New("NO_ONCHAIN_CONTROLLER")
 	ErrWrongAuctionContract     = errors.New("WRONG_AUCTION_CONTRACT")
 	ErrNotExpressLaneController = errors.New("NOT_EXPRESS_LANE_CONTROLLER")
@@ -17,4 +19,37 @@ var (
 	ErrSequenceNumberTooLow     = errors.New("SEQUENCE_NUMBER_TOO_LOW")
 	ErrTooManyBids              = errors.New("PER_ROUND_BID_LIMIT_REACHED")
 	ErrAcceptedTxFailed         = errors.New("Accepted timeboost tx failed")
+	ErrDeniedController         = errors.New("DENIED_EXPRESS_LANE_CONTROLLER")
+	ErrControllerMismatch       = errors.New("CONTROLLER_SIGNATURE_MISMATCH")
+	ErrBidTooLate               = errors.New("BID_ARRIVED_TOO_LATE")
 )
+
+// bidRejections are the errors a bid can be rejected with. Their messages are
+// stable codes, while the errors returned wrapping them carry the details.
+var bidRejections = []error{
+	ErrMalformedData,
+	ErrNotDepositor,
+	ErrWrongChainId,
+	ErrWrongSignature,
+	ErrBadRoundNumber,
+	ErrInsufficientBalance,
+	ErrReservePriceNotMet,
+	ErrBidFloorNotMet,
+	ErrReservePriceNotFinal,
+	ErrTooManyBids,
+	ErrDeniedController,
+	ErrControllerMismatch,
+	ErrBidTooLate,
+}
+
+// BidRejectionReason returns the stable code of the reason a bid was rejected,
+// e.g. RESERVE_PRICE_NOT_MET, so that callers can map rejections to their own
+// error codes. It returns false if err is not a bid rejection.
+func BidRejectionReason(err error) (string, bool) {
+	for _, rejection := range bidRejections {
+		if errors.Is(err, rejection) {
+			return rejection.Error(), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,25 @@
+package timeboost
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBidRejectionReason(t *testing.T) {
+	t.Parallel()
+	reason, ok := BidRejectionReason(errors.Wrapf(ErrReservePriceNotMet, "reserve price %d, bid %d", 2, 1))
+	require.True(t, ok)
+	require.Equal(t, "RESERVE_PRICE_NOT_MET", reason)
+
+	reason, ok = BidRejectionReason(fmt.Errorf("rpc: %w", errors.Wrap(ErrWrongSignature, "could not recover bidder")))
+	require.True(t, ok)
+	require.Equal(t, "WRONG_SIGNATURE", reason)
+
+	_, ok = BidRejectionReason(errors.New("connection refused"))
+	require.False(t, ok)
+	_, ok = BidRejectionReason(nil)
+	require.False(t, ok)
+}
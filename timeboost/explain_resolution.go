@@ -0,0 +1,109 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"math/big"
+	"sort"
+)
+
+// ExplainReasonWrongRound is given for a bid explained as part of a round it
+// was not placed for.
+const ExplainReasonWrongRound = "bid for another round"
+
+// RejectedBid is a bid that did not take part in the resolution of its round,
+// and why, as one of the AuditReason values or ExplainReasonWrongRound.
+type RejectedBid struct {
+	Bid    *ValidatedBid
+	Reason string
+}
+
+// ResolutionExplanation is how the auctioneer resolves a round given the bids
+// it received, see ExplainResolution.
+type ResolutionExplanation struct {
+	Round uint64
+	// Outcome is RoundStatusResolved, or else RoundStatusNoBids or
+	// RoundStatusSkipped if the round would be left unresolved.
+	Outcome RoundStatus
+	// Eligible are the bids the round is resolved among, best first.
+	Eligible []*ValidatedBid
+	// Rejected are the other bids, in the order they were received.
+	Rejected []RejectedBid
+	// FirstPlace and SecondPlace are the bids the round is resolved with.
+	// SecondPlace is nil for a single bid.
+	FirstPlace  *ValidatedBid
+	SecondPlace *ValidatedBid
+	// ClearingPrice is the price the winner pays, see ClearingPrice.
+	// It is nil for a round resolved with a single bid, as the reserve price it
+	// pays is read from the chain.
+	ClearingPrice *big.Int
+}
+
+// ExplainResolution replays how the auctioneer resolves a round given the
+// bids it received for it, in the order received, e.g. as recorded by the bid
+// recorder, for settling disputes over why a round resolved the way it did.
+// Bids are cached and ranked, and the winners picked, by the same code and
+// with the same settings as the live auctioneer, but nothing is read from the
+// chain. Deposits are therefore not rechecked, and each bid is taken to be
+// funded as it was when validated. Neither is the state of the bidder
+// blacklist or the chain head at the time replayed, as it cannot be
+// reconstructed from the bids. Neither the auctioneer nor the bids are
+// modified.
+func (a *AuctioneerServer) ExplainResolution(round uint64, bids []*ValidatedBid) *ResolutionExplanation {
+	explanation := &ResolutionExplanation{Round: round}
+	cache := a.bidCache.emptyCopy()
+	reasons := make(map[*ValidatedBid]string)
+	for _, bid := range bids {
+		switch {
+		case bid.Round != round:
+			reasons[bid] = ExplainReasonWrongRound
+		case bid.BelowReservePrice:
+			reasons[bid] = AuditReasonBelowReservePrice
+		default:
+			added, evicted := cache.add(bid)
+			if evicted != nil {
+				reasons[evicted] = AuditReasonCacheFull
+			}
+			if !added {
+				reasons[bid] = AuditReasonCacheFull
+			}
+		}
+	}
+	domainSeparator := cache.auctionContractDomainSeparator
+	for _, bid := range bids {
+		if reasons[bid] == "" && !cache.contains(bid) {
+			// Replaced by a later bid for the same express lane controller.
+			reasons[bid] = AuditReasonSuperseded
+		}
+		if reason := reasons[bid]; reason != "" {
+			explanation.Rejected = append(explanation.Rejected, RejectedBid{Bid: bid, Reason: reason})
+		} else {
+			explanation.Eligible = append(explanation.Eligible, bid)
+		}
+	}
+	sort.Slice(explanation.Eligible, func(i, j int) bool {
+		x, y := explanation.Eligible[i], explanation.Eligible[j]
+		return (&rankedBid{bid: x, hash: x.BigIntHash(domainSeparator)}).outranks(&rankedBid{bid: y, hash: y.BigIntHash(domainSeparator)})
+	})
+
+	numBids := uint64(cache.size())
+	if numBids > 0 && numBids < a.minBidsToResolve {
+		explanation.Outcome = RoundStatusSkipped
+		return explanation
+	}
+	result := cache.topTwoBids()
+	dropDuplicateBidder(round, result)
+	switch {
+	case result.firstPlace == nil || hasWorthlessBid(result):
+		explanation.Outcome = RoundStatusNoBids
+	case a.skipCollapsedRound(result, numBids):
+		explanation.Outcome = RoundStatusSkipped
+	default:
+		explanation.Outcome = RoundStatusResolved
+		explanation.FirstPlace = result.firstPlace
+		explanation.SecondPlace = result.secondPlace
+		explanation.ClearingPrice = a.clearingPrice(result)
+	}
+	return explanation
+}
@@ -0,0 +1,118 @@
+package timeboost
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestExplainResolution(t *testing.T) {
+	t.Parallel()
+	a := &AuctioneerServer{bidCache: newBidCache([32]byte{})}
+	a.bidCache.maxBids = 3
+	bid := func(controller string, round uint64, amount int64) *ValidatedBid {
+		return &ValidatedBid{
+			ExpressLaneController: common.HexToAddress(controller),
+			Bidder:                common.HexToAddress(controller),
+			Round:                 round,
+			Amount:                big.NewInt(amount),
+		}
+	}
+	wrongRound := bid("0x1", 6, 100)
+	belowReserve := bid("0x2", 5, 1)
+	belowReserve.BelowReservePrice = true
+	superseded := bid("0x3", 5, 50)
+	outbid := bid("0x4", 5, 10)
+	first := bid("0x5", 5, 40)
+	second := bid("0x6", 5, 30)
+	third := bid("0x3", 5, 20)
+	bids := []*ValidatedBid{wrongRound, belowReserve, superseded, outbid, first, second, third}
+
+	explanation := a.ExplainResolution(5, bids)
+	require.Equal(t, RoundStatusResolved, explanation.Outcome)
+	require.Same(t, first, explanation.FirstPlace)
+	require.Same(t, second, explanation.SecondPlace)
+	require.Equal(t, big.NewInt(30), explanation.ClearingPrice)
+	require.Equal(t, []*ValidatedBid{first, second, third}, explanation.Eligible)
+	require.Equal(t, []RejectedBid{
+		{Bid: wrongRound, Reason: ExplainReasonWrongRound},
+		{Bid: belowReserve, Reason: AuditReasonBelowReservePrice},
+		{Bid: superseded, Reason: AuditReasonSuperseded},
+		{Bid: outbid, Reason: AuditReasonCacheFull},
+	}, explanation.Rejected)
+
+	// The explanation is deterministic, and leaves the auctioneer untouched.
+	require.Equal(t, explanation, a.ExplainResolution(5, bids))
+	require.Zero(t, a.bidCache.size())
+
+	// The same settings as the live auctioneer apply.
+	a.minBidsToResolve = 4
+	require.Equal(t, RoundStatusSkipped, a.ExplainResolution(5, bids).Outcome)
+	a.minBidsToResolve = 0
+	WithSingleBidFallback(SkipSingleBid)(a)
+	twoOfOneBidder := []*ValidatedBid{first, {
+		ExpressLaneController: common.HexToAddress("0x7"),
+		Bidder:                first.Bidder,
+		Round:                 5,
+		Amount:                big.NewInt(35),
+	}}
+	require.Equal(t, RoundStatusSkipped, a.ExplainResolution(5, twoOfOneBidder).Outcome)
+	require.Equal(t, RoundStatusNoBids, a.ExplainResolution(5, []*ValidatedBid{wrongRound}).Outcome)
+}
+
+func TestExplainResolutionMatchesLiveResolution(t *testing.T) {
+	t.Parallel()
+	chainId := big.NewInt(1)
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+	require.NoError(t, err)
+	txOpts.GasLimit = 1_000_000
+	a := &AuctioneerServer{
+		txOpts:   txOpts,
+		chainId:  chainId,
+		bidCache: newBidCache([32]byte{0xa}),
+		roundTimingInfo: RoundTimingInfo{
+			// Bidding on round 1 is closed.
+			Offset:            time.Now().Add(-50 * time.Second),
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+	}
+	a.bidCache.maxBids = 4
+	var received []*ValidatedBid
+	for i, amount := range []int64{10, 30, 30, 20, 5, 30, 25} {
+		bid := &JsonValidatedBid{
+			ChainId:               (*hexutil.Big)(chainId),
+			Bidder:                common.BigToAddress(big.NewInt(int64(i%5 + 1))),
+			ExpressLaneController: common.BigToAddress(big.NewInt(int64(i%6 + 1))),
+			Round:                 hexutil.Uint64(a.UpcomingRound()),
+			Amount:                (*hexutil.Big)(big.NewInt(amount)),
+			Signature:             make([]byte, 65),
+		}
+		received = append(received, JsonValidatedBidToGo(bid))
+		a.receiveValidatedBid(bid)
+	}
+	explanation := a.ExplainResolution(a.UpcomingRound(), received)
+
+	client := &fakeAuctioneerClient{baseFee: big.NewInt(1)}
+	var result *ResolutionResult
+	require.NoError(t, a.resolveUpcomingRound(context.Background(), func(ctx context.Context) error {
+		var err error
+		result, err = a.resolveAuctionWithClient(ctx, client, true)
+		return err
+	}))
+	require.Equal(t, result.Outcome, explanation.Outcome)
+	require.Equal(t, result.FirstPlace.ToJson(), explanation.FirstPlace.ToJson())
+	require.Equal(t, result.SecondPlace.ToJson(), explanation.SecondPlace.ToJson())
+	require.Equal(t, result.ClearingPrice, explanation.ClearingPrice)
+}
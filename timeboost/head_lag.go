@@ -0,0 +1,108 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/offchainlabs/nitro/util/arbmath"
+)
+
+// What the auctioneer refuses while the chain it reads is lagging behind.
+const (
+	// StaleHeadRejectResolutions skips the resolution of rounds.
+	StaleHeadRejectResolutions = "resolutions"
+	// StaleHeadRejectBids drops received bids.
+	StaleHeadRejectBids = "bids"
+	// StaleHeadRejectAll does both.
+	StaleHeadRejectAll = "all"
+)
+
+const headLagCheckInterval = time.Second
+
+var errStaleChainHead = errors.New("chain head is stale")
+
+func validateStaleHeadRejects(rejects string) error {
+	switch rejects {
+	case StaleHeadRejectResolutions, StaleHeadRejectBids, StaleHeadRejectAll, "":
+		return nil
+	}
+	return fmt.Errorf("invalid stale head rejects %q, must be %s, %s or %s", rejects, StaleHeadRejectResolutions, StaleHeadRejectBids, StaleHeadRejectAll)
+}
+
+// checkHeadLag compares the timestamp of the latest block served by client
+// against the local clock. A node still syncing serves stale heads, against
+// which the round and deposits the auctioneer reads are outdated. Past the max
+// head lag, the auctioneer enters a degraded mode in which it fails readiness
+// and refuses what it is configured to, until a check finds the chain caught
+// up. The same measurement drives the clock drift safe mode, see
+// updateClockDrift. It returns errStaleChainHead if the head is stale, and nil
+// if both checks are disabled.
+func (a *AuctioneerServer) checkHeadLag(ctx context.Context, client AuctioneerClient) error {
+	if a.maxHeadLag <= 0 && a.maxClockDrift <= 0 {
+		return nil
+	}
+	header, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("getting latest header to check head lag: %w", err)
+	}
+	lag := time.Since(time.Unix(arbmath.SaturatingCast[int64](header.Time), 0))
+	a.headLag.Store(int64(lag))
+	a.getMetrics().headLag.Update(lag.Milliseconds())
+	a.updateClockDrift(lag, header.Number)
+	if a.maxHeadLag <= 0 {
+		return nil
+	}
+	stale := lag > a.maxHeadLag
+	if wasStale := a.headStale.Swap(stale); stale && !wasStale {
+		log.Error("Chain head is stale, auctioneer degraded until it catches up", "lag", lag, "maxHeadLag", a.maxHeadLag, "block", header.Number, "rejecting", a.staleHeadRejects)
+	} else if !stale && wasStale {
+		log.Info("Chain head caught up, auctioneer no longer degraded", "lag", lag, "block", header.Number)
+	}
+	if stale {
+		return fmt.Errorf("%w: block %d is %v old, more than the max head lag of %v", errStaleChainHead, header.Number, lag.Truncate(time.Millisecond), a.maxHeadLag)
+	}
+	return nil
+}
+
+// pollHeadLag checks the head lag of the current sequencer, to be called
+// iteratively so that the auctioneer leaves its degraded mode or safe mode
+// without waiting for the next resolution.
+func (a *AuctioneerServer) pollHeadLag(ctx context.Context) time.Duration {
+	interval := jitter(headLagCheckInterval, a.pollJitter)
+	sequencerRpc, _, err := a.endpointManager.GetSequencerRPC(ctx)
+	if err != nil {
+		log.Warn("No sequencer to check head lag of", "err", err)
+		return interval
+	}
+	checkCtx, cancel := context.WithTimeout(ctx, sequencerHealthCheckTimeout)
+	defer cancel()
+	if err := a.checkHeadLag(checkCtx, newSequencerClient(sequencerRpc)); err != nil && !errors.Is(err, errStaleChainHead) {
+		log.Warn("Could not check head lag", "err", err)
+	}
+	return interval
+}
+
+// staleHeadError returns an error while the auctioneer is degraded by a stale
+// chain head, as of the last head lag check.
+func (a *AuctioneerServer) staleHeadError() error {
+	if !a.headStale.Load() {
+		return nil
+	}
+	return fmt.Errorf("%w: latest block is %v old", errStaleChainHead, time.Duration(a.headLag.Load()).Truncate(time.Millisecond))
+}
+
+// rejectsOnStaleHead reports whether the given kind of stale head rejects
+// applies, which it does only while the chain head is stale.
+func (a *AuctioneerServer) rejectsOnStaleHead(rejects string) bool {
+	if a.maxHeadLag <= 0 || (a.staleHeadRejects != rejects && a.staleHeadRejects != StaleHeadRejectAll) {
+		return false
+	}
+	return a.headStale.Load()
+}
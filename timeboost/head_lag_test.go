@@ -0,0 +1,123 @@
+package timeboost
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestStaleChainHead(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	chainId := big.NewInt(1)
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+	require.NoError(t, err)
+	txOpts.GasLimit = 1_000_000
+	newAuctioneer := func(rejects string) *AuctioneerServer {
+		return &AuctioneerServer{
+			txOpts:              txOpts,
+			chainId:             chainId,
+			auctionContractAddr: common.HexToAddress("0x1234"),
+			bidCache:            newBidCache([32]byte{}),
+			roundTimingInfo: RoundTimingInfo{
+				Offset:            time.Now(),
+				Round:             time.Minute,
+				AuctionClosing:    15 * time.Second,
+				ReserveSubmission: 15 * time.Second,
+			},
+			maxHeadLag:       10 * time.Second,
+			staleHeadRejects: rejects,
+		}
+	}
+	bid := func(controller string) *JsonValidatedBid {
+		return &JsonValidatedBid{
+			ChainId:                (*hexutil.Big)(chainId),
+			AuctionContractAddress: common.HexToAddress("0x1234"),
+			Bidder:                 common.HexToAddress(controller),
+			ExpressLaneController:  common.HexToAddress(controller),
+			Round:                  1,
+			Amount:                 (*hexutil.Big)(big.NewInt(10)),
+			Signature:              make([]byte, 65),
+		}
+	}
+	syncing := func() uint64 { return uint64(time.Now().Add(-time.Minute).Unix()) }
+	synced := func() uint64 { return uint64(time.Now().Unix()) }
+
+	t.Run("RecoversOnceCaughtUp", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer(StaleHeadRejectAll)
+		a.receiveValidatedBid(bid("0x1"))
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1), headTime: syncing()}
+
+		_, err := a.resolveAuctionWithClient(ctx, client, true)
+		require.ErrorIs(t, err, errStaleChainHead)
+		require.Empty(t, client.submitted)
+		require.ErrorIs(t, a.staleHeadError(), errStaleChainHead)
+		a.receiveValidatedBid(bid("0x2"))
+		require.Equal(t, 1, a.bidCache.size())
+
+		client.headTime = synced()
+		require.NoError(t, a.checkHeadLag(ctx, client))
+		require.NoError(t, a.staleHeadError())
+		a.receiveValidatedBid(bid("0x2"))
+		require.Equal(t, 2, a.bidCache.size())
+		result, err := a.resolveAuctionWithClient(ctx, client, true)
+		require.NoError(t, err)
+		require.Equal(t, RoundStatusResolved, result.Outcome)
+		require.Len(t, client.submitted, 1)
+	})
+
+	t.Run("RejectsBidsOnly", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer(StaleHeadRejectBids)
+		a.receiveValidatedBid(bid("0x1"))
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1), headTime: syncing()}
+		require.ErrorIs(t, a.checkHeadLag(ctx, client), errStaleChainHead)
+		a.receiveValidatedBid(bid("0x2"))
+		require.Equal(t, 1, a.bidCache.size())
+
+		result, err := a.resolveAuctionWithClient(ctx, client, true)
+		require.NoError(t, err)
+		require.Equal(t, RoundStatusResolved, result.Outcome)
+	})
+
+	t.Run("RejectsResolutionsOnly", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer(StaleHeadRejectResolutions)
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1), headTime: syncing()}
+		require.ErrorIs(t, a.checkHeadLag(ctx, client), errStaleChainHead)
+		a.receiveValidatedBid(bid("0x1"))
+		require.Equal(t, 1, a.bidCache.size())
+
+		_, err := a.resolveAuctionWithClient(ctx, client, true)
+		require.ErrorIs(t, err, errStaleChainHead)
+		require.Empty(t, client.submitted)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer(StaleHeadRejectAll)
+		a.maxHeadLag = 0
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1), headTime: syncing()}
+		require.NoError(t, a.checkHeadLag(ctx, client))
+		require.NoError(t, a.staleHeadError())
+	})
+}
+
+func TestValidateStaleHeadRejects(t *testing.T) {
+	t.Parallel()
+	for _, rejects := range []string{StaleHeadRejectResolutions, StaleHeadRejectBids, StaleHeadRejectAll, ""} {
+		require.NoError(t, validateStaleHeadRejects(rejects))
+	}
+	require.Error(t, validateStaleHeadRejects("nothing"))
+}
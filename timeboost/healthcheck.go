@@ -0,0 +1,115 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	livenessRequestPath  = "/healthz"
+	readinessRequestPath = "/readyz"
+)
+
+// auctioneerHealthcheck serves liveness and readiness probes for the auctioneer.
+type auctioneerHealthcheck struct {
+	a *AuctioneerServer
+}
+
+func (h auctioneerHealthcheck) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	var err error
+	switch request.URL.Path {
+	case livenessRequestPath:
+		err = h.a.checkLiveness()
+	case readinessRequestPath:
+		err = h.a.checkReadiness(request.Context())
+	default:
+		response.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Debug("Auctioneer healthcheck failed", "path", request.URL.Path, "err", err)
+		response.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = response.Write([]byte(err.Error()))
+		return
+	}
+	response.WriteHeader(http.StatusOK)
+}
+
+// checkLiveness fails once the auctioneer's threads have been stopped.
+func (a *AuctioneerServer) checkLiveness() error {
+	if !a.Started() {
+		return errors.New("auctioneer not started")
+	}
+	if a.Stopped() {
+		return errors.New("auctioneer stopped")
+	}
+	return nil
+}
+
+// checkReadiness fails if the auctioneer is paused, degraded by a stale chain
+// head or in safe mode for a drifted clock, if the auction contract can't be
+// reached through the sequencer, if the signer is running low on funds, or if
+// no round was resolved for longer than a round is allowed to take. Resolution
+// happens once per round, but may be retried until the next round starts, so a
+// round duration plus an auction closing period is allowed between two
+// successful resolutions before the auctioneer is considered stuck.
+func (a *AuctioneerServer) checkReadiness(ctx context.Context) error {
+	if err := a.checkLiveness(); err != nil {
+		return err
+	}
+	if a.Paused() {
+		return errAuctioneerPaused
+	}
+	if err := a.staleHeadError(); err != nil {
+		return err
+	}
+	if err := a.clockDriftError(); err != nil {
+		return err
+	}
+	if _, err := a.getAuctionContract().DomainSeparator(&bind.CallOpts{Context: ctx}); err != nil {
+		return fmt.Errorf("auction contract unreachable: %w", err)
+	}
+	if err := a.checkSignerBalance(); err != nil {
+		return err
+	}
+	lastResolution := time.Unix(0, a.lastResolutionTime.Load())
+	roundTimingInfo := a.getRoundTimingInfo()
+	if stalled := time.Since(lastResolution); stalled > roundTimingInfo.Round+roundTimingInfo.AuctionClosing {
+		return fmt.Errorf("no auction resolved in %v", stalled.Truncate(time.Second))
+	}
+	return nil
+}
+
+func (a *AuctioneerServer) launchHealthcheckServer(ctx context.Context, addr string) {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           auctioneerHealthcheck{a},
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	shutdown := make(chan struct{})
+	go func() {
+		defer close(shutdown)
+		<-ctx.Done()
+		err := server.Shutdown(ctx)
+		if err != nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+			log.Warn("error shutting down auctioneer healthcheck server", "err", err)
+		}
+	}()
+
+	err := server.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Warn("error serving auctioneer healthcheck server", "err", err)
+	}
+	// Don't leave the shutdown behind once the auctioneer is stopped.
+	<-shutdown
+}
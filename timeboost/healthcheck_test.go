@@ -0,0 +1,63 @@
+package timeboost
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/offchainlabs/nitro/solgen/go/express_lane_auctiongen"
+)
+
+func TestAuctioneerHealthcheck(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := &fakeAuctioneerClient{}
+	auctionContract, err := express_lane_auctiongen.NewExpressLaneAuction(common.HexToAddress("0x1234"), client)
+	require.NoError(t, err)
+	a := &AuctioneerServer{
+		auctionContract: auctionContract,
+		roundTimingInfo: RoundTimingInfo{
+			Offset:            time.Now(),
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+	}
+	handler := auctioneerHealthcheck{a}
+	status := func(path string) int {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		return recorder.Code
+	}
+
+	require.Equal(t, http.StatusServiceUnavailable, status(livenessRequestPath))
+	require.Equal(t, http.StatusServiceUnavailable, status(readinessRequestPath))
+	require.Equal(t, http.StatusNotFound, status("/unknown"))
+
+	a.StopWaiter.Start(ctx, a)
+	a.lastResolutionTime.Store(time.Now().UnixNano())
+	require.Equal(t, http.StatusOK, status(livenessRequestPath))
+	require.Equal(t, http.StatusOK, status(readinessRequestPath))
+
+	// Not ready if the auction contract can't be reached.
+	client.callErr = errors.New("unreachable")
+	require.Equal(t, http.StatusOK, status(livenessRequestPath))
+	require.Equal(t, http.StatusServiceUnavailable, status(readinessRequestPath))
+	client.callErr = nil
+
+	// Not ready once resolution has stalled for more than a round.
+	a.lastResolutionTime.Store(time.Now().Add(-2 * time.Minute).UnixNano())
+	require.Equal(t, http.StatusServiceUnavailable, status(readinessRequestPath))
+
+	a.StopAndWait()
+	require.Equal(t, http.StatusServiceUnavailable, status(livenessRequestPath))
+}
@@ -0,0 +1,176 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/offchainlabs/nitro/solgen/go/express_lane_auctiongen"
+	"github.com/offchainlabs/nitro/util/arbmath"
+)
+
+// backfillBlockRange is the number of blocks scanned for resolution events per
+// log query. Queries failing, e.g. because the RPC caps the range of blocks or
+// the number of logs they may cover, are retried over half the range.
+const backfillBlockRange = 10_000
+
+// BackfillHistory reconstructs the outcome of the rounds from fromRound to
+// toRound from the AuctionResolved events of the auction contract, and appends
+// those missing from the auction history, e.g. because the auctioneer wasn't
+// running at the time. It returns the number of rounds it appended.
+//
+// The contract emits no event when a resolution is cancelled, nor for rounds
+// without bids, so only resolved rounds can be backfilled. The number of bids
+// a round received is not known from its event either, and is left at zero.
+func (a *AuctioneerServer) BackfillHistory(ctx context.Context, fromRound, toRound uint64) (int, error) {
+	sequencerRpc, _, err := a.endpointManager.GetSequencerRPC(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get sequencer RPC: %w", err)
+	}
+	return a.backfillHistoryWithClient(ctx, newSequencerClient(sequencerRpc), fromRound, toRound)
+}
+
+func (a *AuctioneerServer) backfillHistoryWithClient(ctx context.Context, client AuctioneerClient, fromRound, toRound uint64) (int, error) {
+	if a.history == nil {
+		return 0, errNoAuctionHistory
+	}
+	if fromRound > toRound {
+		return 0, fmt.Errorf("backfill from round %d is after round %d", fromRound, toRound)
+	}
+	recorded := make(map[uint64]bool)
+	outcomes, err := a.history.HistorySince(fromRound)
+	if err != nil {
+		return 0, err
+	}
+	for _, outcome := range outcomes {
+		recorded[outcome.Round] = true
+	}
+
+	found, err := a.scanResolutionEvents(ctx, client, fromRound, toRound)
+	if err != nil {
+		return 0, err
+	}
+	slices.SortFunc(found, func(x, y *RoundOutcome) int { return cmp.Compare(x.Round, y.Round) })
+	appended := 0
+	for _, outcome := range found {
+		if recorded[outcome.Round] {
+			continue
+		}
+		if err := a.history.AppendRoundOutcome(outcome); err != nil {
+			return appended, fmt.Errorf("failed to append outcome of round %d: %w", outcome.Round, err)
+		}
+		appended++
+	}
+	log.Info("Backfilled auction history from resolution events", "fromRound", fromRound, "toRound", toRound, "resolved", len(found), "appended", appended)
+	return appended, nil
+}
+
+// scanResolutionEvents scans the chain backwards from its head for the
+// resolution events of the given rounds, until reaching blocks older than the
+// close of the first round's auction, before which none of them could have
+// been resolved.
+func (a *AuctioneerServer) scanResolutionEvents(ctx context.Context, client AuctioneerClient, fromRound, toRound uint64) ([]*RoundOutcome, error) {
+	filterer, err := express_lane_auctiongen.NewExpressLaneAuctionFilterer(a.auctionContractAddr, client)
+	if err != nil {
+		return nil, err
+	}
+	roundTimingInfo := a.getRoundTimingInfo()
+	earliest := roundTimingInfo.Offset.Add(roundTimingInfo.Round*arbmath.SaturatingCast[time.Duration](fromRound) - roundTimingInfo.AuctionClosing)
+	head, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[uint64]*RoundOutcome)
+	end := head.Number.Uint64()
+	pageSize := uint64(backfillBlockRange)
+	for {
+		start := end - min(end, pageSize-1)
+		it, err := filterer.FilterAuctionResolved(&bind.FilterOpts{Context: ctx, Start: start, End: &end}, nil, nil, nil)
+		if err != nil {
+			if pageSize > 1 && ctx.Err() == nil {
+				pageSize /= 2
+				log.Debug("Narrowing resolution event query", "start", start, "end", end, "blocks", pageSize, "err", err)
+				continue
+			}
+			return nil, err
+		}
+		for it.Next() {
+			event := it.Event
+			if event.Round < fromRound || event.Round > toRound || found[event.Round] != nil {
+				continue
+			}
+			outcome, err := outcomeFromResolutionEvent(ctx, client, event)
+			if err != nil {
+				it.Close()
+				return nil, err
+			}
+			found[event.Round] = outcome
+		}
+		err = it.Error()
+		it.Close()
+		if err != nil {
+			return nil, err
+		}
+		if start == 0 || uint64(len(found)) > toRound-fromRound {
+			break
+		}
+		header, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(start))
+		if err != nil {
+			return nil, err
+		}
+		// #nosec G115
+		if time.Unix(int64(header.Time), 0).Before(earliest) {
+			break
+		}
+		end = start - 1
+	}
+
+	outcomes := make([]*RoundOutcome, 0, len(found))
+	for _, outcome := range found {
+		outcomes = append(outcomes, outcome)
+	}
+	return outcomes, nil
+}
+
+// outcomeFromResolutionEvent builds the outcome of a round as recorded when
+// the auctioneer resolves it, timed by the block of the resolution.
+func outcomeFromResolutionEvent(ctx context.Context, client AuctioneerClient, event *express_lane_auctiongen.ExpressLaneAuctionAuctionResolved) (*RoundOutcome, error) {
+	header, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(event.Raw.BlockNumber))
+	if err != nil {
+		return nil, err
+	}
+	// #nosec G115
+	resolvedAt := time.Unix(int64(header.Time), 0)
+	outcome := &RoundOutcome{
+		Round:        event.Round,
+		Status:       RoundStatusResolved,
+		Winner:       event.FirstPriceExpressLaneController,
+		WinnerBidder: event.FirstPriceBidder,
+		FirstPrice:   (*hexutil.Big)(new(big.Int).Set(event.FirstPriceAmount)),
+		TxHash:       event.Raw.TxHash,
+		Time:         resolvedAt,
+		// The price the contract charged, be it a second price or the reserve price.
+		ClearingPrice: (*hexutil.Big)(new(big.Int).Set(event.Price)),
+		Revenue:       (*hexutil.Big)(new(big.Int).Set(event.Price)),
+	}
+	// A single bid pays the reserve price rather than a second price.
+	if event.IsMultiBidAuction {
+		outcome.SecondPrice = (*hexutil.Big)(new(big.Int).Set(event.Price))
+	}
+	return outcome, nil
+}
+
+func (api *AuctioneerAdminAPI) BackfillHistory(ctx context.Context, fromRound, toRound hexutil.Uint64) (int, error) {
+	return api.auctioneer.BackfillHistory(ctx, uint64(fromRound), uint64(toRound))
+}
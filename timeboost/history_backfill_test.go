@@ -0,0 +1,124 @@
+package timeboost
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/offchainlabs/nitro/solgen/go/express_lane_auctiongen"
+)
+
+func TestBackfillHistory(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	auctionAbi, err := express_lane_auctiongen.ExpressLaneAuctionMetaData.GetAbi()
+	require.NoError(t, err)
+	resolvedEvent := auctionAbi.Events["AuctionResolved"]
+	contractAddr := common.HexToAddress("0x1234")
+	resolved := func(block, round uint64, controller string, firstPrice, price int64, isMultiBid bool) types.Log {
+		values := map[string]any{
+			"isMultiBidAuction":   isMultiBid,
+			"firstPriceAmount":    big.NewInt(firstPrice),
+			"price":               big.NewInt(price),
+			"roundStartTimestamp": round * 60,
+			"roundEndTimestamp":   (round + 1) * 60,
+		}
+		var args []any
+		for _, input := range resolvedEvent.Inputs.NonIndexed() {
+			args = append(args, values[input.Name])
+		}
+		data, err := resolvedEvent.Inputs.NonIndexed().Pack(args...)
+		require.NoError(t, err)
+		return types.Log{
+			Address: contractAddr,
+			Topics: []common.Hash{
+				resolvedEvent.ID,
+				common.BigToHash(new(big.Int).SetUint64(round)),
+				common.HexToAddress(controller).Hash(),
+				common.HexToAddress(controller).Hash(),
+			},
+			Data:        data,
+			BlockNumber: block,
+			TxHash:      common.Hash{byte(round)},
+		}
+	}
+
+	// Rounds last a minute from the epoch, and blocks are a second apart.
+	client := &fakeAuctioneerClient{
+		head: 30_000,
+		logs: []types.Log{
+			resolved(50, 1, "0x1", 10, 10, false),
+			resolved(5_000, 83, "0x1", 20, 10, true),
+			resolved(25_000, 417, "0x2", 30, 5, false),
+			resolved(29_000, 484, "0x3", 40, 30, true),
+		},
+		// Queries over more blocks than this are rejected, like many RPCs do.
+		maxLogRange: 4_000,
+	}
+	db, err := NewDatabase(t.TempDir())
+	require.NoError(t, err)
+	a := &AuctioneerServer{
+		auctionContractAddr: contractAddr,
+		roundTimingInfo: RoundTimingInfo{
+			Offset:            time.Unix(0, 0),
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+	}
+	_, err = a.backfillHistoryWithClient(ctx, client, 80, 500)
+	require.ErrorIs(t, err, errNoAuctionHistory)
+	WithAuctionHistory(db)(a)
+
+	// Rounds already in the history are not appended again.
+	a.recordRoundOutcome(417, RoundStatusResolved, &auctionResult{firstPlace: &ValidatedBid{ExpressLaneController: common.HexToAddress("0x2"), Amount: big.NewInt(30)}}, 2, common.Hash{1}, nil)
+	appended, err := a.backfillHistoryWithClient(ctx, client, 80, 500)
+	require.NoError(t, err)
+	require.Equal(t, 2, appended)
+
+	history, err := a.HistorySince(0)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	require.Equal(t, uint64(417), history[0].Round)
+	require.Equal(t, uint64(2), history[0].NumBids)
+	for i, expected := range []*RoundOutcome{{
+		Round:        83,
+		Status:       RoundStatusResolved,
+		Winner:       common.HexToAddress("0x1"),
+		WinnerBidder: common.HexToAddress("0x1"),
+		FirstPrice:   (*hexutil.Big)(big.NewInt(20)),
+		SecondPrice:  (*hexutil.Big)(big.NewInt(10)),
+		TxHash:       common.Hash{83},
+		Time:         time.Unix(5_000, 0),
+		// Backfilled from the price the contract charged.
+		ClearingPrice: (*hexutil.Big)(big.NewInt(10)),
+		Revenue:       (*hexutil.Big)(big.NewInt(10)),
+	}, {
+		Round:        484,
+		Status:       RoundStatusResolved,
+		Winner:       common.HexToAddress("0x3"),
+		WinnerBidder: common.HexToAddress("0x3"),
+		FirstPrice:   (*hexutil.Big)(big.NewInt(40)),
+		SecondPrice:  (*hexutil.Big)(big.NewInt(30)),
+		TxHash:       common.Hash{228},
+		Time:         time.Unix(29_000, 0),
+		// Backfilled from the price the contract charged.
+		ClearingPrice: (*hexutil.Big)(big.NewInt(30)),
+		Revenue:       (*hexutil.Big)(big.NewInt(30)),
+	}} {
+		outcome := history[i+1]
+		require.True(t, expected.Time.Equal(outcome.Time))
+		outcome.Time = expected.Time
+		require.Equal(t, expected, outcome)
+	}
+
+	_, err = a.backfillHistoryWithClient(ctx, client, 2, 1)
+	require.Error(t, err)
+}
@@ -0,0 +1,56 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"fmt"
+)
+
+// checkInvariants enables internal consistency checks, which panic on violation
+// and are too costly for production. They are compiled in by building with the
+// timeboostinvariants tag, and always run in this package's tests.
+var checkInvariants = invariantsEnabled
+
+// checkTopTwoBids panics unless result holds the two highest ranked bids in the
+// cache, as found by scanning every cached bid and comparing amounts exactly as
+// big.Ints, so that an inexact comparison, e.g. after rounding amounts to
+// floats, can never go unnoticed in the ranking. The lock must be held.
+func (bc *bidCache) checkTopTwoBids(result *auctionResult) {
+	var first, second *rankedBid
+	for _, bid := range bc.bidsByExpressLaneControllerAddr {
+		ranked := &rankedBid{bid: bid, hash: bid.BigIntHash(bc.auctionContractDomainSeparator)}
+		switch {
+		case first == nil || exactlyOutranks(ranked, first):
+			first, second = ranked, first
+		case second == nil || exactlyOutranks(ranked, second):
+			second = ranked
+		}
+	}
+	if bid := bidOf(first); result.firstPlace != bid {
+		panic(fmt.Sprintf("timeboost invariant violated: first place among %d bids is %+v, expected %+v", len(bc.bidsByExpressLaneControllerAddr), result.firstPlace, bid))
+	}
+	if bid := bidOf(second); result.secondPlace != bid {
+		panic(fmt.Sprintf("timeboost invariant violated: second place among %d bids is %+v, expected %+v", len(bc.bidsByExpressLaneControllerAddr), result.secondPlace, bid))
+	}
+}
+
+// exactlyOutranks restates rankedBid.outranks, so that the check doesn't share
+// a bug with the ranking it checks.
+func exactlyOutranks(bid, other *rankedBid) bool {
+	switch bid.bid.Amount.Cmp(other.bid.Amount) {
+	case 1:
+		return true
+	case -1:
+		return false
+	default:
+		return bid.hash.Cmp(other.hash) == 1
+	}
+}
+
+func bidOf(ranked *rankedBid) *ValidatedBid {
+	if ranked == nil {
+		return nil
+	}
+	return ranked.bid
+}
@@ -0,0 +1,9 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+//go:build !timeboostinvariants
+// +build !timeboostinvariants
+
+package timeboost
+
+const invariantsEnabled = false
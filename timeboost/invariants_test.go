@@ -0,0 +1,93 @@
+package timeboost
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func init() {
+	// Every test of the package runs with the invariant checks.
+	checkInvariants = true
+}
+
+// adversarialAmount returns amounts that are equal, or differ only in bits
+// lost when amounts are compared as float64s or truncated to 64 bits.
+func adversarialAmount(rng *rand.Rand) *big.Int {
+	bases := []*big.Int{
+		big.NewInt(0),
+		new(big.Int).Lsh(big.NewInt(1), 53),
+		new(big.Int).Lsh(big.NewInt(1), 64),
+		new(big.Int).Lsh(big.NewInt(1), 128),
+		new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(3)),
+	}
+	amount := new(big.Int).Set(bases[rng.Intn(len(bases))])
+	amount.Add(amount, big.NewInt(rng.Int63n(3)))
+	if rng.Intn(2) == 0 && amount.BitLen() > 2 {
+		// Straddles the base, e.g. 2^64-1 against 2^64.
+		amount.Sub(amount, big.NewInt(2))
+	}
+	return amount
+}
+
+func TestTopTwoBidsExactlyHighestAmounts(t *testing.T) {
+	t.Parallel()
+	for seed := int64(0); seed < 20; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		bc := newBidCache([32]byte{byte(seed)})
+		for op := 0; op < 500; op++ {
+			if rng.Intn(10) < 8 {
+				bc.add(&ValidatedBid{
+					ExpressLaneController: common.BigToAddress(big.NewInt(rng.Int63n(12) + 1)),
+					Bidder:                common.BigToAddress(big.NewInt(rng.Int63n(6) + 1)),
+					Round:                 1,
+					Amount:                adversarialAmount(rng),
+				})
+			} else {
+				bc.remove(common.BigToAddress(big.NewInt(rng.Int63n(6) + 1)))
+			}
+			// topTwoBids checks itself against a full scan.
+			result := bc.topTwoBids()
+			for _, bid := range bc.snapshot() {
+				if result.firstPlace != bid {
+					require.LessOrEqual(t, bid.Amount.Cmp(result.firstPlace.Amount), 0, "seed %d, op %d", seed, op)
+				}
+				if result.firstPlace != bid && result.secondPlace != bid {
+					require.LessOrEqual(t, bid.Amount.Cmp(result.secondPlace.Amount), 0, "seed %d, op %d", seed, op)
+				}
+			}
+		}
+	}
+}
+
+func TestCheckTopTwoBidsCatchesInexactRanking(t *testing.T) {
+	t.Parallel()
+	bc := newBidCache([32]byte{})
+	low := &ValidatedBid{
+		ExpressLaneController: common.HexToAddress("0x1"),
+		Bidder:                common.HexToAddress("0x1"),
+		Round:                 1,
+		Amount:                new(big.Int).Lsh(big.NewInt(1), 64),
+	}
+	high := &ValidatedBid{
+		ExpressLaneController: common.HexToAddress("0x2"),
+		Bidder:                common.HexToAddress("0x2"),
+		Round:                 1,
+		Amount:                new(big.Int).Add(low.Amount, big.NewInt(1)),
+	}
+	bc.add(low)
+	bc.add(high)
+	require.Same(t, high, bc.topTwoBids().firstPlace)
+
+	// Both amounts are the same float64, which must not decide the ranking.
+	require.Panics(t, func() {
+		bc.checkTopTwoBids(&auctionResult{firstPlace: low, secondPlace: high})
+	})
+	require.Panics(t, func() {
+		bc.checkTopTwoBids(&auctionResult{firstPlace: high})
+	})
+}
@@ -0,0 +1,29 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+
+	"github.com/offchainlabs/nitro/util/arbmath"
+)
+
+var lateBidsCounter = metrics.NewRegisteredCounter("arb/validator/bids/late", nil)
+
+// lateBidError rejects a bid that arrived after the auction of the round it
+// declares closed, telling it apart from a bid declaring the wrong round.
+func (bv *BidValidator) lateBidError(bid *Bid, now time.Time) error {
+	closedAt := bv.roundTimingInfo.Offset.
+		Add(bv.roundTimingInfo.Round * arbmath.SaturatingCast[time.Duration](bid.Round)).
+		Add(-auctionClosingFor(bv.roundTimingInfo, bv.closingDurationForRound, bid.Round))
+	late := now.Sub(closedAt)
+	lateBidsCounter.Inc(1)
+	log.Info("Rejecting bid arrived too late for its round", "round", bid.Round, "controller", bid.ExpressLaneController, "correlationId", bid.CorrelationId, "late", late)
+	return errors.Wrapf(ErrBidTooLate, "auction of round %d closed %v ago", bid.Round, late)
+}
@@ -0,0 +1,113 @@
+package timeboost
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestBidValidator_validateBid_lateBids(t *testing.T) {
+	t.Parallel()
+	auctionContractAddr := common.Address{'a'}
+	newValidator := func(intoRound time.Duration, rejectLateBids bool) *BidValidator {
+		return &BidValidator{
+			chainId: big.NewInt(1),
+			roundTimingInfo: RoundTimingInfo{
+				// Round 2 is in progress.
+				Offset:         time.Now().Add(-time.Minute - intoRound),
+				Round:          time.Minute,
+				AuctionClosing: 15 * time.Second,
+			},
+			reservePrice:            big.NewInt(2),
+			auctionContractAddr:     auctionContractAddr,
+			bidsPerSenderInRound:    make(map[common.Address]uint8),
+			maxBidsPerSenderInRound: 5,
+			rejectLateBids:          rejectLateBids,
+		}
+	}
+	bidFor := func(round uint64) *Bid {
+		return &Bid{
+			ExpressLaneController:  common.Address{'b'},
+			AuctionContractAddress: auctionContractAddr,
+			ChainId:                big.NewInt(1),
+			Round:                  round,
+			Amount:                 big.NewInt(3),
+		}
+	}
+	tests := []struct {
+		name           string
+		intoRound      time.Duration
+		round          uint64
+		rejectLateBids bool
+		expectedErr    error
+		errMsg         string
+	}{
+		{
+			name:        "round started, disabled",
+			intoRound:   10 * time.Second,
+			round:       1,
+			expectedErr: ErrBadRoundNumber,
+			errMsg:      "wanted 2, got 1",
+		},
+		{
+			name:           "round started",
+			intoRound:      10 * time.Second,
+			round:          1,
+			rejectLateBids: true,
+			expectedErr:    ErrBidTooLate,
+			errMsg:         "auction of round 1 closed 25",
+		},
+		{
+			name:           "auction closed",
+			intoRound:      50 * time.Second,
+			round:          2,
+			rejectLateBids: true,
+			expectedErr:    ErrBidTooLate,
+			errMsg:         "auction of round 2 closed 5",
+		},
+		{
+			name:        "auction closed, disabled",
+			intoRound:   50 * time.Second,
+			round:       2,
+			expectedErr: ErrBadRoundNumber,
+			errMsg:      "auction is closed",
+		},
+		{
+			// Not late, but a round long past or yet to be auctioned.
+			name:           "wrong round",
+			intoRound:      10 * time.Second,
+			round:          3,
+			rejectLateBids: true,
+			expectedErr:    ErrBadRoundNumber,
+			errMsg:         "wanted 2, got 3",
+		},
+		{
+			name:           "past round",
+			intoRound:      10 * time.Second,
+			round:          0,
+			rejectLateBids: true,
+			expectedErr:    ErrBadRoundNumber,
+			errMsg:         "wanted 2, got 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			bv := newValidator(tt.intoRound, tt.rejectLateBids)
+			before := lateBidsCounter.Snapshot().Count()
+			_, err := bv.validateBid(bidFor(tt.round), nil)
+			require.ErrorIs(t, err, tt.expectedErr)
+			require.Contains(t, err.Error(), tt.errMsg)
+			if tt.expectedErr == ErrBidTooLate {
+				require.Greater(t, lateBidsCounter.Snapshot().Count(), before)
+				reason, ok := BidRejectionReason(err)
+				require.True(t, ok)
+				require.Equal(t, "BID_ARRIVED_TOO_LATE", reason)
+			}
+		})
+	}
+}
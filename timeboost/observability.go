@@ -0,0 +1,103 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// Observability is best effort: the metrics registry and tracer provider are
+// supplied by the operator, and a failure in either must never hold up bid
+// processing or resolution. Metric values are only ever updated through the
+// in-memory metric types of go-ethereum, which can't fail, so only registering
+// metrics and tracing are guarded. Failures are logged once per kind, then
+// swallowed.
+
+var loggedObservabilityFailures sync.Map
+
+func logObservabilityFailure(kind string, failure any) {
+	if _, logged := loggedObservabilityFailures.LoadOrStore(kind, struct{}{}); logged {
+		return
+	}
+	log.Error("Observability failure, carrying on without it", "kind", kind, "failure", failure)
+}
+
+// newGuardedAuctioneerMetrics registers the auctioneer's metrics into the
+// registry, falling back to metrics that aren't exported if registering fails.
+func newGuardedAuctioneerMetrics(registry metrics.Registry, prefix string) (m *auctioneerMetrics) {
+	defer func() {
+		if r := recover(); r != nil {
+			logObservabilityFailure("metrics registry", r)
+			m = newAuctioneerMetrics(metrics.NewRegistry(), prefix)
+		}
+	}()
+	return newAuctioneerMetrics(registry, prefix)
+}
+
+// guardedTracer keeps a failing tracer from failing what it traces. Spans it
+// fails to start are replaced by no-op spans continuing the trace.
+type guardedTracer struct {
+	trace.Tracer
+}
+
+func newGuardedTracer(provider trace.TracerProvider) (tracer trace.Tracer) {
+	defer func() {
+		if r := recover(); r != nil {
+			logObservabilityFailure("tracer provider", r)
+			tracer = noopTracer
+		}
+	}()
+	return guardedTracer{provider.Tracer(tracerName)}
+}
+
+func (t guardedTracer) Start(ctx context.Context, spanName string, opts ...trace.SpanStartOption) (spanCtx context.Context, span trace.Span) {
+	defer func() {
+		if r := recover(); r != nil {
+			logObservabilityFailure("tracer", r)
+			spanCtx, span = noopTracer.Start(ctx, spanName)
+		}
+	}()
+	spanCtx, span = t.Tracer.Start(ctx, spanName, opts...)
+	return spanCtx, guardedSpan{span}
+}
+
+// guardedSpan guards the span methods used by the auctioneer and the bid
+// validator.
+type guardedSpan struct {
+	trace.Span
+}
+
+func recoverSpanFailure() {
+	if r := recover(); r != nil {
+		logObservabilityFailure("span", r)
+	}
+}
+
+func (s guardedSpan) End(options ...trace.SpanEndOption) {
+	defer recoverSpanFailure()
+	s.Span.End(options...)
+}
+
+func (s guardedSpan) RecordError(err error, options ...trace.EventOption) {
+	defer recoverSpanFailure()
+	s.Span.RecordError(err, options...)
+}
+
+func (s guardedSpan) SetStatus(code codes.Code, description string) {
+	defer recoverSpanFailure()
+	s.Span.SetStatus(code, description)
+}
+
+func (s guardedSpan) SetAttributes(kv ...attribute.KeyValue) {
+	defer recoverSpanFailure()
+	s.Span.SetAttributes(kv...)
+}
@@ -0,0 +1,111 @@
+package timeboost
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// failingRegistry fails to register any metric.
+type failingRegistry struct {
+	metrics.Registry
+}
+
+func (failingRegistry) Register(name string, metric interface{}) error {
+	panic("metrics registry unavailable")
+}
+
+// failingTracerProvider hands out tracers failing to start spans, or whose
+// spans fail.
+type failingTracerProvider struct {
+	noop.TracerProvider
+	failStart bool
+}
+
+func (p failingTracerProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return failingTracer{Tracer: noopTracer, failStart: p.failStart}
+}
+
+type failingTracer struct {
+	trace.Tracer
+	failStart bool
+}
+
+func (t failingTracer) Start(ctx context.Context, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if t.failStart {
+		panic("tracer unavailable")
+	}
+	ctx, span := t.Tracer.Start(ctx, spanName, opts...)
+	return ctx, failingSpan{span}
+}
+
+type failingSpan struct {
+	trace.Span
+}
+
+func (failingSpan) End(...trace.SpanEndOption) {
+	panic("span exporter unavailable")
+}
+
+func (failingSpan) SetAttributes(...attribute.KeyValue) {
+	panic("span exporter unavailable")
+}
+
+func TestFailingObservabilityDoesNotAffectResolution(t *testing.T) {
+	t.Parallel()
+	chainId := big.NewInt(1)
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+	require.NoError(t, err)
+	txOpts.GasLimit = 1_000_000
+
+	for _, failStart := range []bool{false, true} {
+		a := &AuctioneerServer{
+			txOpts:   txOpts,
+			chainId:  chainId,
+			bidCache: newBidCache([32]byte{}),
+			roundTimingInfo: RoundTimingInfo{
+				Offset:            time.Now(),
+				Round:             time.Minute,
+				AuctionClosing:    15 * time.Second,
+				ReserveSubmission: 15 * time.Second,
+			},
+		}
+		WithMetricsRegistry(failingRegistry{metrics.NewRegistry()}, "arb/auctioneer/")(a)
+		WithTracerProvider(failingTracerProvider{failStart: failStart})(a)
+		require.NotNil(t, a.metrics)
+
+		for _, bidder := range []string{"0x1", "0x2"} {
+			a.receiveValidatedBid(&JsonValidatedBid{
+				ChainId:               (*hexutil.Big)(chainId),
+				Bidder:                common.HexToAddress(bidder),
+				ExpressLaneController: common.HexToAddress(bidder),
+				Round:                 hexutil.Uint64(a.UpcomingRound()),
+				Amount:                (*hexutil.Big)(big.NewInt(10)),
+				Signature:             make([]byte, 65),
+			})
+		}
+		require.Equal(t, 2, a.bidCache.size())
+
+		ctx, span := a.getTracer().Start(context.Background(), "timeboost.resolveRound")
+		result, err := a.resolveAuctionWithClient(ctx, &fakeAuctioneerClient{baseFee: big.NewInt(1)}, true)
+		endSpan(span, err)
+		require.NoError(t, err)
+		require.Equal(t, RoundStatusResolved, result.Outcome)
+		// Metrics are still kept, only not exported.
+		require.NotZero(t, a.getMetrics().firstBidValue.Snapshot().Value())
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// WithObserverMode runs the auctioneer as a read-only monitoring instance
+// alongside the auctioneer actually resolving rounds. It receives bids and
+// computes the resolution of every round like that auctioneer, but never
+// sends a transaction, so it doesn't need a wallet or signer to be configured.
+func WithObserverMode(enabled bool) AuctioneerServerOpt {
+	return func(a *AuctioneerServer) {
+		a.observerMode = enabled
+	}
+}
+
+// observeResolution reports the resolution the auctioneer would have submitted
+// for the round through logs, metrics, the audit sink and the auction history.
+// It returns false if there was nothing to resolve the round with.
+func (a *AuctioneerServer) observeResolution(round uint64, result *auctionResult, numBids uint64) bool {
+	first, second := result.firstPlace, result.secondPlace
+	switch {
+	case first != nil && second != nil:
+		a.getMetrics().firstBidValue.Update(first.Amount.Int64())
+		a.getMetrics().secondBidValue.Update(second.Amount.Int64())
+		log.Info("Observer would resolve auction with two bids", "round", round, "winner", first.ExpressLaneController, "firstPrice", first.Amount.String(), "secondPrice", second.Amount.String())
+	case first != nil:
+		a.getMetrics().firstBidValue.Update(first.Amount.Int64())
+		log.Info("Observer would resolve auction with single bid", "round", round, "winner", first.ExpressLaneController, "firstPrice", first.Amount.String())
+	default:
+		log.Info("No bids received for auction resolution", "round", round)
+		if numBids == 0 {
+			a.warnAllBelowReserve(round)
+		}
+		a.setRoundStatus(round, RoundStatusNoBids)
+		return false
+	}
+	a.getMetrics().observedResolutions.Inc(1)
+	a.emitAuditRecord(round, RoundStatusObserved, result, common.Hash{}, nil)
+	a.recordRoundOutcome(round, RoundStatusObserved, result, numBids, common.Hash{}, nil)
+	return true
+}
@@ -0,0 +1,49 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var errAuctioneerPaused = errors.New("auctioneer is paused")
+
+// Pause stops the auctioneer from resolving auctions, e.g. during maintenance
+// of the auction contract, without shutting it down. Bids are still received
+// and cached while paused, but rounds closing in the meantime are skipped.
+func (a *AuctioneerServer) Pause() {
+	if !a.paused.Swap(true) {
+		log.Warn("Auctioneer paused, auctions will not be resolved until resumed", "upcomingRound", a.UpcomingRound())
+	}
+}
+
+// Resume undoes Pause, resolving auctions again from the next round closing.
+func (a *AuctioneerServer) Resume() {
+	if a.paused.Swap(false) {
+		// Give the auctioneer a full grace period before readiness checks
+		// fail on rounds that were skipped while paused.
+		a.lastResolutionTime.Store(time.Now().UnixNano())
+		log.Info("Auctioneer resumed", "upcomingRound", a.UpcomingRound())
+	}
+}
+
+// Paused returns whether the auctioneer is paused.
+func (a *AuctioneerServer) Paused() bool {
+	return a.paused.Load()
+}
+
+func (api *AuctioneerAdminAPI) Pause() {
+	api.auctioneer.Pause()
+}
+
+func (api *AuctioneerAdminAPI) Resume() {
+	api.auctioneer.Resume()
+}
+
+func (api *AuctioneerAdminAPI) Paused() bool {
+	return api.auctioneer.Paused()
+}
@@ -0,0 +1,54 @@
+package timeboost
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestPauseAndResume(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	a := &AuctioneerServer{
+		bidCache: newBidCache([32]byte{}),
+		roundTimingInfo: RoundTimingInfo{
+			Offset:            time.Now().Add(-50 * time.Second),
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+	}
+	a.StopWaiter.Start(ctx, a)
+	defer a.StopWaiter.StopAndWait()
+	resolutions := 0
+	resolve := func(context.Context) error {
+		resolutions++
+		return nil
+	}
+	api := &AuctioneerAdminAPI{a}
+	api.Pause()
+	require.True(t, api.Paused())
+
+	// Bids are still cached while paused, but nothing is resolved.
+	a.receiveValidatedBid(&JsonValidatedBid{Amount: (*hexutil.Big)(big.NewInt(1)), ChainId: (*hexutil.Big)(big.NewInt(1)), Round: 2})
+	require.Equal(t, 1, a.bidCache.size())
+	require.ErrorIs(t, a.resolveUpcomingRound(ctx, resolve), errAuctioneerPaused)
+	_, err := a.ResolveNow(ctx)
+	require.ErrorIs(t, err, errAuctioneerPaused)
+	require.Zero(t, resolutions)
+	status, ok := a.RoundOutcome(a.UpcomingRound())
+	require.True(t, ok)
+	require.Equal(t, RoundStatusPaused, status)
+	require.ErrorIs(t, a.checkReadiness(ctx), errAuctioneerPaused)
+
+	api.Resume()
+	require.False(t, api.Paused())
+	require.NotZero(t, a.lastResolutionTime.Load())
+	require.NoError(t, a.resolveUpcomingRound(ctx, resolve))
+	require.Equal(t, 1, resolutions)
+}
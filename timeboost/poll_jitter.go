@@ -0,0 +1,38 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// maxPollJitter bounds the jitter of polling intervals, so that no poll is
+// ever delayed by more than half its interval.
+const maxPollJitter = 0.5
+
+// DefaultPollJitter is the default poll jitter of the auctioneer and the bid
+// validator.
+const DefaultPollJitter = 0.1
+
+func validatePollJitter(fraction float64) error {
+	if fraction < 0 || fraction > maxPollJitter {
+		return fmt.Errorf("invalid poll jitter %v, must be between 0 and %v", fraction, maxPollJitter)
+	}
+	return nil
+}
+
+// jitter randomizes a polling interval by up to the given fraction of it
+// either way, so that pollers of several instances on the same interval don't
+// hit the RPC endpoint in lockstep. The jitter is uniform around the interval,
+// so the average interval stays the same. A zero fraction disables it.
+func jitter(interval time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 || interval <= 0 {
+		return interval
+	}
+	fraction = min(fraction, maxPollJitter)
+	// #nosec G404
+	return interval + time.Duration((rand.Float64()*2-1)*fraction*float64(interval))
+}
@@ -0,0 +1,33 @@
+package timeboost
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJitter(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, time.Second, jitter(time.Second, 0))
+	require.Zero(t, jitter(0, 0.1))
+
+	var total time.Duration
+	const polls = 10_000
+	for i := 0; i < polls; i++ {
+		interval := jitter(time.Second, 0.1)
+		require.GreaterOrEqual(t, interval, 900*time.Millisecond)
+		require.LessOrEqual(t, interval, 1100*time.Millisecond)
+		total += interval
+	}
+	// The average interval doesn't drift.
+	require.InDelta(t, float64(time.Second), float64(total/polls), float64(5*time.Millisecond))
+
+	// Jitter is capped at half the interval.
+	for i := 0; i < 100; i++ {
+		require.GreaterOrEqual(t, jitter(time.Second, 5), 500*time.Millisecond)
+	}
+	require.NoError(t, validatePollJitter(DefaultPollJitter))
+	require.Error(t, validatePollJitter(-0.1))
+	require.Error(t, validatePollJitter(maxPollJitter+0.1))
+}
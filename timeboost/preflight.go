@@ -0,0 +1,197 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/offchainlabs/nitro/solgen/go/express_lane_auctiongen"
+)
+
+// Checks run by Preflight.
+const (
+	PreflightAuctionContract = "auction-contract"
+	PreflightRoundTiming     = "round-timing"
+	PreflightSignerFunds     = "signer-funds"
+	PreflightReservePrice    = "reserve-price"
+)
+
+// PreflightCheck is the outcome of a single check run by Preflight. Err is nil
+// if the check passed.
+type PreflightCheck struct {
+	Name string
+	Err  error
+}
+
+// PreflightError is returned by Preflight if any of its checks failed. It
+// holds the outcome of every check, passed or not, and the settings they were
+// run with.
+type PreflightError struct {
+	Checks []PreflightCheck
+	Config *EffectiveConfig
+}
+
+func (e *PreflightError) Error() string {
+	var report []string
+	for _, check := range e.Checks {
+		if check.Err != nil {
+			report = append(report, fmt.Sprintf("%s: %v", check.Name, check.Err))
+		} else {
+			report = append(report, check.Name+": ok")
+		}
+	}
+	return "auctioneer preflight failed: " + strings.Join(report, "; ")
+}
+
+func (e *PreflightError) Unwrap() []error {
+	var errs []error
+	for _, check := range e.Checks {
+		if check.Err != nil {
+			errs = append(errs, check.Err)
+		}
+	}
+	return errs
+}
+
+// Preflight checks, once, that the auctioneer is ready to resolve rounds, so
+// that misconfigurations show before the first round rather than when it is
+// resolved. It checks that the auction contract serves its view calls through
+// the sequencer, that its round timing matches the auctioneer's and leaves
+// room for the resolution wait time, that every signer can pay for a
+// resolution of the maximum gas, and that the reserve price isn't below the
+// min reserve price. Every check is run, and if any fails a *PreflightError
+// reports all of them, along with the effective config, which is logged
+// either way. Bids are received by the bid validators, whose RPC
+// listeners are not checked here.
+func (a *AuctioneerServer) Preflight(ctx context.Context) error {
+	sequencerRpc, _, err := a.endpointManager.GetSequencerRPC(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get sequencer RPC: %w", err)
+	}
+	return a.preflightWithClient(ctx, newSequencerClient(sequencerRpc))
+}
+
+func (a *AuctioneerServer) preflightWithClient(ctx context.Context, client AuctioneerClient) error {
+	auctionContract, err := express_lane_auctiongen.NewExpressLaneAuction(a.auctionContractAddr, client)
+	if err != nil {
+		return err
+	}
+	config := a.EffectiveConfig()
+	log.Info("Auctioneer preflight with effective config", "config", config)
+	callOpts := &bind.CallOpts{Context: ctx}
+	checks := []PreflightCheck{
+		{PreflightAuctionContract, a.preflightAuctionContract(callOpts, auctionContract)},
+		{PreflightRoundTiming, a.preflightRoundTiming(callOpts, auctionContract)},
+		{PreflightSignerFunds, a.preflightSignerFunds(ctx, client)},
+		{PreflightReservePrice, preflightReservePrice(callOpts, auctionContract)},
+	}
+	failed := false
+	for _, check := range checks {
+		if check.Err != nil {
+			log.Error("Auctioneer preflight check failed", "check", check.Name, "err", check.Err)
+			failed = true
+		} else {
+			log.Info("Auctioneer preflight check passed", "check", check.Name)
+		}
+	}
+	if failed {
+		return &PreflightError{Checks: checks, Config: config}
+	}
+	return nil
+}
+
+func (a *AuctioneerServer) preflightAuctionContract(callOpts *bind.CallOpts, auctionContract *express_lane_auctiongen.ExpressLaneAuction) error {
+	domainSeparator, err := auctionContract.DomainSeparator(callOpts)
+	if err != nil {
+		return fmt.Errorf("auction contract %s unreachable: %w", a.auctionContractAddr, err)
+	}
+	if a.auctionContractDomainSeparator != ([32]byte{}) && domainSeparator != a.auctionContractDomainSeparator {
+		return fmt.Errorf("auction contract %s domain separator %#x, expected %#x", a.auctionContractAddr, domainSeparator, a.auctionContractDomainSeparator)
+	}
+	return nil
+}
+
+func (a *AuctioneerServer) preflightRoundTiming(callOpts *bind.CallOpts, auctionContract *express_lane_auctiongen.ExpressLaneAuction) error {
+	rawRoundTimingInfo, err := auctionContract.RoundTimingInfo(callOpts)
+	if err != nil {
+		return err
+	}
+	roundTimingInfo, err := NewRoundTimingInfo(rawRoundTimingInfo)
+	if err != nil {
+		return err
+	}
+	if current := a.getRoundTimingInfo(); !current.equal(roundTimingInfo) {
+		return fmt.Errorf("auction contract round timing %+v differs from the auctioneer's %+v", *roundTimingInfo, current)
+	}
+	return roundTimingInfo.ValidateResolutionWaitTime(a.auctionResolutionWaitTime)
+}
+
+// preflightSignerFunds checks every signer can pay for a resolution of the
+// maximum gas at twice the current base fee, and holds the minimum balance.
+func (a *AuctioneerServer) preflightSignerFunds(ctx context.Context, client AuctioneerClient) error {
+	if a.observerMode {
+		// Nothing is ever sent in observer mode.
+		return nil
+	}
+	signers := a.signers
+	if len(signers) == 0 {
+		signers = []*bind.TransactOpts{a.txOpts}
+	}
+	header, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+	gasPrice := header.BaseFee
+	if gasPrice == nil {
+		if gasPrice, err = client.SuggestGasPrice(ctx); err != nil {
+			return err
+		}
+	}
+	var errs []error
+	for _, signer := range signers {
+		if signer == nil {
+			errs = append(errs, errors.New("no signer configured"))
+			continue
+		}
+		gas := a.maxResolutionGas
+		if gas == 0 {
+			gas = signer.GasLimit
+		}
+		required := new(big.Int).Mul(new(big.Int).SetUint64(gas), new(big.Int).Mul(gasPrice, big.NewInt(2)))
+		if a.minSignerBalance != nil && a.minSignerBalance.Cmp(required) > 0 {
+			required = a.minSignerBalance
+		}
+		balance, err := client.BalanceAt(ctx, signer.From, nil)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if balance.Cmp(required) < 0 {
+			errs = append(errs, fmt.Errorf("%w: signer %s balance %s, required %s", errSignerOutOfFunds, signer.From, balance.String(), required.String()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func preflightReservePrice(callOpts *bind.CallOpts, auctionContract *express_lane_auctiongen.ExpressLaneAuction) error {
+	reservePrice, err := auctionContract.ReservePrice(callOpts)
+	if err != nil {
+		return err
+	}
+	minReservePrice, err := auctionContract.MinReservePrice(callOpts)
+	if err != nil {
+		return err
+	}
+	if reservePrice.Cmp(minReservePrice) < 0 {
+		return fmt.Errorf("reserve price %s is below min reserve price %s", reservePrice.String(), minReservePrice.String())
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package timeboost
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/offchainlabs/nitro/solgen/go/express_lane_auctiongen"
+)
+
+func TestPreflight(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	auctionAbi, err := express_lane_auctiongen.ExpressLaneAuctionMetaData.GetAbi()
+	require.NoError(t, err)
+	pack := func(method string, values ...any) ([4]byte, []byte) {
+		result, err := auctionAbi.Methods[method].Outputs.Pack(values...)
+		require.NoError(t, err)
+		return [4]byte(auctionAbi.Methods[method].ID), result
+	}
+	offset := time.Unix(time.Now().Add(-10*time.Minute).Unix(), 0)
+	newClient := func(roundSeconds uint64, reservePrice, minReservePrice int64) *fakeAuctioneerClient {
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1), callResults: map[[4]byte][]byte{}}
+		for _, call := range [][]any{
+			{"roundTimingInfo", offset.Unix(), roundSeconds, uint64(15), uint64(15)},
+			{"reservePrice", big.NewInt(reservePrice)},
+			{"minReservePrice", big.NewInt(minReservePrice)},
+		} {
+			selector, result := pack(call[0].(string), call[1:]...)
+			client.callResults[selector] = result
+		}
+		return client
+	}
+	a := &AuctioneerServer{
+		txOpts:                    &bind.TransactOpts{From: common.HexToAddress("0x1")},
+		auctionContractAddr:       common.HexToAddress("0x1234"),
+		auctionResolutionWaitTime: 2 * time.Second,
+		maxResolutionGas:          1_000_000,
+		roundTimingInfo: RoundTimingInfo{
+			Offset:            offset,
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+	}
+	require.NoError(t, a.preflightWithClient(ctx, newClient(60, 2, 1)))
+
+	// Every check is reported, whether it passed or not.
+	client := newClient(120, 1, 2)
+	client.balance = big.NewInt(1_999_999)
+	err = a.preflightWithClient(ctx, client)
+	var preflightErr *PreflightError
+	require.True(t, errors.As(err, &preflightErr))
+	require.ErrorIs(t, err, errSignerOutOfFunds)
+	require.Len(t, preflightErr.Checks, 4)
+	failed := make(map[string]bool)
+	for _, check := range preflightErr.Checks {
+		failed[check.Name] = check.Err != nil
+	}
+	require.Equal(t, map[string]bool{
+		PreflightAuctionContract: false,
+		PreflightRoundTiming:     true,
+		PreflightSignerFunds:     true,
+		PreflightReservePrice:    true,
+	}, failed)
+	require.Contains(t, err.Error(), PreflightAuctionContract+": ok")
+	require.Equal(t, a.EffectiveConfig(), preflightErr.Config)
+
+	// The contract being unreachable fails every check relying on it.
+	client = newClient(60, 2, 1)
+	client.callErr = errors.New("unreachable")
+	err = a.preflightWithClient(ctx, client)
+	require.ErrorContains(t, err, "auction contract 0x0000000000000000000000000000000000001234 unreachable")
+}
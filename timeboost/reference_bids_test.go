@@ -0,0 +1,98 @@
+package timeboost
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// referenceBids are bids signed by testdata/reference_signer.py, which
+// implements the signature scheme of bids independently of go-ethereum.
+type referenceBids struct {
+	ChainId                *hexutil.Big   `json:"chainId"`
+	AuctionContractAddress common.Address `json:"auctionContractAddress"`
+	Bidder                 common.Address `json:"bidder"`
+	Round                  hexutil.Uint64 `json:"round"`
+	ReservePrice           *hexutil.Big   `json:"reservePrice"`
+	Bids                   []struct {
+		Name                   string         `json:"name"`
+		ChainId                *hexutil.Big   `json:"chainId"`
+		AuctionContractAddress common.Address `json:"auctionContractAddress"`
+		ExpressLaneController  common.Address `json:"expressLaneController"`
+		Round                  hexutil.Uint64 `json:"round"`
+		Amount                 *hexutil.Big   `json:"amount"`
+		Signature              hexutil.Bytes  `json:"signature"`
+		// ExpectedError is the code of the rejection, empty for valid bids.
+		ExpectedError string `json:"expectedError"`
+	} `json:"bids"`
+}
+
+func TestReferenceSignerBids(t *testing.T) {
+	t.Parallel()
+	data, err := os.ReadFile(filepath.Join("testdata", "reference_bids.json"))
+	require.NoError(t, err)
+	var fixtures referenceBids
+	require.NoError(t, json.Unmarshal(data, &fixtures))
+	require.NotEmpty(t, fixtures.Bids)
+
+	chainId := fixtures.ChainId.ToInt()
+	bv := &BidValidator{
+		chainId: chainId,
+		roundTimingInfo: RoundTimingInfo{
+			// Bidding on the round of the fixtures is open.
+			Offset:         time.Now().Add(-time.Duration(fixtures.Round-1)*time.Minute - time.Second),
+			Round:          time.Minute,
+			AuctionClosing: 15 * time.Second,
+		},
+		reservePrice:                   fixtures.ReservePrice.ToInt(),
+		bidsPerSenderInRound:           make(map[common.Address]uint8),
+		maxBidsPerSenderInRound:        5,
+		auctionContractAddr:            fixtures.AuctionContractAddress,
+		auctionContractDomainSeparator: ComputeDomainSeparator(chainId, fixtures.AuctionContractAddress),
+	}
+	require.Equal(t, uint64(fixtures.Round), bv.upcomingRound())
+	// Only the bidder of the fixtures has a deposit, so that bids whose
+	// signature recovers to anyone else are rejected.
+	balanceCheckerFn := func(_ *bind.CallOpts, account common.Address) (*big.Int, error) {
+		if account == fixtures.Bidder {
+			return new(big.Int).Lsh(big.NewInt(1), 100), nil
+		}
+		return new(big.Int), nil
+	}
+
+	var valid, rejected int
+	for _, fixture := range fixtures.Bids {
+		bid := &Bid{
+			ChainId:                fixture.ChainId.ToInt(),
+			ExpressLaneController:  fixture.ExpressLaneController,
+			AuctionContractAddress: fixture.AuctionContractAddress,
+			Round:                  uint64(fixture.Round),
+			Amount:                 fixture.Amount.ToInt(),
+			Signature:              fixture.Signature,
+		}
+		validated, err := bv.checkBid(bid, balanceCheckerFn, false)
+		if fixture.ExpectedError == "" {
+			valid++
+			require.NoError(t, err, fixture.Name)
+			require.Equal(t, fixtures.Bidder, validated.Bidder, fixture.Name)
+			require.Equal(t, bid.Amount, validated.Amount.ToInt(), fixture.Name)
+			continue
+		}
+		rejected++
+		require.Error(t, err, fixture.Name)
+		reason, ok := BidRejectionReason(err)
+		require.True(t, ok, "%s: %v", fixture.Name, err)
+		require.Equal(t, fixture.ExpectedError, reason, "%s: %v", fixture.Name, err)
+	}
+	require.NotZero(t, valid)
+	require.NotZero(t, rejected)
+}
@@ -0,0 +1,72 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"crypto/rand"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// bidCheckDetails is what checking a bid found out about it before the bid
+// was accepted or rejected. Fields are left empty if the check didn't get as
+// far as reading them.
+type bidCheckDetails struct {
+	expectedRound  uint64
+	reservePrice   *big.Int
+	signer         *common.Address
+	depositBalance *big.Int
+}
+
+// newRejectionId returns a random id to correlate the rejection of a bid
+// returned to its submitter with the validator's logs.
+func newRejectionId() string {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return ""
+	}
+	return hexutil.Encode(id)
+}
+
+// logRejectedBid logs everything known about a rejected bid at debug level,
+// if logging rejected bids is enabled, and returns the rejection wrapped with
+// the id it was logged under. The bid itself is never logged above debug
+// level, and the rejection is returned unchanged if logging is disabled.
+func (bv *BidValidator) logRejectedBid(bid *JsonBid, details *bidCheckDetails, rejection error) error {
+	if !bv.logRejectedBids || rejection == nil {
+		return rejection
+	}
+	id := newRejectionId()
+	reason, _ := BidRejectionReason(rejection)
+	ctx := []any{
+		"rejectionId", id,
+		"reason", reason,
+		"correlationId", bid.CorrelationId,
+		"controller", bid.ExpressLaneController.Hex(),
+		"declaredRound", uint64(bid.Round),
+		"expectedRound", details.expectedRound,
+		"amount", bid.Amount.ToInt(),
+		"amountDecimal", bid.AmountDecimal,
+		"reservePrice", details.reservePrice,
+		"chainId", bid.ChainId.ToInt(),
+		"auctionContract", bid.AuctionContractAddress.Hex(),
+		"err", rejection,
+	}
+	if details.signer != nil {
+		ctx = append(ctx, "signer", details.signer.Hex())
+	}
+	if details.depositBalance != nil {
+		ctx = append(ctx, "depositBalance", details.depositBalance)
+	}
+	log.Debug("Rejected bid", ctx...)
+	if id == "" {
+		return rejection
+	}
+	return errors.Wrapf(rejection, "rejection %s", id)
+}
@@ -0,0 +1,64 @@
+package timeboost
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestBidValidator_logRejectedBids(t *testing.T) {
+	t.Parallel()
+	balanceCheckerFn := func(_ *bind.CallOpts, _ common.Address) (*big.Int, error) {
+		return big.NewInt(1), nil
+	}
+	auctionContractAddr := common.Address{'a'}
+	bv := &BidValidator{
+		chainId: big.NewInt(1),
+		roundTimingInfo: RoundTimingInfo{
+			Offset:         time.Now().Add(-time.Second),
+			Round:          time.Minute,
+			AuctionClosing: 45 * time.Second,
+		},
+		reservePrice:            big.NewInt(2),
+		bidsPerSenderInRound:    make(map[common.Address]uint8),
+		maxBidsPerSenderInRound: 5,
+		auctionContractAddr:     auctionContractAddr,
+	}
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	bid, err := NewSignedBid(privateKey, 1, big.NewInt(3), common.Address{'b'}, big.NewInt(1), auctionContractAddr)
+	require.NoError(t, err)
+
+	// Checking the bid records everything it found out before rejecting it.
+	details := &bidCheckDetails{}
+	_, rejection := bv.checkBidWithDetails(bid, balanceCheckerFn, true, details)
+	require.ErrorIs(t, rejection, ErrInsufficientBalance)
+	require.Equal(t, uint64(1), details.expectedRound)
+	require.Equal(t, big.NewInt(2), details.reservePrice)
+	require.NotNil(t, details.signer)
+	require.Equal(t, big.NewInt(1), details.depositBalance)
+
+	// Disabled, the rejection is returned as is.
+	require.Equal(t, rejection, bv.logRejectedBid(bid.ToJson(), details, rejection))
+
+	// Enabled, the rejection carries the id it was logged under.
+	bv.logRejectedBids = true
+	err = bv.logRejectedBid(bid.ToJson(), details, rejection)
+	require.ErrorIs(t, err, ErrInsufficientBalance)
+	require.True(t, strings.HasPrefix(err.Error(), "rejection 0x"))
+	reason, ok := BidRejectionReason(err)
+	require.True(t, ok)
+	require.Equal(t, ErrInsufficientBalance.Error(), reason)
+	require.NotEqual(t, err.Error(), bv.logRejectedBid(bid.ToJson(), details, rejection).Error())
+
+	// Bids rejected before the round is checked are logged with what is known.
+	_, rejection = bv.checkBidWithDetails(&Bid{}, balanceCheckerFn, true, &bidCheckDetails{})
+	require.ErrorIs(t, bv.logRejectedBid((&Bid{}).ToJson(), &bidCheckDetails{}, rejection), ErrMalformedData)
+}
@@ -0,0 +1,65 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// SetRoundReserveOverride sets a one-off reserve price for the given round,
+// e.g. one of anticipated high demand, without changing the auction contract.
+// As with a reserve price oracle, the contract still enforces its own reserve
+// price, so an override can only raise the reserve: bids for the round are
+// validated against the higher of the two. A nil price removes the override.
+// Overrides are forgotten once the auction of their round closes.
+func (bv *BidValidator) SetRoundReserveOverride(round uint64, price *big.Int) error {
+	if price != nil && price.Sign() <= 0 {
+		return fmt.Errorf("reserve override must be positive, got %s", price.String())
+	}
+	if upcomingRound := bv.upcomingRound(); round < upcomingRound {
+		return fmt.Errorf("can't override the reserve price of round %d, bidding is on round %d", round, upcomingRound)
+	}
+	bv.reservePriceLock.Lock()
+	defer bv.reservePriceLock.Unlock()
+	if price == nil {
+		delete(bv.reserveOverrides, round)
+		return nil
+	}
+	if bv.reserveOverrides == nil {
+		bv.reserveOverrides = make(map[uint64]*big.Int)
+	}
+	bv.reserveOverrides[round] = new(big.Int).Set(price)
+	log.Info("Reserve price overridden", "round", round, "reservePrice", price.String())
+	return nil
+}
+
+// reservePriceOfRound returns the reserve price bids for the given round are
+// validated against, which is the highest of the standard reserve price, the
+// operator's reserve price and the override of the round.
+func (bv *BidValidator) reservePriceOfRound(round uint64) *big.Int {
+	bv.reservePriceLock.RLock()
+	defer bv.reservePriceLock.RUnlock()
+	reservePrice := bv.reservePrice
+	for _, raised := range []*big.Int{bv.operatorReservePrice, bv.reserveOverrides[round]} {
+		if raised != nil && raised.Cmp(reservePrice) > 0 {
+			reservePrice = raised
+		}
+	}
+	return reservePrice
+}
+
+// clearReserveOverrides forgets the overrides of every round up to the given
+// one, whose auctions are closed.
+func (bv *BidValidator) clearReserveOverrides(closedRound uint64) {
+	bv.reservePriceLock.Lock()
+	defer bv.reservePriceLock.Unlock()
+	for round := range bv.reserveOverrides {
+		if round <= closedRound {
+			delete(bv.reserveOverrides, round)
+		}
+	}
+}
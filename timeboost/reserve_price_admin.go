@@ -0,0 +1,63 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// setOperatorReservePrice sets a reserve price enforced by the validator from
+// now on, until it is unset with a nil price, e.g. to stop bids at a price the
+// auction contract's reserve price can't be raised to in time. As with an
+// override, the contract still enforces its own reserve price, so bids are
+// validated against the higher of the two. It may not be below the min
+// reserve price. Bids accepted before the change remain eligible, see
+// meetsReservePrice.
+func (bv *BidValidator) setOperatorReservePrice(price *big.Int, caller rpc.PeerInfo) error {
+	if price != nil {
+		if price.Sign() <= 0 {
+			return fmt.Errorf("reserve price must be positive, got %s", price.String())
+		}
+		if minReservePrice := bv.MinReservePrice(); minReservePrice != nil && price.Cmp(minReservePrice) < 0 {
+			return fmt.Errorf("reserve price %s is below min reserve price %s", price.String(), minReservePrice.String())
+		}
+		price = new(big.Int).Set(price)
+	}
+	bv.reservePriceLock.Lock()
+	previous := bv.operatorReservePrice
+	bv.operatorReservePrice = price
+	bv.reservePriceLock.Unlock()
+	log.Warn("Operator reserve price changed", "old", previous, "new", price, "transport", caller.Transport, "remoteAddr", caller.RemoteAddr, "userAgent", caller.HTTP.UserAgent)
+	return nil
+}
+
+// BidValidatorAdminAPI is served alongside the auctioneer's admin API, over the
+// authenticated RPC endpoint of the bid validator.
+type BidValidatorAdminAPI struct {
+	bidValidator *BidValidator
+}
+
+// SetReservePrice is auctioneeradmin_setReservePrice, see
+// setOperatorReservePrice. A null price unsets the operator's reserve price.
+func (api *BidValidatorAdminAPI) SetReservePrice(ctx context.Context, price *hexutil.Big) error {
+	return api.bidValidator.setOperatorReservePrice(price.ToInt(), rpc.PeerInfoFromContext(ctx))
+}
+
+// DomainSeparator is auctioneer_domainSeparator, see BidValidator.DomainSeparator.
+func (api *BidValidatorAPI) DomainSeparator() common.Hash {
+	return api.bidValidator.DomainSeparator()
+}
+
+// ReservePrice is auctioneer_reservePrice, the reserve price bids for the
+// upcoming round are currently validated against.
+func (api *BidValidatorAPI) ReservePrice() *big.Int {
+	return api.bidValidator.reservePriceOfRound(api.bidValidator.upcomingRound())
+}
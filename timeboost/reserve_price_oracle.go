@@ -0,0 +1,52 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ReservePriceOracle computes an off-chain reserve price for a round, e.g.
+// from recent gas prices or bidding demand.
+type ReservePriceOracle interface {
+	ReservePrice(ctx context.Context, round uint64) (*big.Int, error)
+}
+
+// BidValidatorOpt configures optional behavior of a BidValidator that cannot
+// be expressed through its config.
+type BidValidatorOpt func(*BidValidator)
+
+// WithReservePriceOracle has bids validated against the reserve price computed
+// by the oracle instead of only the one set on the auction contract. The
+// contract still rejects resolutions with bids below its own reserve price,
+// so the oracle can only raise the reserve: whenever it returns less than the
+// contract's reserve price, or fails, the contract's reserve price is used.
+// The oracle is consulted once per round, whenever the contract's reserve
+// price for the upcoming round is read.
+func WithReservePriceOracle(oracle ReservePriceOracle) BidValidatorOpt {
+	return func(bv *BidValidator) {
+		bv.reservePriceOracle = oracle
+	}
+}
+
+// applyReservePriceOracle returns the reserve price to validate bids for the
+// upcoming round against, given the contract's reserve price for it.
+func (bv *BidValidator) applyReservePriceOracle(ctx context.Context, onChainReservePrice *big.Int) *big.Int {
+	if bv.reservePriceOracle == nil {
+		return onChainReservePrice
+	}
+	round := bv.roundTimingInfo.RoundNumber() + 1
+	reservePrice, err := bv.reservePriceOracle.ReservePrice(ctx, round)
+	if err != nil {
+		log.Warn("Reserve price oracle failed, using on-chain reserve price", "round", round, "onChainReservePrice", onChainReservePrice.String(), "err", err)
+		return onChainReservePrice
+	}
+	if reservePrice == nil || reservePrice.Cmp(onChainReservePrice) <= 0 {
+		return onChainReservePrice
+	}
+	return reservePrice
+}
@@ -0,0 +1,58 @@
+package timeboost
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fixedReservePriceOracle struct {
+	price  *big.Int
+	err    error
+	rounds []uint64
+}
+
+func (o *fixedReservePriceOracle) ReservePrice(ctx context.Context, round uint64) (*big.Int, error) {
+	o.rounds = append(o.rounds, round)
+	return o.price, o.err
+}
+
+func TestApplyReservePriceOracle(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	onChain := big.NewInt(100)
+	tests := []struct {
+		name     string
+		oracle   *fixedReservePriceOracle
+		expected *big.Int
+	}{
+		{name: "no oracle", expected: onChain},
+		{name: "oracle above on-chain", oracle: &fixedReservePriceOracle{price: big.NewInt(150)}, expected: big.NewInt(150)},
+		{name: "oracle below on-chain", oracle: &fixedReservePriceOracle{price: big.NewInt(50)}, expected: onChain},
+		{name: "oracle without price", oracle: &fixedReservePriceOracle{}, expected: onChain},
+		{name: "oracle failure", oracle: &fixedReservePriceOracle{price: big.NewInt(150), err: errors.New("oracle down")}, expected: onChain},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bv := &BidValidator{
+				roundTimingInfo: RoundTimingInfo{
+					Offset:         time.Now().Add(-90 * time.Second),
+					Round:          time.Minute,
+					AuctionClosing: 15 * time.Second,
+				},
+			}
+			if tt.oracle != nil {
+				WithReservePriceOracle(tt.oracle)(bv)
+			}
+			require.Equal(t, tt.expected, bv.applyReservePriceOracle(ctx, onChain))
+			if tt.oracle != nil {
+				// The oracle prices the round being auctioned.
+				require.Equal(t, []uint64{2}, tt.oracle.rounds)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var errAccessListOnLegacyTx = errors.New("resolution access lists need access list or dynamic fee transactions, not legacy ones")
+
+// ResolutionAccessList computes the access list attached to the resolution of
+// a round from the bids it is resolved with, second being nil for single bid
+// resolutions. An empty access list leaves the resolution without one.
+type ResolutionAccessList func(round uint64, first, second *ValidatedBid) (types.AccessList, error)
+
+// WithResolutionAccessList attaches an access list to resolution transactions,
+// which pre-warms the accounts and storage slots the resolution touches, e.g.
+// the deposits of the bidders, for a lower gas cost. By default resolutions
+// have no access list.
+func WithResolutionAccessList(accessList ResolutionAccessList) AuctioneerServerOpt {
+	return func(a *AuctioneerServer) {
+		a.resolutionAccessList = accessList
+	}
+}
+
+// WithStaticResolutionAccessList attaches the same access list to every
+// resolution transaction, see WithResolutionAccessList.
+func WithStaticResolutionAccessList(accessList types.AccessList) AuctioneerServerOpt {
+	return WithResolutionAccessList(func(uint64, *ValidatedBid, *ValidatedBid) (types.AccessList, error) {
+		return accessList, nil
+	})
+}
+
+// accessListGas is the intrinsic gas an access list adds to a transaction.
+func accessListGas(accessList types.AccessList) uint64 {
+	return uint64(len(accessList))*params.TxAccessListAddressGas + uint64(accessList.StorageKeys())*params.TxAccessListStorageKeyGas
+}
+
+// attachResolutionAccessList rebuilds the resolution transaction built by the
+// bindings, which can't attach an access list themselves, with the access list
+// of the round, and signs it again. Legacy priced transactions become access
+// list transactions, which keep their gas price. The intrinsic gas of the
+// access list is added to an estimated gas limit, as that was estimated without
+// the access list, but not to one fixed by the signer.
+func (a *AuctioneerServer) attachResolutionAccessList(signer *bind.TransactOpts, round uint64, first, second *ValidatedBid, tx *types.Transaction) (*types.Transaction, error) {
+	if a.resolutionAccessList == nil {
+		return tx, nil
+	}
+	accessList, err := a.resolutionAccessList(round, first, second)
+	if err != nil {
+		return nil, fmt.Errorf("computing resolution access list of round %d: %w", round, err)
+	}
+	if len(accessList) == 0 {
+		return tx, nil
+	}
+	gas := tx.Gas()
+	if signer.GasLimit == 0 {
+		gas += accessListGas(accessList)
+	}
+	var inner types.TxData
+	switch tx.Type() {
+	case types.LegacyTxType, types.AccessListTxType:
+		inner = &types.AccessListTx{
+			ChainID:    a.chainId,
+			Nonce:      tx.Nonce(),
+			GasPrice:   tx.GasPrice(),
+			Gas:        gas,
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: accessList,
+		}
+	case types.DynamicFeeTxType:
+		inner = &types.DynamicFeeTx{
+			ChainID:    a.chainId,
+			Nonce:      tx.Nonce(),
+			GasTipCap:  tx.GasTipCap(),
+			GasFeeCap:  tx.GasFeeCap(),
+			Gas:        gas,
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: accessList,
+		}
+	default:
+		return nil, fmt.Errorf("can't attach an access list to resolution transaction of type %d", tx.Type())
+	}
+	withAccessList, err := signer.Signer(signer.From, types.NewTx(inner))
+	if err != nil {
+		return nil, fmt.Errorf("signing auction resolution with access list: %w", err)
+	}
+	return withAccessList, nil
+}
@@ -0,0 +1,150 @@
+package timeboost
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestResolutionAccessList(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	chainId := big.NewInt(1)
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+	require.NoError(t, err)
+	txOpts.GasLimit = 1_000_000
+	auctionContractAddr := common.HexToAddress("0x1234")
+	first, second := common.HexToAddress("0x1"), common.HexToAddress("0x2")
+	newAuctioneer := func(opts ...AuctioneerServerOpt) *AuctioneerServer {
+		a := &AuctioneerServer{
+			txOpts:              txOpts,
+			chainId:             chainId,
+			auctionContractAddr: auctionContractAddr,
+			bidCache:            newBidCache([32]byte{}),
+			roundTimingInfo: RoundTimingInfo{
+				Offset:            time.Now(),
+				Round:             time.Minute,
+				AuctionClosing:    15 * time.Second,
+				ReserveSubmission: 15 * time.Second,
+			},
+		}
+		for _, opt := range opts {
+			opt(a)
+		}
+		for i, bidder := range []common.Address{first, second} {
+			a.bidCache.add(&ValidatedBid{
+				ChainId:               chainId,
+				Bidder:                bidder,
+				ExpressLaneController: bidder,
+				Round:                 1,
+				Amount:                big.NewInt(int64(20 - 10*i)),
+				Signature:             make([]byte, 65),
+			})
+		}
+		return a
+	}
+	// Pre-warms the deposits of both bidders.
+	bidderAccessList := func(_ uint64, first, second *ValidatedBid) (types.AccessList, error) {
+		return types.AccessList{{
+			Address:     auctionContractAddr,
+			StorageKeys: []common.Hash{common.BytesToHash(first.Bidder.Bytes()), common.BytesToHash(second.Bidder.Bytes())},
+		}}, nil
+	}
+	wantAccessList, err := bidderAccessList(1, &ValidatedBid{Bidder: first}, &ValidatedBid{Bidder: second})
+	require.NoError(t, err)
+	requireSignedBy := func(t *testing.T, tx *types.Transaction) {
+		t.Helper()
+		sender, err := types.Sender(types.LatestSignerForChainID(chainId), tx)
+		require.NoError(t, err)
+		require.Equal(t, txOpts.From, sender)
+	}
+
+	t.Run("DynamicFee", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer(WithResolutionAccessList(bidderAccessList))
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1)}
+		_, err := a.resolveAuctionWithClient(ctx, client, true)
+		require.NoError(t, err)
+		require.Len(t, client.submitted, 1)
+		tx := client.submitted[0]
+		require.Equal(t, uint8(types.DynamicFeeTxType), tx.Type())
+		require.Equal(t, wantAccessList, tx.AccessList())
+		// The gas limit fixed by the signer is kept.
+		require.Equal(t, txOpts.GasLimit, tx.Gas())
+		requireSignedBy(t, tx)
+	})
+
+	t.Run("LegacyPriced", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer(WithStaticResolutionAccessList(wantAccessList))
+		// Without a base fee, resolutions are legacy priced.
+		client := &fakeAuctioneerClient{}
+		_, err := a.resolveAuctionWithClient(ctx, client, true)
+		require.NoError(t, err)
+		require.Len(t, client.submitted, 1)
+		tx := client.submitted[0]
+		require.Equal(t, uint8(types.AccessListTxType), tx.Type())
+		require.Equal(t, wantAccessList, tx.AccessList())
+		require.Equal(t, big.NewInt(2), tx.GasPrice())
+		requireSignedBy(t, tx)
+
+		// Repricing keeps the kind of transaction and its access list.
+		repriced, err := a.repriceResolution(txOpts, tx)
+		require.NoError(t, err)
+		require.Equal(t, uint8(types.AccessListTxType), repriced.Type())
+		require.Equal(t, wantAccessList, repriced.AccessList())
+		require.Equal(t, big.NewInt(4), repriced.GasPrice())
+	})
+
+	t.Run("None", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer(WithStaticResolutionAccessList(nil))
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1)}
+		_, err := a.resolveAuctionWithClient(ctx, client, true)
+		require.NoError(t, err)
+		require.Len(t, client.submitted, 1)
+		require.Empty(t, client.submitted[0].AccessList())
+	})
+
+	t.Run("Failing", func(t *testing.T) {
+		t.Parallel()
+		failure := errors.New("no access list")
+		a := newAuctioneer(WithResolutionAccessList(func(uint64, *ValidatedBid, *ValidatedBid) (types.AccessList, error) {
+			return nil, failure
+		}))
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1)}
+		_, err := a.resolveAuctionWithClient(ctx, client, true)
+		require.ErrorIs(t, err, failure)
+		require.Empty(t, client.submitted)
+	})
+
+	t.Run("EstimatedGas", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer(WithStaticResolutionAccessList(wantAccessList))
+		estimated := *txOpts
+		estimated.GasLimit = 0
+		tx, err := estimated.Signer(estimated.From, types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainId,
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: big.NewInt(2),
+			Gas:       100_000,
+			To:        &auctionContractAddr,
+		}))
+		require.NoError(t, err)
+		withAccessList, err := a.attachResolutionAccessList(&estimated, 1, nil, nil, tx)
+		require.NoError(t, err)
+		// One address and two storage keys.
+		require.Equal(t, uint64(100_000+2400+2*1900), withAccessList.Gas())
+	})
+}
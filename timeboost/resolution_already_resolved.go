@@ -0,0 +1,34 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// roundAlreadyResolvedError is the auction contract error resolutions of a
+// round that was already resolved revert with.
+const roundAlreadyResolvedError = "RoundAlreadyResolved"
+
+var errRoundResolvedOnChain = errors.New("round already resolved on the auction contract")
+
+// isRoundAlreadyResolvedError reports whether err is a resolution reverting
+// because its round was already resolved, decoded from the revert data if the
+// RPC relays it, or else as relayed in the error message.
+func isRoundAlreadyResolvedError(err error) bool {
+	return err != nil && (revertReason(err) == roundAlreadyResolvedError || strings.Contains(err.Error(), roundAlreadyResolvedError))
+}
+
+// resolvedElsewhere marks a round resolved whose resolution reverted because it
+// was already resolved, most likely by a redundant auctioneer instance racing
+// this one. That is as good as resolving it, so it isn't retried, nor counted
+// against the winner. Who won is not known to this instance.
+func (a *AuctioneerServer) resolvedElsewhere(round uint64, err error) *ResolutionResult {
+	log.Info("Round already resolved, likely by a redundant auctioneer, not retrying", "round", round, "err", err)
+	a.setRoundStatus(round, RoundStatusResolved)
+	return newResolutionResult(round, RoundStatusResolved, nil)
+}
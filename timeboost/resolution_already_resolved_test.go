@@ -0,0 +1,109 @@
+package timeboost
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestRoundAlreadyResolved(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	chainId := big.NewInt(1)
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+	require.NoError(t, err)
+	txOpts.GasLimit = 1_000_000
+	winner := common.HexToAddress("0x1")
+	newAuctioneer := func() *AuctioneerServer {
+		a := &AuctioneerServer{
+			txOpts:   txOpts,
+			chainId:  chainId,
+			bidCache: newBidCache([32]byte{}),
+			roundTimingInfo: RoundTimingInfo{
+				// Bidding on round 1 is closed.
+				Offset:            time.Now().Add(-50 * time.Second),
+				Round:             time.Minute,
+				AuctionClosing:    15 * time.Second,
+				ReserveSubmission: 15 * time.Second,
+			},
+			bidderBlacklist: newBidderBlacklist(1, time.Hour),
+		}
+		a.bidCache.add(&ValidatedBid{
+			ChainId:               chainId,
+			Bidder:                winner,
+			ExpressLaneController: winner,
+			Round:                 1,
+			Amount:                big.NewInt(10),
+			Signature:             make([]byte, 65),
+		})
+		return a
+	}
+	// As the auction contract reverts resolutions of a resolved round.
+	alreadyResolved := &revertDataError{data: hexutil.Encode(append(
+		crypto.Keccak256([]byte("RoundAlreadyResolved(uint64)"))[:4],
+		common.BigToHash(big.NewInt(1)).Bytes()...,
+	))}
+	resolve := func(t *testing.T, a *AuctioneerServer, client *fakeAuctioneerClient) *ResolutionResult {
+		t.Helper()
+		var result *ResolutionResult
+		require.NoError(t, a.resolveUpcomingRound(ctx, func(ctx context.Context) error {
+			var err error
+			result, err = a.resolveAuctionWithClient(ctx, client, true)
+			return err
+		}))
+		_, state := a.RoundState()
+		require.Equal(t, RoundStatusResolved, state)
+		status, ok := a.RoundOutcome(1)
+		require.True(t, ok)
+		require.Equal(t, RoundStatusResolved, status)
+		// The winner isn't held responsible for the race.
+		require.False(t, a.bidderBlacklist.blocked(winner, time.Now()))
+		return result
+	}
+
+	t.Run("Simulated", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer()
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1), simulate: func([]byte) error { return alreadyResolved }}
+		result := resolve(t, a, client)
+		require.Equal(t, RoundStatusResolved, result.Outcome)
+		require.Nil(t, result.FirstPlace)
+		require.Empty(t, client.submitted)
+	})
+
+	t.Run("Mined", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer()
+		// Resolved by another instance between simulating and mining.
+		simulated := false
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1), revertReceipts: true, simulate: func([]byte) error {
+			if !simulated {
+				simulated = true
+				return nil
+			}
+			return alreadyResolved
+		}}
+		result := resolve(t, a, client)
+		require.Equal(t, RoundStatusResolved, result.Outcome)
+		require.Len(t, client.submitted, 1)
+	})
+
+	t.Run("RelayedAsMessage", func(t *testing.T) {
+		t.Parallel()
+		require.True(t, isRoundAlreadyResolvedError(alreadyResolved))
+		require.True(t, isRoundAlreadyResolvedError(errors.New("execution reverted: RoundAlreadyResolved(1)")))
+		require.False(t, isRoundAlreadyResolvedError(revertWithReason(t, "insufficient balance")))
+		require.False(t, isRoundAlreadyResolvedError(nil))
+	})
+}
@@ -0,0 +1,27 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"errors"
+	"fmt"
+)
+
+var errBidsWrongOrder = errors.New("auction resolution bids in wrong order")
+
+// checkMultiBidOrder makes sure the winning and price-setting bids are ranked
+// the way the contract expects, so that a ranking bug fails before anything is
+// sent rather than by reverting on chain. resolveMultiBidAuction takes the
+// winning bid first, and reverts unless its amount is at least that of the
+// price-setting bid, or, for bids of the same amount, its BigIntHash is the
+// larger.
+func checkMultiBidOrder(first, second *ValidatedBid, domainSeparator [32]byte) error {
+	switch c := first.Amount.Cmp(second.Amount); {
+	case c < 0:
+		return fmt.Errorf("%w: first bid %s is below second bid %s", errBidsWrongOrder, first.Amount.String(), second.Amount.String())
+	case c == 0 && first.BigIntHash(domainSeparator).Cmp(second.BigIntHash(domainSeparator)) < 0:
+		return fmt.Errorf("%w: tied bids of %s, first bid hashes lower than second bid", errBidsWrongOrder, first.Amount.String())
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package timeboost
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/offchainlabs/nitro/solgen/go/express_lane_auctiongen"
+)
+
+func TestCheckMultiBidOrder(t *testing.T) {
+	t.Parallel()
+	var domainSeparator [32]byte
+	bid := func(bidder string, amount int64) *ValidatedBid {
+		return &ValidatedBid{
+			Bidder:                common.HexToAddress(bidder),
+			ExpressLaneController: common.HexToAddress(bidder),
+			Round:                 1,
+			Amount:                big.NewInt(amount),
+		}
+	}
+	require.NoError(t, checkMultiBidOrder(bid("0x1", 20), bid("0x2", 10), domainSeparator))
+	require.ErrorIs(t, checkMultiBidOrder(bid("0x2", 10), bid("0x1", 20), domainSeparator), errBidsWrongOrder)
+
+	// Ties are broken by the larger hash, as by the contract.
+	high, low := bid("0x1", 10), bid("0x2", 10)
+	if high.BigIntHash(domainSeparator).Cmp(low.BigIntHash(domainSeparator)) < 0 {
+		high, low = low, high
+	}
+	require.NoError(t, checkMultiBidOrder(high, low, domainSeparator))
+	require.ErrorIs(t, checkMultiBidOrder(low, high, domainSeparator), errBidsWrongOrder)
+}
+
+func TestResolveMultiBidAuctionArgumentOrder(t *testing.T) {
+	t.Parallel()
+	chainId := big.NewInt(1)
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+	require.NoError(t, err)
+	txOpts.GasLimit = 1_000_000
+	a := &AuctioneerServer{
+		txOpts:                 txOpts,
+		chainId:                chainId,
+		bidCache:               newBidCache([32]byte{}),
+		auctionContractVersion: AuctionContractV1,
+		roundTimingInfo: RoundTimingInfo{
+			Offset:            time.Now(),
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+	}
+	// Cached lowest first, ranked highest first.
+	for i, amount := range []int64{5, 30, 20} {
+		bidder := common.BigToAddress(big.NewInt(int64(i + 1)))
+		a.bidCache.add(&ValidatedBid{
+			ChainId:               chainId,
+			Bidder:                bidder,
+			ExpressLaneController: bidder,
+			Round:                 1,
+			Amount:                big.NewInt(amount),
+			Signature:             make([]byte, 65),
+		})
+	}
+	client := &fakeAuctioneerClient{baseFee: big.NewInt(1)}
+	_, err = a.resolveAuctionWithClient(context.Background(), client, true)
+	require.NoError(t, err)
+	require.Len(t, client.submitted, 1)
+
+	auctionAbi, err := express_lane_auctiongen.ExpressLaneAuctionMetaData.GetAbi()
+	require.NoError(t, err)
+	method := auctionAbi.Methods["resolveMultiBidAuction"]
+	data := client.submitted[0].Data()
+	require.Equal(t, method.ID, data[:4])
+	args, err := method.Inputs.Unpack(data[4:])
+	require.NoError(t, err)
+	require.Len(t, args, 2)
+	firstPriceBid := *abi.ConvertType(args[0], new(express_lane_auctiongen.Bid)).(*express_lane_auctiongen.Bid)
+	secondPriceBid := *abi.ConvertType(args[1], new(express_lane_auctiongen.Bid)).(*express_lane_auctiongen.Bid)
+	require.Equal(t, big.NewInt(30), firstPriceBid.Amount)
+	require.Equal(t, big.NewInt(20), secondPriceBid.Amount)
+	require.GreaterOrEqual(t, firstPriceBid.Amount.Cmp(secondPriceBid.Amount), 0)
+}
@@ -0,0 +1,77 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/offchainlabs/nitro/solgen/go/express_lane_auctiongen"
+)
+
+var errResolutionCalldataMismatch = errors.New("auction resolution calldata does not match the bids it resolves with")
+
+// checkResolutionCalldata decodes the bids from the calldata of a resolution,
+// and checks they are the given ones, in the given order, a single bid being
+// resolved with resolveSingleBidAuction and two with resolveMultiBidAuction.
+func checkResolutionCalldata(data []byte, bids ...*ValidatedBid) error {
+	contractAbi, err := express_lane_auctiongen.ExpressLaneAuctionMetaData.GetAbi()
+	if err != nil {
+		return err
+	}
+	if len(data) < 4 {
+		return fmt.Errorf("%w: calldata too short", errResolutionCalldataMismatch)
+	}
+	method, err := contractAbi.MethodById(data[:4])
+	if err != nil {
+		return fmt.Errorf("%w: %w", errResolutionCalldataMismatch, err)
+	}
+	expectedMethod := "resolveMultiBidAuction"
+	if len(bids) == 1 {
+		expectedMethod = "resolveSingleBidAuction"
+	}
+	if method.Name != expectedMethod {
+		return fmt.Errorf("%w: calls %s, expected %s", errResolutionCalldataMismatch, method.Name, expectedMethod)
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return fmt.Errorf("%w: %w", errResolutionCalldataMismatch, err)
+	}
+	if len(args) != len(bids) {
+		return fmt.Errorf("%w: %d bids encoded, expected %d", errResolutionCalldataMismatch, len(args), len(bids))
+	}
+	for i, arg := range args {
+		encoded := abi.ConvertType(arg, new(express_lane_auctiongen.Bid)).(*express_lane_auctiongen.Bid)
+		bid := bids[i]
+		if encoded.ExpressLaneController != bid.ExpressLaneController ||
+			encoded.Amount == nil || encoded.Amount.Cmp(bid.Amount) != 0 ||
+			!bytes.Equal(encoded.Signature, bid.Signature) {
+			return fmt.Errorf("%w: bid %d encoded with controller %s and amount %s, expected controller %s and amount %s",
+				errResolutionCalldataMismatch, i, encoded.ExpressLaneController.Hex(), encoded.Amount, bid.ExpressLaneController.Hex(), bid.Amount)
+		}
+	}
+	return nil
+}
+
+// checkResolutionTx makes sure the resolution transaction about to be sent
+// resolves the round with the bids it was built from, in the order the auction
+// contract takes them. A mismatch can only be an encoding or signing bug, which
+// must not reach the chain, as it would hand the express lane to the wrong
+// controller or at the wrong price.
+func (a *AuctioneerServer) checkResolutionTx(round uint64, tx *types.Transaction, first, second *ValidatedBid) error {
+	bids := []*ValidatedBid{first}
+	if second != nil {
+		bids = append(bids, second)
+	}
+	if err := checkResolutionCalldata(tx.Data(), bids...); err != nil {
+		log.Root().Write(log.LevelCrit, "Not sending auction resolution whose calldata does not match its bids", "round", round, "txHash", tx.Hash().Hex(), "err", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package timeboost
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/offchainlabs/nitro/solgen/go/express_lane_auctiongen"
+)
+
+func TestCheckResolutionCalldata(t *testing.T) {
+	t.Parallel()
+	contractAbi, err := express_lane_auctiongen.ExpressLaneAuctionMetaData.GetAbi()
+	require.NoError(t, err)
+	bid := func(controller string, amount int64) *ValidatedBid {
+		return &ValidatedBid{
+			ExpressLaneController: common.HexToAddress(controller),
+			Amount:                big.NewInt(amount),
+			Signature:             append(make([]byte, 64), byte(amount)),
+		}
+	}
+	encode := func(bids ...*ValidatedBid) []byte {
+		args := make([]interface{}, 0, len(bids))
+		for _, bid := range bids {
+			args = append(args, express_lane_auctiongen.Bid{
+				ExpressLaneController: bid.ExpressLaneController,
+				Amount:                bid.Amount,
+				Signature:             bid.Signature,
+			})
+		}
+		method := "resolveMultiBidAuction"
+		if len(bids) == 1 {
+			method = "resolveSingleBidAuction"
+		}
+		data, err := contractAbi.Pack(method, args...)
+		require.NoError(t, err)
+		return data
+	}
+	first, second := bid("0x1", 20), bid("0x2", 10)
+
+	require.NoError(t, checkResolutionCalldata(encode(first), first))
+	require.NoError(t, checkResolutionCalldata(encode(first, second), first, second))
+
+	for name, data := range map[string][]byte{
+		"swapped":      encode(second, first),
+		"amount":       encode(bid("0x1", 21), second),
+		"controller":   encode(bid("0x3", 20), second),
+		"signature":    encode(&ValidatedBid{ExpressLaneController: first.ExpressLaneController, Amount: first.Amount, Signature: make([]byte, 65)}, second),
+		"single bid":   encode(first),
+		"other method": contractAbi.Methods["reservePrice"].ID,
+		"truncated":    encode(first, second)[:40],
+		"no selector":  nil,
+	} {
+		require.ErrorIs(t, checkResolutionCalldata(data, first, second), errResolutionCalldataMismatch, name)
+	}
+}
+
+func TestResolutionCalldataMismatchIsNotSent(t *testing.T) {
+	t.Parallel()
+	chainId := big.NewInt(1)
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+	require.NoError(t, err)
+	txOpts.GasLimit = 1_000_000
+	contractAbi, err := express_lane_auctiongen.ExpressLaneAuctionMetaData.GetAbi()
+	require.NoError(t, err)
+	// A faulty signer signs a resolution for more than the winner bid.
+	sign := txOpts.Signer
+	txOpts.Signer = func(from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		data, err := contractAbi.Pack("resolveSingleBidAuction", express_lane_auctiongen.Bid{
+			ExpressLaneController: common.HexToAddress("0x1"),
+			Amount:                big.NewInt(11),
+			Signature:             make([]byte, 65),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return sign(from, types.NewTx(&types.DynamicFeeTx{
+			ChainID:   tx.ChainId(),
+			Nonce:     tx.Nonce(),
+			GasTipCap: tx.GasTipCap(),
+			GasFeeCap: tx.GasFeeCap(),
+			Gas:       tx.Gas(),
+			To:        tx.To(),
+			Value:     tx.Value(),
+			Data:      data,
+		}))
+	}
+	a := &AuctioneerServer{
+		txOpts:   txOpts,
+		chainId:  chainId,
+		bidCache: newBidCache([32]byte{}),
+		roundTimingInfo: RoundTimingInfo{
+			Offset:            time.Now(),
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+	}
+	a.bidCache.add(&ValidatedBid{
+		ChainId:               chainId,
+		Bidder:                common.HexToAddress("0x1"),
+		ExpressLaneController: common.HexToAddress("0x1"),
+		Round:                 1,
+		Amount:                big.NewInt(10),
+		Signature:             make([]byte, 65),
+	})
+	client := &fakeAuctioneerClient{baseFee: big.NewInt(1)}
+	result, err := a.resolveAuctionWithClient(context.Background(), client, true)
+	require.ErrorIs(t, err, errResolutionCalldataMismatch)
+	require.ErrorContains(t, err, "amount 11, expected controller")
+	require.Nil(t, result)
+	require.Empty(t, client.submitted)
+}
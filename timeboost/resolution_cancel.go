@@ -0,0 +1,160 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	sequencerHealthCheckInterval = time.Second
+	sequencerHealthCheckTimeout  = 5 * time.Second
+	// Replacement transactions must pay at least 10% more, double the fees to
+	// make sure the cancellation is accepted.
+	cancellationFeeMultiplier = 2
+)
+
+var errResolutionCancelled = errors.New("auction resolution cancelled")
+
+// SequencerHealthSource reports an error if the sequencer behind client is
+// unhealthy. By default a sequencer is healthy as long as it serves its
+// latest header.
+type SequencerHealthSource func(ctx context.Context, client AuctioneerClient) error
+
+// WithSequencerHealthSource overrides how the health of the sequencer an
+// in-flight resolution was submitted to is checked, e.g. to simulate
+// sequencer downtime in tests.
+func WithSequencerHealthSource(source SequencerHealthSource) AuctioneerServerOpt {
+	return func(a *AuctioneerServer) {
+		a.sequencerHealth = source
+	}
+}
+
+func latestHeaderSequencerHealth(ctx context.Context, client AuctioneerClient) error {
+	_, err := client.HeaderByNumber(ctx, nil)
+	return err
+}
+
+// inFlightResolution is a resolution transaction that was submitted to the
+// sequencer but has not been mined yet.
+type inFlightResolution struct {
+	round       uint64
+	tx          *types.Transaction
+	signer      *bind.TransactOpts
+	client      AuctioneerClient
+	stopWaiting context.CancelFunc
+	cancelled   bool
+}
+
+func (a *AuctioneerServer) setInFlightResolution(inFlight *inFlightResolution) {
+	a.inFlightLock.Lock()
+	defer a.inFlightLock.Unlock()
+	a.inFlight = inFlight
+}
+
+// takeInFlightResolution clears the in-flight resolution, returning it.
+func (a *AuctioneerServer) takeInFlightResolution() *inFlightResolution {
+	a.inFlightLock.Lock()
+	defer a.inFlightLock.Unlock()
+	inFlight := a.inFlight
+	a.inFlight = nil
+	return inFlight
+}
+
+func (a *AuctioneerServer) resolutionCancelled() bool {
+	a.inFlightLock.Lock()
+	defer a.inFlightLock.Unlock()
+	return a.inFlight != nil && a.inFlight.cancelled
+}
+
+// checkSequencerHealth watches the sequencer an in-flight resolution was
+// submitted to. If that sequencer stops responding before the resolution is
+// mined, the resolution is replaced with a cancellation through the currently
+// chosen sequencer, so that the round doesn't go live on a dead sequencer.
+func (a *AuctioneerServer) checkSequencerHealth(ctx context.Context) time.Duration {
+	a.inFlightLock.Lock()
+	inFlight := a.inFlight
+	a.inFlightLock.Unlock()
+	interval := jitter(sequencerHealthCheckInterval, a.pollJitter)
+	if inFlight == nil || inFlight.cancelled || inFlight.round != a.UpcomingRound() {
+		return interval
+	}
+	sequencerHealth := a.sequencerHealth
+	if sequencerHealth == nil {
+		sequencerHealth = latestHeaderSequencerHealth
+	}
+	healthCtx, cancel := context.WithTimeout(ctx, sequencerHealthCheckTimeout)
+	err := sequencerHealth(healthCtx, inFlight.client)
+	cancel()
+	if err == nil {
+		return interval
+	}
+	log.Warn("Sequencer unhealthy while auction resolution is in flight", "round", inFlight.round, "txHash", inFlight.tx.Hash(), "err", err)
+
+	sequencerRpc, newRpc, err := a.endpointManager.GetSequencerRPC(ctx)
+	if err != nil {
+		log.Error("No sequencer to cancel in-flight auction resolution through", "round", inFlight.round, "err", err)
+		return interval
+	}
+	if newRpc {
+		// The next resolution must rebind the auction contract to the new sequencer.
+		a.sequencerChanged.Store(true)
+	}
+	if err := a.cancelInFlightResolution(ctx, newSequencerClient(sequencerRpc), inFlight); err != nil {
+		log.Error("Could not cancel in-flight auction resolution", "round", inFlight.round, "txHash", inFlight.tx.Hash(), "err", err)
+	}
+	return interval
+}
+
+// cancelInFlightResolution replaces the in-flight resolution with a zero value
+// self transfer using the same nonce and higher fees, then stops waiting for
+// the resolution to be mined.
+func (a *AuctioneerServer) cancelInFlightResolution(ctx context.Context, client AuctioneerClient, inFlight *inFlightResolution) error {
+	from := inFlight.signer.From
+	resolution := inFlight.tx
+	var inner types.TxData
+	// Access list resolutions are legacy priced.
+	if resolution.Type() == types.LegacyTxType || resolution.Type() == types.AccessListTxType {
+		inner = &types.LegacyTx{
+			Nonce:    resolution.Nonce(),
+			GasPrice: new(big.Int).Mul(resolution.GasPrice(), big.NewInt(cancellationFeeMultiplier)),
+			Gas:      params.TxGas,
+			To:       &from,
+			Value:    new(big.Int),
+		}
+	} else {
+		inner = &types.DynamicFeeTx{
+			ChainID:   a.chainId,
+			Nonce:     resolution.Nonce(),
+			GasTipCap: new(big.Int).Mul(resolution.GasTipCap(), big.NewInt(cancellationFeeMultiplier)),
+			GasFeeCap: new(big.Int).Mul(resolution.GasFeeCap(), big.NewInt(cancellationFeeMultiplier)),
+			Gas:       params.TxGas,
+			To:        &from,
+			Value:     new(big.Int),
+		}
+	}
+	cancellation, err := inFlight.signer.Signer(from, types.NewTx(inner))
+	if err != nil {
+		return fmt.Errorf("signing cancellation: %w", err)
+	}
+	if err := client.SendTransaction(ctx, cancellation); err != nil {
+		return fmt.Errorf("sending cancellation: %w", err)
+	}
+	log.Info("Replaced in-flight auction resolution with a cancellation", "round", inFlight.round, "resolutionTxHash", resolution.Hash(), "cancellationTxHash", cancellation.Hash(), "nonce", resolution.Nonce())
+
+	a.inFlightLock.Lock()
+	inFlight.cancelled = true
+	a.inFlightLock.Unlock()
+	inFlight.stopWaiting()
+	return nil
+}
@@ -0,0 +1,208 @@
+package timeboost
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func TestCancelInFlightResolution(t *testing.T) {
+	t.Parallel()
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainId := big.NewInt(1)
+	txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+	require.NoError(t, err)
+	auctionContractAddr := common.HexToAddress("0x1234")
+
+	tests := []struct {
+		name       string
+		resolution types.TxData
+	}{
+		{
+			name: "dynamic fee",
+			resolution: &types.DynamicFeeTx{
+				ChainID:   chainId,
+				Nonce:     7,
+				GasTipCap: big.NewInt(1),
+				GasFeeCap: big.NewInt(10),
+				Gas:       100_000,
+				To:        &auctionContractAddr,
+			},
+		},
+		{
+			name: "legacy",
+			resolution: &types.LegacyTx{
+				Nonce:    7,
+				GasPrice: big.NewInt(10),
+				Gas:      100_000,
+				To:       &auctionContractAddr,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &AuctioneerServer{chainId: chainId}
+			resolution, err := txOpts.Signer(txOpts.From, types.NewTx(tt.resolution))
+			require.NoError(t, err)
+			stopped := false
+			inFlight := &inFlightResolution{
+				round:       1,
+				tx:          resolution,
+				signer:      txOpts,
+				client:      &fakeAuctioneerClient{},
+				stopWaiting: func() { stopped = true },
+			}
+			a.setInFlightResolution(inFlight)
+			require.False(t, a.resolutionCancelled())
+
+			client := &fakeAuctioneerClient{}
+			require.NoError(t, a.cancelInFlightResolution(context.Background(), client, inFlight))
+			require.True(t, stopped)
+			require.True(t, a.resolutionCancelled())
+
+			require.Len(t, client.sent, 1)
+			cancellation := client.sent[0]
+			require.Equal(t, resolution.Type(), cancellation.Type())
+			require.Equal(t, resolution.Nonce(), cancellation.Nonce())
+			require.Equal(t, txOpts.From, *cancellation.To())
+			require.Zero(t, cancellation.Value().Sign())
+			require.Equal(t, new(big.Int).Mul(resolution.GasFeeCap(), big.NewInt(2)), cancellation.GasFeeCap())
+			require.Equal(t, new(big.Int).Mul(resolution.GasTipCap(), big.NewInt(2)), cancellation.GasTipCap())
+			sender, err := types.Sender(types.LatestSignerForChainID(chainId), cancellation)
+			require.NoError(t, err)
+			require.Equal(t, txOpts.From, sender)
+
+			require.Equal(t, inFlight, a.takeInFlightResolution())
+			require.False(t, a.resolutionCancelled())
+		})
+	}
+}
+
+// scriptedSequencerHealth is a sequencer health source tests switch between
+// healthy and unhealthy.
+type scriptedSequencerHealth struct {
+	down atomic.Bool
+}
+
+func (h *scriptedSequencerHealth) check(context.Context, AuctioneerClient) error {
+	if h.down.Load() {
+		return errors.New("sequencer feed went silent")
+	}
+	return nil
+}
+
+// fakeSequencerEth records the transactions sent to the sequencer that
+// cancellations are sent through.
+type fakeSequencerEth struct {
+	mutex sync.Mutex
+	sent  []hexutil.Bytes
+}
+
+func (e *fakeSequencerEth) SendRawTransaction(ctx context.Context, input hexutil.Bytes) (common.Hash, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.sent = append(e.sent, input)
+	return crypto.Keccak256Hash(input), nil
+}
+
+func (e *fakeSequencerEth) numSent() int {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return len(e.sent)
+}
+
+type fakeEndpointManager struct {
+	client *rpc.Client
+}
+
+func (m *fakeEndpointManager) GetSequencerRPC(context.Context) (*rpc.Client, bool, error) {
+	return m.client, false, nil
+}
+
+func TestSequencerDowntimeCancelsResolution(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainId := big.NewInt(1)
+	txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+	require.NoError(t, err)
+	txOpts.GasLimit = 1_000_000
+
+	eth := &fakeSequencerEth{}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", eth))
+	defer server.Stop()
+	health := &scriptedSequencerHealth{}
+	a := &AuctioneerServer{
+		txOpts:              txOpts,
+		chainId:             chainId,
+		auctionContractAddr: common.HexToAddress("0x1234"),
+		bidCache:            newBidCache([32]byte{}),
+		endpointManager:     &fakeEndpointManager{client: rpc.DialInProc(server)},
+		roundTimingInfo: RoundTimingInfo{
+			Offset:            time.Now(),
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+	}
+	WithSequencerHealthSource(health.check)(a)
+	a.bidCache.add(&ValidatedBid{
+		ChainId:               chainId,
+		Bidder:                common.HexToAddress("0x1"),
+		ExpressLaneController: common.HexToAddress("0x1"),
+		Round:                 1,
+		Amount:                big.NewInt(10),
+		Signature:             make([]byte, 65),
+	})
+
+	// resolveInFlight starts resolving through a sequencer that never mines the
+	// resolution until released, and waits for it to be in flight.
+	resolveInFlight := func() (*fakeAuctioneerClient, chan error) {
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1)}
+		client.withholdReceipts.Store(true)
+		done := make(chan error, 1)
+		go func() {
+			_, err := a.resolveAuctionWithClient(ctx, client, true)
+			done <- err
+		}()
+		require.Eventually(t, func() bool {
+			a.inFlightLock.Lock()
+			defer a.inFlightLock.Unlock()
+			return a.inFlight != nil
+		}, 5*time.Second, 10*time.Millisecond)
+		return client, done
+	}
+
+	// The sequencer goes down while the resolution is in flight.
+	_, done := resolveInFlight()
+	health.down.Store(true)
+	a.checkSequencerHealth(ctx)
+	require.ErrorIs(t, <-done, errResolutionCancelled)
+	require.Equal(t, 1, eth.numSent())
+
+	// Once it recovers, resolutions are no longer cancelled.
+	health.down.Store(false)
+	client, done := resolveInFlight()
+	a.checkSequencerHealth(ctx)
+	require.False(t, a.resolutionCancelled())
+	client.withholdReceipts.Store(false)
+	require.NoError(t, <-done)
+	require.Equal(t, 1, eth.numSent())
+}
@@ -0,0 +1,73 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// confirmationPollInterval matches the polling interval of bind.WaitMined, and
+// is how often receipts are polled unless overridden by WithReceiptPollInterval.
+const confirmationPollInterval = time.Second
+
+var errResolutionReorged = errors.New("auction resolution transaction was reorged out")
+
+// WithResolutionConfirmations overrides the configured number of blocks,
+// including its own, a resolution transaction must be buried under before its
+// round is considered resolved and the bid cache is cleared. Values below 1
+// are treated as 1, i.e. the round is resolved as soon as the transaction is
+// mined.
+func WithResolutionConfirmations(confirmations uint64) AuctioneerServerOpt {
+	return func(a *AuctioneerServer) {
+		a.resolutionConfirmations = max(confirmations, 1)
+	}
+}
+
+// waitForConfirmations waits until the mined resolution transaction is buried
+// under the configured number of confirmations, following it if a reorg
+// re-includes it in a different block. It returns errResolutionReorged if the
+// transaction disappears from the chain, so that the caller can resubmit it.
+func (a *AuctioneerServer) waitForConfirmations(ctx context.Context, client AuctioneerClient, tx *types.Transaction, receipt *types.Receipt) (*types.Receipt, error) {
+	if a.resolutionConfirmations <= 1 {
+		return receipt, nil
+	}
+	ticker := time.NewTicker(a.receiptPollingInterval())
+	defer ticker.Stop()
+	for {
+		header, err := client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest header: %w", err)
+		}
+		current, err := client.TransactionReceipt(ctx, tx.Hash())
+		if errors.Is(err, ethereum.NotFound) {
+			log.Warn("Auction resolution transaction was reorged out", "txHash", tx.Hash().Hex(), "minedInBlock", receipt.BlockNumber)
+			return nil, errResolutionReorged
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get resolution transaction receipt: %w", err)
+		}
+		if current.BlockHash != receipt.BlockHash {
+			log.Warn("Auction resolution transaction was re-included after a reorg", "txHash", tx.Hash().Hex(), "oldBlock", receipt.BlockNumber, "newBlock", current.BlockNumber)
+			if current.Status != types.ReceiptStatusSuccessful {
+				return nil, errors.New("re-included auction resolution transaction failed")
+			}
+			receipt = current
+		}
+		if header.Number.Uint64()+1 >= receipt.BlockNumber.Uint64()+a.resolutionConfirmations {
+			return receipt, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
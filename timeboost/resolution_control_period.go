@@ -0,0 +1,37 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// resolvedControlPeriod returns the period the winner of the round controls
+// the express lane for, as emitted by the auction contract in the
+// AuctionResolved event of the resolution's receipt. The contract emits the
+// period as timestamps rather than blocks, see RoundToBlockRange for the
+// blocks it covers. It returns false if the receipt holds no such event.
+func resolvedControlPeriod(contract auctionContractSnapshot, receipt *types.Receipt, round uint64) (time.Time, time.Time, bool) {
+	if contract.binding == nil || receipt == nil {
+		return time.Time{}, time.Time{}, false
+	}
+	for _, l := range receipt.Logs {
+		if l.Address != contract.addr {
+			continue
+		}
+		// Logs of other events fail to parse.
+		event, err := contract.binding.ParseAuctionResolved(*l)
+		if err != nil || event.Round != round {
+			continue
+		}
+		// #nosec G115
+		start, end := time.Unix(int64(event.RoundStartTimestamp), 0), time.Unix(int64(event.RoundEndTimestamp), 0)
+		return start, end, true
+	}
+	log.Warn("No AuctionResolved event in the receipt of the auction resolution", "round", round, "txHash", receipt.TxHash)
+	return time.Time{}, time.Time{}, false
+}
@@ -0,0 +1,100 @@
+package timeboost
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/offchainlabs/nitro/solgen/go/express_lane_auctiongen"
+)
+
+func TestResolutionControlPeriod(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	chainId := big.NewInt(1)
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+	require.NoError(t, err)
+	txOpts.GasLimit = 1_000_000
+	auctionAbi, err := express_lane_auctiongen.ExpressLaneAuctionMetaData.GetAbi()
+	require.NoError(t, err)
+	resolvedEvent := auctionAbi.Events["AuctionResolved"]
+	contractAddr := common.HexToAddress("0x1234")
+	roundStart := time.Now().Add(time.Minute).Truncate(time.Second)
+
+	resolved := func(contract common.Address, round uint64) *types.Log {
+		values := map[string]any{
+			"isMultiBidAuction":   false,
+			"firstPriceAmount":    big.NewInt(10),
+			"price":               big.NewInt(1),
+			"roundStartTimestamp": uint64(roundStart.Unix()),
+			"roundEndTimestamp":   uint64(roundStart.Add(time.Minute).Unix()),
+		}
+		var args []any
+		for _, input := range resolvedEvent.Inputs.NonIndexed() {
+			args = append(args, values[input.Name])
+		}
+		data, err := resolvedEvent.Inputs.NonIndexed().Pack(args...)
+		require.NoError(t, err)
+		return &types.Log{
+			Address: contract,
+			Topics: []common.Hash{
+				resolvedEvent.ID,
+				common.BigToHash(new(big.Int).SetUint64(round)),
+				common.HexToAddress("0x1").Hash(),
+				common.HexToAddress("0x1").Hash(),
+			},
+			Data: data,
+		}
+	}
+	resolve := func(logs ...*types.Log) (*ResolutionResult, AuctionResolution) {
+		a := &AuctioneerServer{
+			txOpts:              txOpts,
+			chainId:             chainId,
+			auctionContractAddr: contractAddr,
+			bidCache:            newBidCache([32]byte{}),
+			roundTimingInfo: RoundTimingInfo{
+				Offset:            time.Now(),
+				Round:             time.Minute,
+				AuctionClosing:    15 * time.Second,
+				ReserveSubmission: 15 * time.Second,
+			},
+		}
+		// The listener thread is never started, so the notification stays pending.
+		WithResolutionListener(func(AuctionResolution) {})(a)
+		a.bidCache.add(&ValidatedBid{
+			ChainId:               chainId,
+			Bidder:                common.HexToAddress("0x1"),
+			ExpressLaneController: common.HexToAddress("0x1"),
+			Round:                 1,
+			Amount:                big.NewInt(10),
+			Signature:             make([]byte, 65),
+		})
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1), receiptLogs: logs}
+		result, err := a.resolveAuctionWithClient(ctx, client, true)
+		require.NoError(t, err)
+		return result, <-a.resolutionListeners[0].pending
+	}
+
+	// Events of other contracts and rounds are ignored.
+	result, resolution := resolve(resolved(common.HexToAddress("0x5678"), 1), resolved(contractAddr, 2), resolved(contractAddr, 1))
+	require.True(t, roundStart.Equal(result.ControlStart))
+	require.True(t, roundStart.Add(time.Minute).Equal(result.ControlEnd))
+	require.Equal(t, result.ControlStart, resolution.ControlStart)
+	require.Equal(t, result.ControlEnd, resolution.ControlEnd)
+
+	// Without the event the round is still resolved, only without its period.
+	result, resolution = resolve(resolved(common.HexToAddress("0x5678"), 1))
+	require.Equal(t, RoundStatusResolved, result.Outcome)
+	require.True(t, result.ControlStart.IsZero())
+	require.True(t, resolution.ControlEnd.IsZero())
+}
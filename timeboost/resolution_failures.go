@@ -0,0 +1,64 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ResolutionFailureAlert describes the resolution of several rounds in a row
+// failing, which points at a systemic problem such as a bad signer, a contract
+// issue or an RPC outage, rather than a transient error.
+type ResolutionFailureAlert struct {
+	// Round is the last round whose resolution failed.
+	Round               uint64
+	ConsecutiveFailures uint64
+	// Err is why the resolution of Round failed.
+	Err error
+}
+
+// ResolutionFailureAlerter is invoked when the number of rounds in a row whose
+// resolution failed reaches the failure alert threshold.
+type ResolutionFailureAlerter func(ResolutionFailureAlert)
+
+// WithResolutionFailureAlerter registers a callback raising an alert when the
+// failure alert threshold is reached. It is invoked synchronously from the
+// resolution thread, so it must not block.
+func WithResolutionFailureAlerter(alerter ResolutionFailureAlerter) AuctioneerServerOpt {
+	return func(a *AuctioneerServer) {
+		a.failureAlerter = alerter
+	}
+}
+
+// recordResolutionFailure counts the rounds whose resolution failed in a row,
+// retries of a round counting once, and alerts once there are as many as the
+// failure alert threshold. Callers must hold the resolution lock.
+func (a *AuctioneerServer) recordResolutionFailure(round uint64, err error) {
+	if a.consecutiveFailures > 0 && a.lastFailedRound == round {
+		return
+	}
+	a.consecutiveFailures++
+	a.lastFailedRound = round
+	// #nosec G115
+	a.getMetrics().consecutiveFailures.Update(int64(a.consecutiveFailures))
+	if a.failureAlertThreshold == 0 || a.consecutiveFailures != a.failureAlertThreshold {
+		return
+	}
+	log.Root().Write(log.LevelCrit, "Auction resolution failed several rounds in a row", "round", round, "consecutiveFailures", a.consecutiveFailures, "err", err)
+	a.getMetrics().failureAlerts.Inc(1)
+	if a.failureAlerter != nil {
+		a.failureAlerter(ResolutionFailureAlert{Round: round, ConsecutiveFailures: a.consecutiveFailures, Err: err})
+	}
+}
+
+// recordResolutionSuccess resets the count of rounds whose resolution failed
+// in a row. Callers must hold the resolution lock.
+func (a *AuctioneerServer) recordResolutionSuccess(round uint64) {
+	if a.consecutiveFailures == 0 {
+		return
+	}
+	log.Info("Auction resolution succeeded after failures", "round", round, "consecutiveFailures", a.consecutiveFailures)
+	a.consecutiveFailures = 0
+	a.getMetrics().consecutiveFailures.Update(0)
+}
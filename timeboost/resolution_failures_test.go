@@ -0,0 +1,69 @@
+package timeboost
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolutionFailureAlert(t *testing.T) {
+	t.Parallel()
+	var alerts []ResolutionFailureAlert
+	a := &AuctioneerServer{failureAlertThreshold: 3}
+	WithResolutionFailureAlerter(func(alert ResolutionFailureAlert) { alerts = append(alerts, alert) })(a)
+	errFailed := errors.New("failed")
+
+	// Retries of a round count as a single failure.
+	a.recordResolutionFailure(1, errFailed)
+	a.recordResolutionFailure(1, errFailed)
+	a.recordResolutionFailure(2, errFailed)
+	require.Empty(t, alerts)
+	require.Equal(t, uint64(2), a.consecutiveFailures)
+
+	// The alert is raised once the threshold is crossed.
+	a.recordResolutionFailure(3, errFailed)
+	a.recordResolutionFailure(4, errFailed)
+	require.Equal(t, []ResolutionFailureAlert{{Round: 3, ConsecutiveFailures: 3, Err: errFailed}}, alerts)
+
+	// A success starts counting over.
+	a.recordResolutionSuccess(5)
+	require.Zero(t, a.consecutiveFailures)
+	for round := uint64(6); round < 9; round++ {
+		a.recordResolutionFailure(round, errFailed)
+	}
+	require.Len(t, alerts, 2)
+	require.Equal(t, uint64(8), alerts[1].Round)
+
+	// No alert is ever raised without a threshold.
+	a = &AuctioneerServer{}
+	WithResolutionFailureAlerter(func(ResolutionFailureAlert) { t.Fatal("unexpected alert") })(a)
+	for round := uint64(1); round < 10; round++ {
+		a.recordResolutionFailure(round, errFailed)
+	}
+}
+
+func TestResolveUpcomingRoundCountsFailures(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	a := &AuctioneerServer{
+		failureAlertThreshold: 1,
+		roundTimingInfo: RoundTimingInfo{
+			Offset:            time.Now().Add(-50 * time.Second),
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+	}
+	var alerts int
+	WithResolutionFailureAlerter(func(ResolutionFailureAlert) { alerts++ })(a)
+	fail := func(context.Context) error { return errors.New("failed") }
+	require.Error(t, a.resolveUpcomingRound(ctx, fail))
+	require.Error(t, a.resolveUpcomingRound(ctx, fail))
+	require.Equal(t, uint64(1), a.consecutiveFailures)
+	require.Equal(t, 1, alerts)
+	require.NoError(t, a.resolveUpcomingRound(ctx, func(context.Context) error { return nil }))
+	require.Zero(t, a.consecutiveFailures)
+}
@@ -0,0 +1,47 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// resolutionFees are the parent chain fees a resolution was sent and mined at,
+// recorded for analyzing resolution costs against chain conditions.
+type resolutionFees struct {
+	// baseFee is that of the latest header when the resolution was sent. It is
+	// nil on chains without a base fee.
+	baseFee *big.Int
+	// effectiveGasPrice is the price per gas paid by the mined resolution, nil
+	// if its receipt has none.
+	effectiveGasPrice *big.Int
+}
+
+func newResolutionFees(header *types.Header, receipt *types.Receipt) *resolutionFees {
+	fees := &resolutionFees{}
+	if header != nil && header.BaseFee != nil {
+		fees.baseFee = new(big.Int).Set(header.BaseFee)
+	}
+	if receipt != nil && receipt.EffectiveGasPrice != nil {
+		fees.effectiveGasPrice = new(big.Int).Set(receipt.EffectiveGasPrice)
+	}
+	return fees
+}
+
+// hexFees returns the fees for JSON records, both nil if unknown.
+func (f *resolutionFees) hexFees() (baseFee, effectiveGasPrice *hexutil.Big) {
+	if f == nil {
+		return nil, nil
+	}
+	if f.baseFee != nil {
+		baseFee = (*hexutil.Big)(new(big.Int).Set(f.baseFee))
+	}
+	if f.effectiveGasPrice != nil {
+		effectiveGasPrice = (*hexutil.Big)(new(big.Int).Set(f.effectiveGasPrice))
+	}
+	return baseFee, effectiveGasPrice
+}
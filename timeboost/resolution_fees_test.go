@@ -0,0 +1,79 @@
+package timeboost
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestResolutionRecordsFees(t *testing.T) {
+	t.Parallel()
+	chainId := big.NewInt(1)
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+	require.NoError(t, err)
+	txOpts.GasLimit = 1_000_000
+	db, err := NewDatabase(t.TempDir())
+	require.NoError(t, err)
+	a := &AuctioneerServer{
+		txOpts:   txOpts,
+		chainId:  chainId,
+		bidCache: newBidCache([32]byte{}),
+		roundTimingInfo: RoundTimingInfo{
+			// Bidding on round 1 is closed.
+			Offset:            time.Now().Add(-50 * time.Second),
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+	}
+	WithAuctionHistory(db)(a)
+	a.bidCache.add(&ValidatedBid{
+		ChainId:               chainId,
+		Bidder:                common.HexToAddress("0x1"),
+		ExpressLaneController: common.HexToAddress("0x1"),
+		Round:                 1,
+		Amount:                big.NewInt(10),
+		Signature:             make([]byte, 65),
+	})
+	client := &fakeAuctioneerClient{baseFee: big.NewInt(7_000_000_000)}
+
+	var result *ResolutionResult
+	require.NoError(t, a.resolveUpcomingRound(context.Background(), func(ctx context.Context) error {
+		var err error
+		result, err = a.resolveAuctionWithClient(ctx, client, true)
+		return err
+	}))
+	require.Equal(t, RoundStatusResolved, result.Outcome)
+	require.Equal(t, client.baseFee, result.BaseFee)
+	require.Equal(t, client.effectiveGasPrice(client.submitted[0]), result.EffectiveGasPrice)
+	// Without a tip, the resolution pays exactly the base fee.
+	require.Equal(t, client.baseFee, result.EffectiveGasPrice)
+
+	history, err := a.HistorySince(0)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Equal(t, client.baseFee, history[0].BaseFee.ToInt())
+	require.Equal(t, client.baseFee, history[0].EffectiveGasPrice.ToInt())
+}
+
+func TestAuditRecordFees(t *testing.T) {
+	t.Parallel()
+	auditor := &bidAuditor{}
+	record := auditor.record(1, newBidCache([32]byte{}), &auctionResult{}, common.Hash{1}, newResolutionFees(nil, nil), nil)
+	require.Nil(t, record.BaseFee)
+	require.Nil(t, record.EffectiveGasPrice)
+
+	fees := &resolutionFees{baseFee: big.NewInt(3), effectiveGasPrice: big.NewInt(5)}
+	record = auditor.record(1, newBidCache([32]byte{}), &auctionResult{}, common.Hash{1}, fees, nil)
+	require.Equal(t, big.NewInt(3), record.BaseFee.ToInt())
+	require.Equal(t, big.NewInt(5), record.EffectiveGasPrice.ToInt())
+}
@@ -0,0 +1,29 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// recordResolutionLatency records how long a resolution took from its first
+// submission until it was confirmed, and the margin it was confirmed with
+// before its round started. Both inform how early rounds must be resolved, and
+// how aggressively resolutions must be priced. Resolutions with one and two
+// bids are recorded apart, as they differ in gas and may differ in latency.
+func (a *AuctioneerServer) recordResolutionLatency(round uint64, multiBid bool, submittedAt, confirmedAt, roundStart time.Time) {
+	latency := confirmedAt.Sub(submittedAt)
+	margin := roundStart.Sub(confirmedAt)
+	m := a.getMetrics()
+	if multiBid {
+		m.multiBidLatency.Update(latency.Milliseconds())
+		m.multiBidMargin.Update(margin.Milliseconds())
+	} else {
+		m.singleBidLatency.Update(latency.Milliseconds())
+		m.singleBidMargin.Update(margin.Milliseconds())
+	}
+	log.Debug("Auction resolution confirmed", "round", round, "multiBid", multiBid, "latency", latency, "margin", margin)
+}
@@ -0,0 +1,32 @@
+package timeboost
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+func TestRecordResolutionLatency(t *testing.T) {
+	t.Parallel()
+	registry := metrics.NewRegistry()
+	a := &AuctioneerServer{}
+	WithMetricsRegistry(registry, "arb/auctioneer/")(a)
+	submittedAt := time.Now()
+	roundStart := submittedAt.Add(10 * time.Second)
+
+	a.recordResolutionLatency(1, false, submittedAt, submittedAt.Add(2*time.Second), roundStart)
+	a.recordResolutionLatency(2, true, submittedAt, submittedAt.Add(3*time.Second), roundStart)
+	a.recordResolutionLatency(3, true, submittedAt, submittedAt.Add(5*time.Second), roundStart)
+
+	m := a.getMetrics()
+	require.Equal(t, int64(1), m.singleBidLatency.Snapshot().Count())
+	require.Equal(t, int64(2_000), m.singleBidLatency.Snapshot().Max())
+	require.Equal(t, int64(8_000), m.singleBidMargin.Snapshot().Value())
+	require.Equal(t, int64(2), m.multiBidLatency.Snapshot().Count())
+	require.Equal(t, int64(5_000), m.multiBidLatency.Snapshot().Max())
+	// The margin is that of the most recent round.
+	require.Equal(t, int64(5_000), m.multiBidMargin.Snapshot().Value())
+}
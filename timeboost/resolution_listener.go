@@ -0,0 +1,77 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// resolutionListenerBuffer is the number of pending notifications a single
+// listener may fall behind by before newer notifications are dropped for it.
+const resolutionListenerBuffer = 16
+
+// AuctionResolution describes an auction round that was successfully resolved.
+type AuctionResolution struct {
+	Round uint64
+	// FirstPlace is the winning bid.
+	FirstPlace *ValidatedBid
+	// SecondPlace is nil if the auction was resolved with a single bid.
+	SecondPlace *ValidatedBid
+	TxHash      common.Hash
+	// ControlStart and ControlEnd bound the period the winner controls the
+	// express lane for, as emitted by the auction contract. Both are zero if
+	// it emitted none.
+	ControlStart time.Time
+	ControlEnd   time.Time
+}
+
+// ResolutionListener is notified after every successful auction resolution.
+type ResolutionListener func(AuctionResolution)
+
+// WithResolutionListener registers a listener to be notified after every
+// successful auction resolution. Listeners are invoked from their own thread,
+// in resolution order, so a slow listener never blocks the resolution loop.
+func WithResolutionListener(listener ResolutionListener) AuctioneerServerOpt {
+	return func(a *AuctioneerServer) {
+		a.resolutionListeners = append(a.resolutionListeners, &resolutionListener{
+			fn:      listener,
+			pending: make(chan AuctionResolution, resolutionListenerBuffer),
+		})
+	}
+}
+
+type resolutionListener struct {
+	fn      ResolutionListener
+	pending chan AuctionResolution
+}
+
+func (a *AuctioneerServer) startResolutionListeners() {
+	for _, listener := range a.resolutionListeners {
+		listener := listener
+		a.StopWaiter.LaunchThread(func(ctx context.Context) {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case resolution := <-listener.pending:
+					listener.fn(resolution)
+				}
+			}
+		})
+	}
+}
+
+func (a *AuctioneerServer) notifyResolutionListeners(resolution AuctionResolution) {
+	for _, listener := range a.resolutionListeners {
+		select {
+		case listener.pending <- resolution:
+		default:
+			log.Warn("Resolution listener is falling behind, dropping notification", "round", resolution.Round)
+		}
+	}
+}
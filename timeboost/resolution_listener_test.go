@@ -0,0 +1,53 @@
+package timeboost
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestResolutionListeners(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fast := make(chan AuctionResolution, 10)
+	blockSlow := make(chan struct{})
+	a := &AuctioneerServer{}
+	WithResolutionListener(func(r AuctionResolution) { fast <- r })(a)
+	WithResolutionListener(func(AuctionResolution) { <-blockSlow })(a)
+	a.StopWaiter.Start(ctx, a)
+	a.startResolutionListeners()
+	defer func() {
+		close(blockSlow)
+		a.StopAndWait()
+	}()
+
+	// The slow listener must not prevent the fast one from being notified,
+	// nor block the caller once its buffer fills up.
+	done := make(chan struct{})
+	go func() {
+		for round := uint64(0); round < resolutionListenerBuffer*2; round++ {
+			a.notifyResolutionListeners(AuctionResolution{Round: round, TxHash: common.Hash{byte(round)}})
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("notifying listeners blocked on a slow listener")
+	}
+
+	for round := uint64(0); round < resolutionListenerBuffer; round++ {
+		select {
+		case r := <-fast:
+			require.Equal(t, round, r.Round)
+		case <-time.After(5 * time.Second):
+			t.Fatal("fast listener was not notified")
+		}
+	}
+}
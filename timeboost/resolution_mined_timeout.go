@@ -0,0 +1,147 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// What to do with a resolution transaction not mined within the configured
+// timeout.
+const (
+	// MinedTimeoutReprice replaces the transaction with one of higher fees, as
+	// long as its round hasn't started.
+	MinedTimeoutReprice = "reprice"
+	// MinedTimeoutAbort gives up on resolving the round.
+	MinedTimeoutAbort = "abort"
+)
+
+// Replacement transactions must pay at least 10% more, double the fees so
+// that a stuck resolution is replaced in a single step.
+const repriceFeeMultiplier = 2
+
+var errResolutionNotMined = errors.New("auction resolution transaction not mined in time")
+
+func validateMinedTimeoutAction(action string) error {
+	switch action {
+	case MinedTimeoutReprice, MinedTimeoutAbort, "":
+		return nil
+	}
+	return fmt.Errorf("invalid resolution mined timeout action %q, must be %s or %s", action, MinedTimeoutReprice, MinedTimeoutAbort)
+}
+
+// WithReceiptPollInterval sets how often the receipts of resolution
+// transactions are polled for while waiting for them to be mined and confirmed,
+// trading how soon a round is found resolved against the load on the RPC.
+// Values of zero or less keep the default of one second.
+func WithReceiptPollInterval(interval time.Duration) AuctioneerServerOpt {
+	return func(a *AuctioneerServer) {
+		a.receiptPollInterval = interval
+	}
+}
+
+// WithMinedTimeout overrides the configured time a resolution transaction has
+// to be mined in before it is acted on as stuck, zero waiting until its round
+// starts.
+func WithMinedTimeout(timeout time.Duration) AuctioneerServerOpt {
+	return func(a *AuctioneerServer) {
+		a.minedTimeout = timeout
+	}
+}
+
+func (a *AuctioneerServer) receiptPollingInterval() time.Duration {
+	if a.receiptPollInterval <= 0 {
+		return confirmationPollInterval
+	}
+	return a.receiptPollInterval
+}
+
+// waitMined waits for any of the transactions submitted to resolve a round,
+// which all share a nonce, to be mined. Earlier ones may still be mined after
+// being replaced. It returns errResolutionNotMined if none is mined within the
+// configured timeout, if any.
+func (a *AuctioneerServer) waitMined(ctx context.Context, client AuctioneerClient, txs []*types.Transaction) (*types.Transaction, *types.Receipt, error) {
+	waitCtx := ctx
+	if a.minedTimeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, a.minedTimeout)
+		defer cancel()
+	}
+	ticker := time.NewTicker(a.receiptPollingInterval())
+	defer ticker.Stop()
+	for {
+		for _, tx := range txs {
+			receipt, err := client.TransactionReceipt(waitCtx, tx.Hash())
+			if err == nil {
+				return tx, receipt, nil
+			}
+			if !errors.Is(err, ethereum.NotFound) {
+				// Retried like bind.WaitMined does.
+				log.Debug("Failed to get auction resolution receipt", "txHash", tx.Hash().Hex(), "err", err)
+			}
+		}
+		select {
+		case <-waitCtx.Done():
+			if ctx.Err() != nil {
+				return nil, nil, ctx.Err()
+			}
+			return nil, nil, fmt.Errorf("%w: txHash %s, timeout %v", errResolutionNotMined, txs[len(txs)-1].Hash().Hex(), a.minedTimeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// repriceResolution re-signs the resolution transaction with the same nonce
+// and higher fees, to replace it while it is stuck.
+func (a *AuctioneerServer) repriceResolution(signer *bind.TransactOpts, tx *types.Transaction) (*types.Transaction, error) {
+	var inner types.TxData
+	switch tx.Type() {
+	case types.LegacyTxType:
+		inner = &types.LegacyTx{
+			Nonce:    tx.Nonce(),
+			GasPrice: new(big.Int).Mul(tx.GasPrice(), big.NewInt(repriceFeeMultiplier)),
+			Gas:      tx.Gas(),
+			To:       tx.To(),
+			Value:    tx.Value(),
+			Data:     tx.Data(),
+		}
+	case types.AccessListTxType:
+		inner = &types.AccessListTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasPrice:   new(big.Int).Mul(tx.GasPrice(), big.NewInt(repriceFeeMultiplier)),
+			Gas:        tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		}
+	default:
+		inner = &types.DynamicFeeTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  new(big.Int).Mul(tx.GasTipCap(), big.NewInt(repriceFeeMultiplier)),
+			GasFeeCap:  new(big.Int).Mul(tx.GasFeeCap(), big.NewInt(repriceFeeMultiplier)),
+			Gas:        tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		}
+	}
+	repriced, err := signer.Signer(signer.From, types.NewTx(inner))
+	if err != nil {
+		return nil, fmt.Errorf("signing repriced auction resolution: %w", err)
+	}
+	return repriced, nil
+}
@@ -0,0 +1,160 @@
+package timeboost
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+func TestResolutionMinedTimeout(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	chainId := big.NewInt(1)
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+	require.NoError(t, err)
+	txOpts.GasLimit = 1_000_000
+
+	newAuctioneer := func(action string) *AuctioneerServer {
+		a := &AuctioneerServer{
+			txOpts:             txOpts,
+			chainId:            chainId,
+			bidCache:           newBidCache([32]byte{}),
+			minedTimeout:       50 * time.Millisecond,
+			minedTimeoutAction: action,
+			roundTimingInfo: RoundTimingInfo{
+				Offset:            time.Now(),
+				Round:             time.Minute,
+				AuctionClosing:    15 * time.Second,
+				ReserveSubmission: 15 * time.Second,
+			},
+		}
+		WithMetricsRegistry(metrics.NewRegistry(), "arb/auctioneer/")(a)
+		a.bidCache.add(&ValidatedBid{
+			ChainId:               chainId,
+			Bidder:                common.HexToAddress("0x1"),
+			ExpressLaneController: common.HexToAddress("0x1"),
+			Round:                 1,
+			Amount:                big.NewInt(10),
+			Signature:             make([]byte, 65),
+		})
+		return a
+	}
+
+	t.Run("Abort", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer(MinedTimeoutAbort)
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1)}
+		client.withholdReceipts.Store(true)
+
+		result, err := a.resolveAuctionWithClient(ctx, client, true)
+		require.ErrorIs(t, err, errResolutionNotMined)
+		require.Nil(t, result)
+		require.Len(t, client.submitted, 1)
+		require.Equal(t, int64(1), a.getMetrics().minedTimeouts.Snapshot().Count())
+	})
+
+	t.Run("Reprice", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer(MinedTimeoutReprice)
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1)}
+		client.withholdReceipts.Store(true)
+
+		type resolved struct {
+			result *ResolutionResult
+			err    error
+		}
+		done := make(chan resolved, 1)
+		go func() {
+			result, err := a.resolveAuctionWithClient(ctx, client, true)
+			done <- resolved{result, err}
+		}()
+		inFlightTx := func() *types.Transaction {
+			a.inFlightLock.Lock()
+			defer a.inFlightLock.Unlock()
+			if a.inFlight == nil {
+				return nil
+			}
+			return a.inFlight.tx
+		}
+		require.Eventually(t, func() bool { return inFlightTx() != nil }, 5*time.Second, 10*time.Millisecond)
+		first := inFlightTx()
+		// The repriced resolution is submitted once the timed out attempt is retried.
+		require.Eventually(t, func() bool { return inFlightTx() != first }, 5*time.Second, 10*time.Millisecond)
+		client.withholdReceipts.Store(false)
+
+		res := <-done
+		require.NoError(t, res.err)
+		require.Equal(t, RoundStatusResolved, res.result.Outcome)
+		// Later attempts may have been repriced again before the first was mined.
+		require.GreaterOrEqual(t, len(client.submitted), 2)
+		original, repriced := client.submitted[0], client.submitted[1]
+		require.Equal(t, original.Nonce(), repriced.Nonce())
+		require.Equal(t, new(big.Int).Mul(original.GasFeeCap(), big.NewInt(2)), repriced.GasFeeCap())
+		require.Equal(t, new(big.Int).Mul(original.GasTipCap(), big.NewInt(2)), repriced.GasTipCap())
+		require.GreaterOrEqual(t, a.getMetrics().minedTimeouts.Snapshot().Count(), int64(1))
+	})
+
+	t.Run("InvalidAction", func(t *testing.T) {
+		t.Parallel()
+		require.Error(t, validateMinedTimeoutAction("bogus"))
+		require.NoError(t, validateMinedTimeoutAction(MinedTimeoutAbort))
+	})
+}
+
+func TestReceiptPollInterval(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1, Gas: 21_000, GasPrice: big.NewInt(1)})
+
+	t.Run("ReturnsOnceMined", func(t *testing.T) {
+		t.Parallel()
+		a := &AuctioneerServer{}
+		WithReceiptPollInterval(10 * time.Millisecond)(a)
+		client := &fakeAuctioneerClient{submitted: []*types.Transaction{tx}}
+		client.withholdReceipts.Store(true)
+		var minedAt time.Time
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			minedAt = time.Now()
+			client.withholdReceipts.Store(false)
+		}()
+		minedTx, receipt, err := a.waitMined(ctx, client, []*types.Transaction{tx})
+		require.NoError(t, err)
+		require.Equal(t, tx.Hash(), minedTx.Hash())
+		require.Equal(t, tx.Hash(), receipt.TxHash)
+		// Well within the default interval of a second.
+		require.Less(t, time.Since(minedAt), 500*time.Millisecond)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		t.Parallel()
+		a := &AuctioneerServer{minedTimeout: time.Hour}
+		WithReceiptPollInterval(10 * time.Millisecond)(a)
+		WithMinedTimeout(50 * time.Millisecond)(a)
+		client := &fakeAuctioneerClient{submitted: []*types.Transaction{tx}}
+		client.withholdReceipts.Store(true)
+		start := time.Now()
+		_, _, err := a.waitMined(ctx, client, []*types.Transaction{tx})
+		require.ErrorIs(t, err, errResolutionNotMined)
+		require.Less(t, time.Since(start), 500*time.Millisecond)
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		t.Parallel()
+		a := &AuctioneerServer{}
+		require.Equal(t, confirmationPollInterval, a.receiptPollingInterval())
+		WithReceiptPollInterval(-time.Second)(a)
+		require.Equal(t, confirmationPollInterval, a.receiptPollingInterval())
+	})
+}
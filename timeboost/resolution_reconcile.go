@@ -0,0 +1,34 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// reconcileTimeout bounds looking up whether a resolution was mined after all.
+const reconcileTimeout = 5 * time.Second
+
+// minedResolution looks up whether any of the transactions submitted to resolve
+// a round was successfully mined, after waiting for them failed, e.g. because
+// the resolution was aborted with its context right as one was mined. The round
+// would otherwise be considered failed and resolved again. It is looked up
+// under a context of its own, as that of the resolution may be done.
+func (a *AuctioneerServer) minedResolution(client AuctioneerClient, round uint64, submitted []*types.Transaction, waitErr error) (*types.Transaction, *types.Receipt) {
+	ctx, cancel := context.WithTimeout(context.Background(), reconcileTimeout)
+	defer cancel()
+	for _, tx := range submitted {
+		receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+		if err != nil || receipt == nil || receipt.Status != types.ReceiptStatusSuccessful {
+			continue
+		}
+		log.Warn("Auction resolution was mined although waiting for it failed", "round", round, "txHash", tx.Hash().Hex(), "waitErr", waitErr)
+		return tx, receipt
+	}
+	return nil, nil
+}
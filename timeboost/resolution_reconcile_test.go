@@ -0,0 +1,79 @@
+package timeboost
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// unseenReceiptClient only reports receipts once the resolution waiting for
+// them was aborted, as for a transaction mined right as it was.
+type unseenReceiptClient struct {
+	*fakeAuctioneerClient
+	resolveCtx context.Context
+}
+
+func (c *unseenReceiptClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	if c.resolveCtx.Err() == nil {
+		return nil, ethereum.NotFound
+	}
+	return c.fakeAuctioneerClient.TransactionReceipt(ctx, txHash)
+}
+
+func TestResolutionMinedWhileWaitingFails(t *testing.T) {
+	t.Parallel()
+	chainId := big.NewInt(1)
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+	require.NoError(t, err)
+	txOpts.GasLimit = 1_000_000
+	a := &AuctioneerServer{
+		txOpts:   txOpts,
+		chainId:  chainId,
+		bidCache: newBidCache([32]byte{}),
+		roundTimingInfo: RoundTimingInfo{
+			// Bidding on round 1 is closed.
+			Offset:            time.Now().Add(-50 * time.Second),
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+	}
+	a.bidCache.add(&ValidatedBid{
+		ChainId:               chainId,
+		Bidder:                common.HexToAddress("0x1"),
+		ExpressLaneController: common.HexToAddress("0x1"),
+		Round:                 1,
+		Amount:                big.NewInt(10),
+		Signature:             make([]byte, 65),
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	client := &unseenReceiptClient{fakeAuctioneerClient: &fakeAuctioneerClient{baseFee: big.NewInt(1)}, resolveCtx: ctx}
+
+	var result *ResolutionResult
+	resolve := func(ctx context.Context) error {
+		var err error
+		result, err = a.resolveAuctionWithClient(ctx, client, true)
+		return err
+	}
+	require.NoError(t, a.resolveUpcomingRound(ctx, resolve))
+	require.Equal(t, RoundStatusResolved, result.Outcome)
+	require.Equal(t, client.submitted[0].Hash(), result.TxHash)
+	_, state := a.RoundState()
+	require.Equal(t, RoundStatusResolved, state)
+
+	// The round is not resolved again.
+	require.ErrorIs(t, a.resolveUpcomingRound(context.Background(), resolve), errRoundAlreadyResolved)
+	require.Len(t, client.submitted, 1)
+}
@@ -0,0 +1,52 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ResolutionResult describes what a resolution attempt did with its round.
+type ResolutionResult struct {
+	Round uint64
+	// Outcome is the status the round was left in, one of the RoundStatus
+	// values, e.g. RoundStatusResolved or RoundStatusSkipped.
+	Outcome RoundStatus
+	// FirstPlace and SecondPlace are the bids the round was resolved, or
+	// observed, with. SecondPlace is nil for a single bid.
+	FirstPlace  *ValidatedBid
+	SecondPlace *ValidatedBid
+	// TxHash, BlockNumber and GasUsed are only set for rounds resolved on
+	// chain.
+	TxHash      common.Hash
+	BlockNumber uint64
+	GasUsed     uint64
+	// ControlStart and ControlEnd bound the period the winner controls the
+	// express lane for, as emitted by the auction contract on resolution.
+	// Both are zero if the resolution's receipt holds no such event.
+	ControlStart time.Time
+	ControlEnd   time.Time
+	// ClearingPrice is the price the winner pays, see ClearingPrice.
+	ClearingPrice *big.Int
+	// BaseFee is the parent chain base fee of the latest header when the round
+	// was resolved, and EffectiveGasPrice the price per gas the resolution paid
+	// once mined. Either is nil if unknown, e.g. on chains without a base fee.
+	BaseFee           *big.Int
+	EffectiveGasPrice *big.Int
+}
+
+func newResolutionResult(round uint64, outcome RoundStatus, result *auctionResult) *ResolutionResult {
+	resolution := &ResolutionResult{
+		Round:   round,
+		Outcome: outcome,
+	}
+	if result != nil {
+		resolution.FirstPlace = result.firstPlace
+		resolution.SecondPlace = result.secondPlace
+	}
+	return resolution
+}
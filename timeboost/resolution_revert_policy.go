@@ -0,0 +1,91 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ResolutionRevertPolicy selects what the auctioneer does when the resolution
+// of a round reverts, which is blamed on the bidder whose bid won it, e.g. for
+// pulling its deposit between bidding and resolution.
+type ResolutionRevertPolicy uint8
+
+const (
+	// RevertCancel is the default policy. The round is left unresolved.
+	RevertCancel ResolutionRevertPolicy = iota
+	// RevertPromoteNext drops the bids of the winner and resolves the round
+	// again with the next best bids, as long as the round hasn't started.
+	RevertPromoteNext
+	// RevertHalt pauses the auctioneer and raises a critical alert, for
+	// operators who want to look into any revert before resolving more rounds.
+	// Resolutions resume once the auctioneer is resumed.
+	RevertHalt
+)
+
+// A revert caused by the contract itself rather than the winner would
+// otherwise drop every bid of the round one at a time.
+const maxRevertPromotions = 2
+
+func (p ResolutionRevertPolicy) String() string {
+	switch p {
+	case RevertCancel:
+		return "cancel"
+	case RevertPromoteNext:
+		return "promote-next"
+	case RevertHalt:
+		return "halt"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(p))
+	}
+}
+
+// WithResolutionRevertPolicy sets what to do when the resolution of a round
+// reverts. By default, the round is left unresolved.
+func WithResolutionRevertPolicy(policy ResolutionRevertPolicy) AuctioneerServerOpt {
+	return func(a *AuctioneerServer) {
+		a.revertPolicy = policy
+	}
+}
+
+// resolutionRevertError is the resolution of a round reverting, with the
+// bidder of the winning bid.
+type resolutionRevertError struct {
+	round  uint64
+	winner common.Address
+	err    error
+}
+
+func (e *resolutionRevertError) Error() string {
+	return e.err.Error()
+}
+
+func (e *resolutionRevertError) Unwrap() error {
+	return e.err
+}
+
+// handleResolutionRevert applies the resolution revert policy to the reverted
+// resolution of a round, after promoted earlier ones, and reports whether the
+// round must be resolved again.
+func (a *AuctioneerServer) handleResolutionRevert(revert *resolutionRevertError, promoted int) bool {
+	switch a.revertPolicy {
+	case RevertPromoteNext:
+		if promoted >= maxRevertPromotions {
+			log.Error("Auction resolution kept reverting, giving up on the round", "round", revert.round, "promoted", promoted, "err", revert.err)
+			return false
+		}
+		a.bidCache.remove(revert.winner)
+		log.Warn("Auction resolution reverted, resolving with the next best bids", "round", revert.round, "winner", revert.winner, "err", revert.err)
+		return true
+	case RevertHalt:
+		a.Pause()
+		log.Root().Write(log.LevelCrit, "Auction resolution reverted, halting auction resolutions until resumed", "round", revert.round, "winner", revert.winner, "err", revert.err)
+		return false
+	default:
+		return false
+	}
+}
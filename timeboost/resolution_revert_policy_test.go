@@ -0,0 +1,94 @@
+package timeboost
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestResolutionRevertPolicy(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	chainId := big.NewInt(1)
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+	require.NoError(t, err)
+	txOpts.GasLimit = 1_000_000
+	reverting, next, last := common.HexToAddress("0x1"), common.HexToAddress("0x2"), common.HexToAddress("0x3")
+	newAuctioneer := func(policy ResolutionRevertPolicy) *AuctioneerServer {
+		a := &AuctioneerServer{
+			txOpts:   txOpts,
+			chainId:  chainId,
+			bidCache: newBidCache([32]byte{}),
+			roundTimingInfo: RoundTimingInfo{
+				Offset:            time.Now(),
+				Round:             time.Minute,
+				AuctionClosing:    15 * time.Second,
+				ReserveSubmission: 15 * time.Second,
+			},
+		}
+		WithResolutionRevertPolicy(policy)(a)
+		for i, bidder := range []common.Address{reverting, next, last} {
+			a.bidCache.add(&ValidatedBid{
+				ChainId:               chainId,
+				Bidder:                bidder,
+				ExpressLaneController: bidder,
+				Round:                 1,
+				Amount:                big.NewInt(int64(30 - 10*i)),
+				Signature:             make([]byte, 65),
+			})
+		}
+		return a
+	}
+
+	t.Run("Cancel", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer(RevertCancel)
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1), revertBidders: []common.Address{reverting}}
+		_, err := a.resolveAuctionWithClient(ctx, client, true)
+		require.ErrorIs(t, err, errResolutionReverted)
+		require.Len(t, client.submitted, 1)
+		require.Equal(t, 3, a.bidCache.size())
+		require.False(t, a.Paused())
+	})
+
+	t.Run("PromoteNext", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer(RevertPromoteNext)
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1), revertBidders: []common.Address{reverting}}
+		result, err := a.resolveAuctionWithClient(ctx, client, true)
+		require.NoError(t, err)
+		require.Len(t, client.submitted, 2)
+		require.Equal(t, RoundStatusResolved, result.Outcome)
+		require.Equal(t, next, result.FirstPlace.Bidder)
+		require.Equal(t, last, result.SecondPlace.Bidder)
+	})
+
+	t.Run("PromoteNextGivesUp", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer(RevertPromoteNext)
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1), revertReceipts: true}
+		_, err := a.resolveAuctionWithClient(ctx, client, true)
+		require.ErrorIs(t, err, errResolutionReverted)
+		require.Len(t, client.submitted, maxRevertPromotions+1)
+		require.Equal(t, 1, a.bidCache.size())
+	})
+
+	t.Run("Halt", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer(RevertHalt)
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1), revertBidders: []common.Address{reverting}}
+		_, err := a.resolveAuctionWithClient(ctx, client, true)
+		require.ErrorIs(t, err, errResolutionReverted)
+		require.Len(t, client.submitted, 1)
+		require.True(t, a.Paused())
+	})
+}
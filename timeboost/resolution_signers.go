@@ -0,0 +1,26 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+// WithResolutionSigners resolves auctions from a pool of signer accounts
+// rather than from the configured wallet alone, so that a stuck nonce on one
+// account doesn't block every round. Rounds are assigned to signers round
+// robin, and all attempts at resolving a round use the same signer.
+func WithResolutionSigners(signers ...*bind.TransactOpts) AuctioneerServerOpt {
+	return func(a *AuctioneerServer) {
+		a.signers = signers
+	}
+}
+
+// resolutionSigner returns the signer that resolves the given round.
+func (a *AuctioneerServer) resolutionSigner(round uint64) *bind.TransactOpts {
+	if len(a.signers) == 0 {
+		return a.txOpts
+	}
+	return a.signers[round%uint64(len(a.signers))]
+}
@@ -0,0 +1,66 @@
+package timeboost
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestResolutionSigners(t *testing.T) {
+	t.Parallel()
+	chainId := big.NewInt(1)
+	newSigner := func() *bind.TransactOpts {
+		privKey, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+		require.NoError(t, err)
+		txOpts.GasLimit = 1_000_000
+		return txOpts
+	}
+	wallet := newSigner()
+	a := &AuctioneerServer{
+		txOpts:              wallet,
+		chainId:             chainId,
+		auctionContractAddr: common.HexToAddress("0x1234"),
+		bidCache:            newBidCache([32]byte{}),
+		roundTimingInfo: RoundTimingInfo{
+			Offset:            time.Now(),
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+	}
+	// Without a pool, the wallet resolves every round.
+	require.Equal(t, wallet, a.resolutionSigner(1))
+	require.Equal(t, wallet, a.resolutionSigner(2))
+
+	pool := []*bind.TransactOpts{newSigner(), newSigner(), newSigner()}
+	WithResolutionSigners(pool...)(a)
+	for round := uint64(0); round < 6; round++ {
+		require.Equal(t, pool[round%3], a.resolutionSigner(round))
+	}
+
+	a.bidCache.add(&ValidatedBid{
+		ChainId:               chainId,
+		Bidder:                common.HexToAddress("0x1"),
+		ExpressLaneController: common.HexToAddress("0x1"),
+		Round:                 1,
+		Amount:                big.NewInt(10),
+		Signature:             make([]byte, 65),
+	})
+	client := &fakeAuctioneerClient{baseFee: big.NewInt(1)}
+	_, err := a.resolveAuctionWithClient(context.Background(), client, true)
+	require.NoError(t, err)
+	require.Len(t, client.submitted, 1)
+	sender, err := types.Sender(types.LatestSignerForChainID(chainId), client.submitted[0])
+	require.NoError(t, err)
+	require.Equal(t, a.resolutionSigner(a.UpcomingRound()).From, sender)
+}
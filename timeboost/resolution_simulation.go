@@ -0,0 +1,102 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/offchainlabs/nitro/solgen/go/express_lane_auctiongen"
+)
+
+var errResolutionSimulationReverted = errors.New("auction resolution reverted when simulated")
+
+// pendingContractCaller is implemented by clients that can call contracts
+// against the pending state, such as ethclient.Client.
+type pendingContractCaller interface {
+	PendingCallContract(ctx context.Context, call ethereum.CallMsg) ([]byte, error)
+}
+
+// simulateResolution calls the auction contract with the exact resolution
+// transaction, against the pending state if the client supports it and the
+// latest state otherwise, so that a resolution that would revert isn't sent.
+// Gas estimation already simulates the resolution, but is skipped if the
+// signer has a fixed gas limit.
+func simulateResolution(ctx context.Context, client AuctioneerClient, from common.Address, tx *types.Transaction) error {
+	call := ethereum.CallMsg{
+		From:       from,
+		To:         tx.To(),
+		Gas:        tx.Gas(),
+		Value:      tx.Value(),
+		Data:       tx.Data(),
+		AccessList: tx.AccessList(),
+	}
+	var err error
+	if pending, ok := client.(pendingContractCaller); ok {
+		_, err = pending.PendingCallContract(ctx, call)
+	} else {
+		_, err = client.CallContract(ctx, call, nil)
+	}
+	return err
+}
+
+// checkResolutionSimulation simulates the resolution of the round, and returns
+// a *resolutionRevertError for the resolution revert policy to handle if it
+// reverts, or errRoundResolvedOnChain if the round was already resolved. Failing to simulate it otherwise doesn't hold up the resolution,
+// which is then sent as it would be without simulating it.
+func (a *AuctioneerServer) checkResolutionSimulation(ctx context.Context, client AuctioneerClient, round uint64, result *auctionResult, from common.Address, tx *types.Transaction) error {
+	err := simulateResolution(ctx, client, from, tx)
+	if err == nil {
+		return nil
+	}
+	if isRoundAlreadyResolvedError(err) {
+		return fmt.Errorf("%w: %w", errRoundResolvedOnChain, err)
+	}
+	if !isRevertError(err) {
+		log.Warn("Could not simulate auction resolution, sending it anyway", "round", round, "err", err)
+		return nil
+	}
+	log.Warn("Not sending auction resolution reverting when simulated", "round", round, "winner", result.firstPlace.Bidder, "reason", revertReason(err), "err", err)
+	a.attributeResolutionFailure(round, result, err)
+	return &resolutionRevertError{round: round, winner: result.firstPlace.Bidder, err: fmt.Errorf("%w: %w", errResolutionSimulationReverted, err)}
+}
+
+// revertReason decodes the revert data relayed with an RPC error, either as a
+// revert string or as the name of an auction contract error. It returns the
+// raw data if it can't be decoded, and nothing if the error carries none.
+func revertReason(err error) string {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return ""
+	}
+	hexData, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return ""
+	}
+	data, decodeErr := hexutil.Decode(hexData)
+	if decodeErr != nil || len(data) < 4 {
+		return hexData
+	}
+	if reason, unpackErr := abi.UnpackRevert(data); unpackErr == nil {
+		return reason
+	}
+	if contractAbi, abiErr := express_lane_auctiongen.ExpressLaneAuctionMetaData.GetAbi(); abiErr == nil {
+		for name, contractErr := range contractAbi.Errors {
+			if bytes.Equal(contractErr.ID[:4], data[:4]) {
+				return name
+			}
+		}
+	}
+	return hexData
+}
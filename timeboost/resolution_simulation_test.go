@@ -0,0 +1,131 @@
+package timeboost
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/offchainlabs/nitro/solgen/go/express_lane_auctiongen"
+)
+
+// revertDataError is a revert as relayed by the RPC of a node, with its data.
+type revertDataError struct {
+	data string
+}
+
+func (e *revertDataError) Error() string  { return vm.ErrExecutionReverted.Error() }
+func (e *revertDataError) ErrorCode() int { return 3 }
+func (e *revertDataError) ErrorData() any { return e.data }
+
+func revertWithReason(t *testing.T, reason string) *revertDataError {
+	stringType, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	packed, err := abi.Arguments{{Type: stringType}}.Pack(reason)
+	require.NoError(t, err)
+	return &revertDataError{data: hexutil.Encode(append(crypto.Keccak256([]byte("Error(string)"))[:4], packed...))}
+}
+
+func TestResolutionSimulation(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	chainId := big.NewInt(1)
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+	require.NoError(t, err)
+	txOpts.GasLimit = 1_000_000
+	reverting, next, last := common.HexToAddress("0x1"), common.HexToAddress("0x2"), common.HexToAddress("0x3")
+	newAuctioneer := func(policy ResolutionRevertPolicy) *AuctioneerServer {
+		a := &AuctioneerServer{
+			txOpts:   txOpts,
+			chainId:  chainId,
+			bidCache: newBidCache([32]byte{}),
+			roundTimingInfo: RoundTimingInfo{
+				Offset:            time.Now(),
+				Round:             time.Minute,
+				AuctionClosing:    15 * time.Second,
+				ReserveSubmission: 15 * time.Second,
+			},
+		}
+		WithResolutionRevertPolicy(policy)(a)
+		for i, bidder := range []common.Address{reverting, next, last} {
+			a.bidCache.add(&ValidatedBid{
+				ChainId:               chainId,
+				Bidder:                bidder,
+				ExpressLaneController: bidder,
+				Round:                 1,
+				Amount:                big.NewInt(int64(30 - 10*i)),
+				Signature:             make([]byte, 65),
+			})
+		}
+		return a
+	}
+	simulateRevertFor := func(bidder common.Address) func([]byte) error {
+		return func(data []byte) error {
+			if bytes.Contains(data, bidder.Bytes()) {
+				return revertWithReason(t, "insufficient balance")
+			}
+			return nil
+		}
+	}
+
+	t.Run("Cancel", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer(RevertCancel)
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1), simulate: simulateRevertFor(reverting)}
+		_, err := a.resolveAuctionWithClient(ctx, client, true)
+		require.ErrorIs(t, err, errResolutionSimulationReverted)
+		var revert *resolutionRevertError
+		require.ErrorAs(t, err, &revert)
+		require.Equal(t, reverting, revert.winner)
+		require.Empty(t, client.submitted)
+		require.Equal(t, 3, a.bidCache.size())
+	})
+
+	t.Run("PromoteNext", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer(RevertPromoteNext)
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1), simulate: simulateRevertFor(reverting)}
+		result, err := a.resolveAuctionWithClient(ctx, client, true)
+		require.NoError(t, err)
+		require.Len(t, client.submitted, 1)
+		require.Equal(t, next, result.FirstPlace.Bidder)
+		require.Equal(t, last, result.SecondPlace.Bidder)
+	})
+
+	t.Run("SimulationUnavailable", func(t *testing.T) {
+		t.Parallel()
+		a := newAuctioneer(RevertCancel)
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1), simulate: func([]byte) error { return errors.New("connection refused") }}
+		result, err := a.resolveAuctionWithClient(ctx, client, true)
+		require.NoError(t, err)
+		require.Len(t, client.submitted, 1)
+		require.Equal(t, RoundStatusResolved, result.Outcome)
+	})
+}
+
+func TestRevertReason(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, "insufficient balance", revertReason(revertWithReason(t, "insufficient balance")))
+	require.Equal(t, "0x1234", revertReason(&revertDataError{data: "0x1234"}))
+	require.Empty(t, revertReason(vm.ErrExecutionReverted))
+
+	// Custom errors of the auction contract are named.
+	contractAbi, err := express_lane_auctiongen.ExpressLaneAuctionMetaData.GetAbi()
+	require.NoError(t, err)
+	for name, contractErr := range contractAbi.Errors {
+		require.Equal(t, name, revertReason(&revertDataError{data: hexutil.Encode(contractErr.ID[:4])}))
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// resolutionSnapshot holds the bids the first attempt at resolving a round
+// picked. Resolutions of the round retried after it failed are made with the
+// very same bids, in the same order, rather than with whatever the bid cache
+// holds by then, as bids keep being cached while the round is closing.
+type resolutionSnapshot struct {
+	sync.Mutex
+	round  uint64
+	result *auctionResult
+}
+
+// of returns a copy of the bids snapshotted for the round, or nil if none were.
+func (s *resolutionSnapshot) of(round uint64) *auctionResult {
+	s.Lock()
+	defer s.Unlock()
+	if s.result == nil || s.round != round {
+		return nil
+	}
+	snapshot := *s.result
+	return &snapshot
+}
+
+func (s *resolutionSnapshot) take(round uint64, result *auctionResult) {
+	s.Lock()
+	defer s.Unlock()
+	snapshot := *result
+	s.round, s.result = round, &snapshot
+}
+
+func (s *resolutionSnapshot) reset() {
+	s.Lock()
+	defer s.Unlock()
+	s.round, s.result = 0, nil
+}
+
+// resolutionBids returns the bids to resolve the round with: those of its first
+// resolution attempt if there was one, or else its funded top two bids, which
+// are then snapshotted for the attempts that follow. The deposits backing the
+// snapshotted bids are not rechecked, the contract rejects the resolution
+// if need be.
+func (a *AuctioneerServer) resolutionBids(ctx context.Context, round uint64) *auctionResult {
+	if snapshot := a.resolutionSnapshot.of(round); snapshot != nil {
+		log.Info("Retrying auction resolution with the bids of its first attempt", "round", round, "bids", a.bidCache.size())
+		return snapshot
+	}
+	result := a.fundedTopTwoBids(ctx, round, a.getAuctionContract().BalanceOf)
+	if dropDuplicateBidder(round, result) {
+		a.getMetrics().duplicateBidder.Inc(1)
+	}
+	if result.firstPlace != nil {
+		a.resolutionSnapshot.take(round, result)
+	}
+	return result
+}
@@ -0,0 +1,84 @@
+package timeboost
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestResolutionRetryUsesSnapshot(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	chainId := big.NewInt(1)
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+	require.NoError(t, err)
+	txOpts.GasLimit = 1_000_000
+	a := &AuctioneerServer{
+		txOpts:   txOpts,
+		chainId:  chainId,
+		bidCache: newBidCache([32]byte{}),
+		roundTimingInfo: RoundTimingInfo{
+			// Bidding on round 1 is closed.
+			Offset:            time.Now().Add(-50 * time.Second),
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+	}
+	bid := func(bidder string, amount int64) *JsonValidatedBid {
+		return &JsonValidatedBid{
+			ChainId:               (*hexutil.Big)(chainId),
+			Bidder:                common.HexToAddress(bidder),
+			ExpressLaneController: common.HexToAddress(bidder),
+			Round:                 hexutil.Uint64(a.UpcomingRound()),
+			Amount:                (*hexutil.Big)(big.NewInt(amount)),
+			Signature:             make([]byte, 65),
+		}
+	}
+	resolve := func(client *fakeAuctioneerClient) (*ResolutionResult, error) {
+		var result *ResolutionResult
+		err := a.resolveUpcomingRound(ctx, func(ctx context.Context) error {
+			var err error
+			result, err = a.resolveAuctionWithClient(ctx, client, true)
+			return err
+		})
+		return result, err
+	}
+	a.receiveValidatedBid(bid("0x1", 20))
+	a.receiveValidatedBid(bid("0x2", 10))
+
+	// The first attempt fails, leaving the round closing.
+	client := &fakeAuctioneerClient{baseFee: big.NewInt(1), balance: big.NewInt(0)}
+	_, err = resolve(client)
+	require.ErrorIs(t, err, errSignerOutOfFunds)
+	require.Empty(t, client.submitted)
+	_, state := a.RoundState()
+	require.Equal(t, RoundStatusClosing, state)
+
+	// A higher bid is still cached in the meantime.
+	a.receiveValidatedBid(bid("0x3", 30))
+	require.Equal(t, 3, a.bidCache.size())
+
+	// Yet the retry resolves with the bids of the first attempt.
+	client.balance = nil
+	result, err := resolve(client)
+	require.NoError(t, err)
+	require.Equal(t, RoundStatusResolved, result.Outcome)
+	require.Equal(t, common.HexToAddress("0x1"), result.FirstPlace.Bidder)
+	require.Equal(t, common.HexToAddress("0x2"), result.SecondPlace.Bidder)
+	require.Len(t, client.submitted, 1)
+
+	// Closing the round discards the snapshot.
+	a.closeRound(a.UpcomingRound())
+	require.Nil(t, a.resolutionSnapshot.of(1))
+}
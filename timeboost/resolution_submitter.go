@@ -0,0 +1,62 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ResolutionSubmitter hands signed resolution transactions to whatever gets
+// them included, instead of the sequencer client.
+type ResolutionSubmitter interface {
+	SubmitAuctionResolutionTransaction(ctx context.Context, tx *types.Transaction) error
+}
+
+// WithResolutionSubmitter routes resolution transactions through the given
+// submitter, e.g. a private transaction relay. Only the submission is routed,
+// receipts and confirmations are still polled through the sequencer client,
+// which works the same for transactions that never were in a public mempool.
+func WithResolutionSubmitter(submitter ResolutionSubmitter) AuctioneerServerOpt {
+	return func(a *AuctioneerServer) {
+		a.resolutionSubmitter = submitter
+	}
+}
+
+// submitResolution submits the resolution through the configured submitter,
+// falling back to the client the round is resolved through.
+func (a *AuctioneerServer) submitResolution(ctx context.Context, client AuctioneerClient, tx *types.Transaction) error {
+	if a.resolutionSubmitter != nil {
+		return a.resolutionSubmitter.SubmitAuctionResolutionTransaction(ctx, tx)
+	}
+	return client.SubmitAuctionResolutionTransaction(ctx, tx)
+}
+
+// privateRelaySubmitter submits transactions to a Flashbots style private
+// transaction relay through eth_sendPrivateTransaction.
+type privateRelaySubmitter struct {
+	rpc *rpc.Client
+}
+
+type privateTransactionArgs struct {
+	Tx hexutil.Bytes `json:"tx"`
+}
+
+// NewPrivateRelaySubmitter returns a submitter sending resolution transactions
+// to the private transaction relay the client is connected to.
+func NewPrivateRelaySubmitter(client *rpc.Client) ResolutionSubmitter {
+	return &privateRelaySubmitter{rpc: client}
+}
+
+func (s *privateRelaySubmitter) SubmitAuctionResolutionTransaction(ctx context.Context, tx *types.Transaction) error {
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("encoding resolution transaction: %w", err)
+	}
+	return s.rpc.CallContext(ctx, nil, "eth_sendPrivateTransaction", privateTransactionArgs{Tx: raw})
+}
@@ -0,0 +1,88 @@
+package timeboost
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// fakePrivateRelay serves eth_sendPrivateTransaction. Transactions it accepts
+// are included on the chain of the client, without going through it.
+type fakePrivateRelay struct {
+	mutex  sync.Mutex
+	client *fakeAuctioneerClient
+	txs    []*types.Transaction
+}
+
+func (r *fakePrivateRelay) SendPrivateTransaction(args privateTransactionArgs) error {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(args.Tx); err != nil {
+		return err
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.txs = append(r.txs, tx)
+	r.client.submitted = append(r.client.submitted, tx)
+	return nil
+}
+
+func TestResolveThroughPrivateRelay(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainId := big.NewInt(1)
+	txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+	require.NoError(t, err)
+	txOpts.GasLimit = 1_000_000
+
+	client := &fakeAuctioneerClient{baseFee: big.NewInt(1)}
+	relay := &fakePrivateRelay{client: client}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", relay))
+	defer server.Stop()
+
+	a := &AuctioneerServer{
+		txOpts:              txOpts,
+		chainId:             chainId,
+		auctionContractAddr: common.HexToAddress("0x1234"),
+		bidCache:            newBidCache([32]byte{}),
+		roundTimingInfo: RoundTimingInfo{
+			Offset:            time.Now(),
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+	}
+	WithResolutionSubmitter(NewPrivateRelaySubmitter(rpc.DialInProc(server)))(a)
+	a.bidCache.add(&ValidatedBid{
+		ChainId:               chainId,
+		Bidder:                common.HexToAddress("0x1"),
+		ExpressLaneController: common.HexToAddress("0x1"),
+		Round:                 1,
+		Amount:                big.NewInt(10),
+		Signature:             make([]byte, 65),
+	})
+	_, err = a.resolveAuctionWithClient(ctx, client, true)
+	require.NoError(t, err)
+
+	// Submitted through the relay only, and its receipt found through the client.
+	require.Len(t, relay.txs, 1)
+	require.Len(t, client.submitted, 1)
+	require.Equal(t, a.auctionContractAddr, *relay.txs[0].To())
+	status, ok := a.RoundOutcome(1)
+	require.True(t, ok)
+	require.Equal(t, RoundStatusResolved, status)
+}
@@ -0,0 +1,169 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// AuctioneerAdminNamespace is the namespace of the auctioneer's admin API,
+// which is only served over the authenticated RPC endpoint.
+const AuctioneerAdminNamespace = "auctioneeradmin"
+
+var (
+	errRoundAlreadyResolved  = errors.New("auction round already resolved")
+	errRoundAlreadyResolving = errors.New("auction round already resolving")
+	errResolutionOverran     = errors.New("auction resolution overran the start of its round")
+	errTooEarlyToResolve     = errors.New("too early to resolve")
+)
+
+// ResolveNow resolves the upcoming round right away instead of waiting for the
+// auction close ticker, e.g. to recover from a failed resolution. Bidding for
+// the round must already be closed. The result describes what became of the
+// round, and is nil if resolving it failed. A round is only ever resolved by
+// one resolution at a time: if the ticker or another caller is resolving it
+// already, ResolveNow returns errRoundAlreadyResolving right away rather than
+// waiting to find it resolved.
+func (a *AuctioneerServer) ResolveNow(ctx context.Context) (*ResolutionResult, error) {
+	if round, state := a.RoundState(); state == RoundStatusResolving {
+		return nil, fmt.Errorf("%w: round %d", errRoundAlreadyResolving, round)
+	}
+	return a.resolveRound(ctx)
+}
+
+// resolveRound resolves the upcoming round, after any resolution of it in
+// progress, which the ticker must see through before closing the round.
+func (a *AuctioneerServer) resolveRound(ctx context.Context) (*ResolutionResult, error) {
+	var result *ResolutionResult
+	err := a.resolveUpcomingRound(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = a.resolveAuction(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	a.publishResult(result)
+	return result, nil
+}
+
+// resolveUpcomingRound serializes manual and ticker-driven resolutions, and
+// makes sure a round that was resolved successfully isn't resolved again.
+// Nothing is resolved while the auctioneer is paused, nor before bidding on the
+// round closes, which would cut off bids still allowed in. Rounds without bids
+// are no exception, as bids may still arrive for them. A failed resolution
+// leaves the round closing, and a cancelled one leaves it cancelled, so that
+// either can be retried. Bids still queued from the bid validators are cached
+// before the round is resolved, see drainReceivedBids. The resolution is
+// cancelled once the round starts, as the contract no longer resolves it then,
+// so that it can't hold up the rounds that follow.
+func (a *AuctioneerServer) resolveUpcomingRound(ctx context.Context, resolve func(context.Context) error) error {
+	a.resolutionLock.Lock()
+	defer a.resolutionLock.Unlock()
+	round := a.UpcomingRound()
+	if state := a.roundStates.stateOf(round); state == RoundStatusResolving || state == RoundStatusClosed || state.handled() {
+		return fmt.Errorf("%w: round %d is %s", errRoundAlreadyResolved, round, state)
+	}
+	roundTimingInfo := a.getRoundTimingInfo()
+	if time.Now().Before(a.resolvableAt(round)) {
+		return fmt.Errorf("%w: auction for round %d is still open", errTooEarlyToResolve, round)
+	}
+	if a.Paused() {
+		a.setRoundStatus(round, RoundStatusPaused)
+		return fmt.Errorf("%w: round %d", errAuctioneerPaused, round)
+	}
+	if drained := a.drainReceivedBids(); drained > 0 {
+		log.Info("Consumed queued bids before resolving round", "round", round, "bids", drained)
+	}
+	if err := a.roundStates.transition(round, RoundStatusResolving); err != nil {
+		return err
+	}
+	resolveCtx, cancel := context.WithDeadline(ctx, roundTimingInfo.roundStartTime(round))
+	err := resolve(resolveCtx)
+	overran := errors.Is(resolveCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil
+	cancel()
+	if err != nil {
+		if overran {
+			err = fmt.Errorf("%w: round %d: %w", errResolutionOverran, round, err)
+		}
+		if errors.Is(err, errResolutionCancelled) {
+			a.setRoundState(round, RoundStatusCancelled)
+		} else {
+			a.setRoundState(round, RoundStatusClosing)
+		}
+		if _, handled := a.RoundOutcome(round); !handled {
+			a.setRoundStatus(round, RoundStatusSkipped)
+		}
+		a.recordResolutionFailure(round, err)
+		return err
+	}
+	// The round is left with the outcome its resolution recorded.
+	outcome, ok := a.RoundOutcome(round)
+	if !ok || !outcome.handled() {
+		outcome = RoundStatusResolved
+	}
+	a.setRoundState(round, outcome)
+	a.recordResolutionSuccess(round)
+	a.lastResolutionTime.Store(time.Now().UnixNano())
+	return nil
+}
+
+// AuctioneerAdminAPI wraps rather than embeds the auctioneer, so that only the
+// methods below are exposed over RPC.
+type AuctioneerAdminAPI struct {
+	auctioneer *AuctioneerServer
+}
+
+func (api *AuctioneerAdminAPI) ResolveNow(ctx context.Context) error {
+	_, err := api.auctioneer.ResolveNow(ctx)
+	return err
+}
+
+// RegisterAPIs exposes the auctioneer over the RPC endpoints of the stack:
+//   - auctioneeradmin, on the authenticated endpoint only: resolveNow, pause,
+//     resume, paused, backfillHistory, effectiveConfig, committedValue and
+//     setAuctionContract.
+//   - auctioneer, the auction history and schedule: historySince, revenue,
+//     roundOutcome, roundState, roundToBlockRange and roundTimingInfo.
+//   - auctioneer, for bidders: bidderRanking for their own standing, and
+//     bidStats for the bids of the upcoming round in aggregate.
+func (a *AuctioneerServer) RegisterAPIs(stack *node.Node) {
+	stack.RegisterAPIs([]rpc.API{{
+		Namespace:     AuctioneerAdminNamespace,
+		Version:       "1.0",
+		Service:       &AuctioneerAdminAPI{a},
+		Public:        false,
+		Authenticated: true,
+	}, {
+		Namespace: AuctioneerNamespace,
+		Version:   "1.0",
+		Service:   &AuctioneerHistoryAPI{a},
+		Public:    true,
+	}, {
+		Namespace: AuctioneerNamespace,
+		Version:   "1.0",
+		Service:   &AuctioneerBidderAPI{a},
+		Public:    true,
+	}})
+}
+
+func EnsureAuctioneerAdminExposedViaAuthRPC(stackConf *node.Config) {
+	found := false
+	for _, module := range stackConf.AuthModules {
+		if module == AuctioneerAdminNamespace {
+			found = true
+			break
+		}
+	}
+	if !found {
+		stackConf.AuthModules = append(stackConf.AuthModules, AuctioneerAdminNamespace)
+	}
+}
@@ -0,0 +1,181 @@
+package timeboost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveUpcomingRound(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	a := &AuctioneerServer{
+		roundTimingInfo: RoundTimingInfo{
+			Offset:            time.Now().Add(-50 * time.Second),
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+	}
+	var resolutions atomic.Int64
+	resolve := func(context.Context) error {
+		resolutions.Add(1)
+		return nil
+	}
+
+	// A failed resolution can be retried.
+	require.Error(t, a.resolveUpcomingRound(ctx, func(context.Context) error { return errors.New("failed") }))
+	require.Zero(t, a.lastResolutionTime.Load())
+	status, ok := a.RoundOutcome(a.UpcomingRound())
+	require.True(t, ok)
+	require.Equal(t, RoundStatusSkipped, status)
+
+	// Concurrent manual and ticker-driven resolutions resolve the round once.
+	var wg sync.WaitGroup
+	var alreadyResolved atomic.Int64
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := a.resolveUpcomingRound(ctx, resolve); err != nil {
+				require.ErrorIs(t, err, errRoundAlreadyResolved)
+				alreadyResolved.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int64(1), resolutions.Load())
+	require.Equal(t, int64(4), alreadyResolved.Load())
+	require.NotZero(t, a.lastResolutionTime.Load())
+}
+
+func TestResolveNowRejectsOpenAuction(t *testing.T) {
+	t.Parallel()
+	a := &AuctioneerServer{
+		roundTimingInfo: RoundTimingInfo{
+			Offset:            time.Now(),
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+	}
+	_, err := a.ResolveNow(context.Background())
+	require.ErrorContains(t, err, "still open")
+
+	// Neither can a mis-scheduled ticker resolve the round early.
+	resolved := false
+	err = a.resolveUpcomingRound(context.Background(), func(context.Context) error {
+		resolved = true
+		return nil
+	})
+	require.ErrorIs(t, err, errTooEarlyToResolve)
+	require.False(t, resolved)
+	_, state := a.RoundState()
+	require.Equal(t, RoundStatusAcceptingBids, state)
+	_, handled := a.RoundOutcome(a.UpcomingRound())
+	require.False(t, handled)
+}
+
+func TestRoundStatusHistory(t *testing.T) {
+	t.Parallel()
+	a := &AuctioneerServer{}
+	_, ok := a.RoundOutcome(1)
+	require.False(t, ok)
+	for round := uint64(1); round <= roundStatusHistory+1; round++ {
+		a.setRoundStatus(round, RoundStatusResolved)
+	}
+	_, ok = a.RoundOutcome(1)
+	require.False(t, ok)
+	status, ok := a.RoundOutcome(2)
+	require.True(t, ok)
+	require.Equal(t, RoundStatusResolved, status)
+	require.Len(t, a.roundStatuses, roundStatusHistory)
+
+	// Skipping ahead prunes every round that fell out of the history.
+	a.setRoundStatus(3*roundStatusHistory, RoundStatusNoBids)
+	require.Len(t, a.roundStatuses, 1)
+}
+
+// A manual resolution fired while the ticker resolves the same round returns
+// right away, and the round is resolved once.
+func TestResolveNowWhileResolving(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	a := &AuctioneerServer{
+		roundTimingInfo: RoundTimingInfo{
+			Offset:            time.Now().Add(-50 * time.Second),
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+	}
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var resolutions atomic.Int64
+	done := make(chan error, 1)
+	go func() {
+		done <- a.resolveUpcomingRound(ctx, func(context.Context) error {
+			resolutions.Add(1)
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+
+	_, err := a.ResolveNow(ctx)
+	require.ErrorIs(t, err, errRoundAlreadyResolving)
+	require.ErrorContains(t, err, fmt.Sprintf("round %d", a.UpcomingRound()))
+
+	close(release)
+	require.NoError(t, <-done)
+	_, err = a.ResolveNow(ctx)
+	require.ErrorIs(t, err, errRoundAlreadyResolved)
+	require.ErrorContains(t, err, "is resolved")
+	require.Equal(t, int64(1), resolutions.Load())
+}
+
+// A resolution still running when its round starts is cancelled, leaving the
+// auctioneer free to resolve the next round.
+func TestResolutionOverrunsRound(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	a := &AuctioneerServer{
+		roundTimingInfo: RoundTimingInfo{
+			// The auction of round 1 closes for the last second of round 0.
+			Offset:         time.Now().Add(-1500 * time.Millisecond),
+			Round:          2 * time.Second,
+			AuctionClosing: time.Second,
+		},
+	}
+	overrunRound := a.UpcomingRound()
+	err := a.resolveUpcomingRound(ctx, func(ctx context.Context) error {
+		// Stuck, as if waiting for a resolution that is never mined.
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	require.ErrorIs(t, err, errResolutionOverran)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, overrunRound+1, a.UpcomingRound())
+	status, ok := a.RoundOutcome(overrunRound)
+	require.True(t, ok)
+	require.Equal(t, RoundStatusSkipped, status)
+
+	// The next round is resolved once its auction closes.
+	time.Sleep(time.Until(a.roundTimingInfo.roundStartTime(overrunRound + 1).Add(-a.roundTimingInfo.AuctionClosing)))
+	resolved := false
+	require.NoError(t, a.resolveUpcomingRound(ctx, func(ctx context.Context) error {
+		_, hasDeadline := ctx.Deadline()
+		resolved = hasDeadline && ctx.Err() == nil
+		return nil
+	}))
+	require.True(t, resolved)
+	_, state := a.RoundState()
+	require.Equal(t, RoundStatusResolved, state)
+}
@@ -0,0 +1,107 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// resultPublisherBuffer is the number of results the publisher may fall
+	// behind by before newer results are dropped.
+	resultPublisherBuffer = 64
+	// resultPublishAttempts is how many times publishing a result is tried
+	// before giving up on it.
+	resultPublishAttempts = 5
+	// resultPublishRetryInterval is the wait before the first retry, doubled
+	// after every failed attempt.
+	resultPublishRetryInterval = 500 * time.Millisecond
+)
+
+// ResultPublisher publishes what became of every round the auctioneer handled,
+// e.g. to a Kafka topic or NATS subject, for consumers outside its process.
+// The auctioneer does not depend on any transport, operators supply one.
+type ResultPublisher interface {
+	Publish(ctx context.Context, result ResolutionResult) error
+}
+
+// WithResultPublisher publishes the result of every round resolved, skipped or
+// otherwise handled, whether by the ticker or ResolveNow. Failed resolutions
+// leave no result to publish. Results are published from their own thread, in
+// order, and failures are retried with backoff, so that a slow or unavailable
+// queue never holds up resolutions. Results are dropped if the publisher falls
+// too far behind, or keeps failing.
+func WithResultPublisher(publisher ResultPublisher) AuctioneerServerOpt {
+	return func(a *AuctioneerServer) {
+		a.resultPublisher = &resultPublisher{
+			publisher:     publisher,
+			pending:       make(chan ResolutionResult, resultPublisherBuffer),
+			retryInterval: resultPublishRetryInterval,
+		}
+	}
+}
+
+type resultPublisher struct {
+	publisher     ResultPublisher
+	pending       chan ResolutionResult
+	retryInterval time.Duration
+}
+
+func (a *AuctioneerServer) startResultPublisher() {
+	if a.resultPublisher == nil {
+		return
+	}
+	a.StopWaiter.LaunchThread(a.resultPublisher.run)
+}
+
+func (p *resultPublisher) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case result := <-p.pending:
+			p.publish(ctx, result)
+		}
+	}
+}
+
+// publish tries publishing the result until it succeeds, runs out of attempts
+// or ctx is done.
+func (p *resultPublisher) publish(ctx context.Context, result ResolutionResult) {
+	retryInterval := p.retryInterval
+	for attempt := 1; ; attempt++ {
+		err := p.publisher.Publish(ctx, result)
+		if err == nil {
+			return
+		}
+		if attempt == resultPublishAttempts {
+			log.Error("Could not publish round result, dropping it", "round", result.Round, "outcome", result.Outcome, "attempts", attempt, "err", err)
+			return
+		}
+		log.Warn("Could not publish round result, retrying", "round", result.Round, "outcome", result.Outcome, "attempt", attempt, "err", err)
+		timer := time.NewTimer(retryInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		retryInterval *= 2
+	}
+}
+
+// publishResult queues the result of a round for publishing, if enabled.
+func (a *AuctioneerServer) publishResult(result *ResolutionResult) {
+	if a.resultPublisher == nil || result == nil {
+		return
+	}
+	select {
+	case a.resultPublisher.pending <- *result:
+	default:
+		log.Warn("Result publisher is falling behind, dropping round result", "round", result.Round, "outcome", result.Outcome)
+	}
+}
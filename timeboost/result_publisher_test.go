@@ -0,0 +1,112 @@
+package timeboost
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// memoryPublisher captures published results, failing the first attempts.
+type memoryPublisher struct {
+	sync.Mutex
+	failures  int
+	attempts  int
+	published []ResolutionResult
+	block     chan struct{}
+}
+
+func (p *memoryPublisher) Publish(ctx context.Context, result ResolutionResult) error {
+	if p.block != nil {
+		<-p.block
+	}
+	p.Lock()
+	defer p.Unlock()
+	p.attempts++
+	if p.attempts <= p.failures {
+		return errors.New("queue unavailable")
+	}
+	p.published = append(p.published, result)
+	return nil
+}
+
+func (p *memoryPublisher) results() []ResolutionResult {
+	p.Lock()
+	defer p.Unlock()
+	return append([]ResolutionResult(nil), p.published...)
+}
+
+func TestResultPublisher(t *testing.T) {
+	t.Parallel()
+	newAuctioneer := func(t *testing.T, publisher *memoryPublisher) *AuctioneerServer {
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		a := &AuctioneerServer{
+			roundTimingInfo: RoundTimingInfo{
+				// Bidding on round 1 is closed.
+				Offset:            time.Now().Add(-50 * time.Second),
+				Round:             time.Minute,
+				AuctionClosing:    15 * time.Second,
+				ReserveSubmission: 15 * time.Second,
+			},
+		}
+		WithResultPublisher(publisher)(a)
+		a.resultPublisher.retryInterval = time.Millisecond
+		a.StopWaiter.Start(ctx, a)
+		a.startResultPublisher()
+		t.Cleanup(a.StopAndWait)
+		return a
+	}
+
+	t.Run("PublishesResults", func(t *testing.T) {
+		t.Parallel()
+		publisher := &memoryPublisher{failures: resultPublishAttempts - 1}
+		a := newAuctioneer(t, publisher)
+		a.publishResult(newResolutionResult(1, RoundStatusNoBids, nil))
+		a.publishResult(newResolutionResult(2, RoundStatusSkipped, nil))
+		a.publishResult(nil)
+		require.Eventually(t, func() bool { return len(publisher.results()) == 2 }, 5*time.Second, time.Millisecond)
+		results := publisher.results()
+		require.Equal(t, uint64(1), results[0].Round)
+		require.Equal(t, RoundStatusNoBids, results[0].Outcome)
+		require.Equal(t, uint64(2), results[1].Round)
+		require.Equal(t, RoundStatusSkipped, results[1].Outcome)
+	})
+
+	t.Run("GivesUp", func(t *testing.T) {
+		t.Parallel()
+		publisher := &memoryPublisher{failures: resultPublishAttempts}
+		a := newAuctioneer(t, publisher)
+		a.publishResult(newResolutionResult(1, RoundStatusNoBids, nil))
+		a.publishResult(newResolutionResult(2, RoundStatusNoBids, nil))
+		require.Eventually(t, func() bool { return len(publisher.results()) == 1 }, 5*time.Second, time.Millisecond)
+		require.Equal(t, uint64(2), publisher.results()[0].Round)
+	})
+
+	t.Run("SlowQueueDoesNotBlock", func(t *testing.T) {
+		t.Parallel()
+		publisher := &memoryPublisher{block: make(chan struct{})}
+		a := newAuctioneer(t, publisher)
+		done := make(chan struct{})
+		go func() {
+			for round := uint64(0); round < resultPublisherBuffer*2; round++ {
+				a.publishResult(newResolutionResult(round, RoundStatusNoBids, nil))
+			}
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("publishing results blocked on a slow queue")
+		}
+		close(publisher.block)
+		// The results buffered, and the one being published if any, while the
+		// rest were dropped.
+		require.Eventually(t, func() bool { return len(publisher.results()) >= resultPublisherBuffer }, 5*time.Second, time.Millisecond)
+		time.Sleep(10 * time.Millisecond)
+		require.LessOrEqual(t, len(publisher.results()), resultPublisherBuffer+1)
+	})
+}
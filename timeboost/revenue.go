@@ -0,0 +1,92 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// CommittedValue returns what the auction earns from a round resolved with the
+// given bids, which is what the winner is charged, see ClearingPrice. Only the
+// winner pays, the runner-up only sets the price. It is zero for a round
+// without bids, and nil for a single bid round whose reserve price is unknown.
+func CommittedValue(first, second *ValidatedBid, reservePrice *big.Int) *big.Int {
+	if first == nil {
+		return new(big.Int)
+	}
+	return ClearingPrice(first, second, reservePrice)
+}
+
+// committedValue returns what the auction would earn from the upcoming round
+// if it was resolved with the cached top two bids now. A single bid pays the
+// reserve price it was validated against, which is final only once bidding on
+// the round has closed.
+func (bc *bidCache) committedValue() *big.Int {
+	result := bc.topTwoBids()
+	var reservePrice *big.Int
+	if result.firstPlace != nil {
+		reservePrice = result.firstPlace.ReservePrice
+	}
+	return CommittedValue(result.firstPlace, result.secondPlace, reservePrice)
+}
+
+// roundRevenue returns what the auction earned from a round left in the given
+// status with result: its committed value if the round was resolved on chain,
+// and zero otherwise, as nothing is charged for rounds that were skipped,
+// cancelled or only observed.
+func (a *AuctioneerServer) roundRevenue(status RoundStatus, result *auctionResult) *big.Int {
+	if status != RoundStatusResolved || result == nil {
+		return new(big.Int)
+	}
+	return CommittedValue(result.firstPlace, result.secondPlace, result.reservePrice)
+}
+
+// RevenueResult is the revenue of the auction over a range of rounds.
+type RevenueResult struct {
+	FromRound hexutil.Uint64 `json:"fromRound"`
+	ToRound   hexutil.Uint64 `json:"toRound"`
+	Revenue   *hexutil.Big   `json:"revenue"`
+	// ResolvedRounds is the number of rounds resolved in the range, of which
+	// UnknownRounds were recorded without a revenue, e.g. by earlier releases,
+	// and are left out of the revenue.
+	ResolvedRounds uint64 `json:"resolvedRounds"`
+	UnknownRounds  uint64 `json:"unknownRounds"`
+}
+
+// Revenue sums the revenue recorded in the auction history for the rounds
+// from fromRound to toRound, both included.
+func (a *AuctioneerServer) Revenue(fromRound, toRound uint64) (*RevenueResult, error) {
+	history, err := a.HistorySince(fromRound)
+	if err != nil {
+		return nil, err
+	}
+	revenue := new(big.Int)
+	result := &RevenueResult{FromRound: hexutil.Uint64(fromRound), ToRound: hexutil.Uint64(toRound)}
+	for _, outcome := range history {
+		if outcome.Round > toRound || outcome.Status != RoundStatusResolved {
+			continue
+		}
+		result.ResolvedRounds++
+		if outcome.Revenue == nil {
+			result.UnknownRounds++
+			continue
+		}
+		revenue.Add(revenue, outcome.Revenue.ToInt())
+	}
+	result.Revenue = (*hexutil.Big)(revenue)
+	return result, nil
+}
+
+func (api *AuctioneerHistoryAPI) Revenue(fromRound, toRound hexutil.Uint64) (*RevenueResult, error) {
+	return api.auctioneer.Revenue(uint64(fromRound), uint64(toRound))
+}
+
+// CommittedValue returns what the auction would earn from the upcoming round
+// if it was resolved with the bids received so far, or nil if unknown. It is
+// only exposed to admins, as it gives away the bids of an open auction.
+func (api *AuctioneerAdminAPI) CommittedValue() *hexutil.Big {
+	return (*hexutil.Big)(api.auctioneer.bidCache.committedValue())
+}
@@ -0,0 +1,103 @@
+package timeboost
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestCommittedValue(t *testing.T) {
+	t.Parallel()
+	first := &ValidatedBid{Bidder: common.HexToAddress("0x1"), ExpressLaneController: common.HexToAddress("0x1"), Round: 1, Amount: big.NewInt(20)}
+	second := &ValidatedBid{Bidder: common.HexToAddress("0x2"), ExpressLaneController: common.HexToAddress("0x2"), Round: 1, Amount: big.NewInt(10)}
+	reservePrice := big.NewInt(3)
+
+	require.Equal(t, new(big.Int), CommittedValue(nil, nil, reservePrice))
+	require.Equal(t, big.NewInt(10), CommittedValue(first, second, reservePrice))
+	require.Equal(t, big.NewInt(3), CommittedValue(first, nil, reservePrice))
+	require.Nil(t, CommittedValue(first, nil, nil))
+
+	// The cache values a single bid at the reserve price it was validated against.
+	bc := newBidCache([32]byte{})
+	require.Equal(t, new(big.Int), bc.committedValue())
+	single := *first
+	single.ReservePrice = reservePrice
+	bc.add(&single)
+	require.Equal(t, big.NewInt(3), bc.committedValue())
+	bc.add(second)
+	require.Equal(t, big.NewInt(10), bc.committedValue())
+	require.Equal(t, (*hexutil.Big)(big.NewInt(10)), (&AuctioneerAdminAPI{&AuctioneerServer{bidCache: bc}}).CommittedValue())
+}
+
+func TestRoundRevenue(t *testing.T) {
+	t.Parallel()
+	db, err := NewDatabase(t.TempDir())
+	require.NoError(t, err)
+	a := &AuctioneerServer{}
+	WithAuctionHistory(db)(a)
+	var audit bytes.Buffer
+	WithAuditSink(&audit)(a)
+	a.bidCache = newBidCache([32]byte{})
+	bid := func(controller string, amount int64) *ValidatedBid {
+		return &ValidatedBid{
+			ExpressLaneController: common.HexToAddress(controller),
+			Bidder:                common.HexToAddress(controller),
+			Amount:                big.NewInt(amount),
+		}
+	}
+	twoBids := &auctionResult{firstPlace: bid("0x1", 20), secondPlace: bid("0x2", 10)}
+	singleBid := &auctionResult{firstPlace: bid("0x1", 7), reservePrice: big.NewInt(4)}
+
+	for _, test := range []struct {
+		status  RoundStatus
+		result  *auctionResult
+		revenue *big.Int
+	}{
+		{RoundStatusResolved, twoBids, big.NewInt(10)},
+		{RoundStatusResolved, singleBid, big.NewInt(4)},
+		// The reserve price it was resolved at is unknown.
+		{RoundStatusResolved, &auctionResult{firstPlace: bid("0x1", 7)}, nil},
+		{RoundStatusCancelled, twoBids, new(big.Int)},
+		{RoundStatusObserved, twoBids, new(big.Int)},
+		{RoundStatusSkipped, singleBid, new(big.Int)},
+		{RoundStatusNoBids, &auctionResult{}, new(big.Int)},
+	} {
+		require.Equal(t, test.revenue, a.roundRevenue(test.status, test.result), test.status)
+	}
+
+	a.recordRoundOutcome(1, RoundStatusResolved, twoBids, 2, common.Hash{1}, nil)
+	a.recordRoundOutcome(2, RoundStatusCancelled, twoBids, 2, common.Hash{2}, nil)
+	a.recordRoundOutcome(3, RoundStatusResolved, singleBid, 1, common.Hash{3}, nil)
+	a.recordRoundOutcome(4, RoundStatusObserved, twoBids, 2, common.Hash{}, nil)
+	a.recordRoundOutcome(5, RoundStatusResolved, &auctionResult{firstPlace: bid("0x1", 7)}, 1, common.Hash{5}, nil)
+	a.recordRoundOutcome(6, RoundStatusResolved, twoBids, 2, common.Hash{6}, nil)
+
+	history, err := a.HistorySince(0)
+	require.NoError(t, err)
+	require.Len(t, history, 6)
+	require.Equal(t, (*hexutil.Big)(big.NewInt(10)), history[0].Revenue)
+	require.Zero(t, history[1].Revenue.ToInt().Sign())
+	require.Equal(t, (*hexutil.Big)(big.NewInt(4)), history[2].Revenue)
+	require.Zero(t, history[3].Revenue.ToInt().Sign())
+	require.Nil(t, history[4].Revenue)
+
+	revenue, err := (&AuctioneerHistoryAPI{a}).Revenue(1, 5)
+	require.NoError(t, err)
+	require.Equal(t, &RevenueResult{
+		FromRound:      1,
+		ToRound:        5,
+		Revenue:        (*hexutil.Big)(big.NewInt(14)),
+		ResolvedRounds: 3,
+		UnknownRounds:  1,
+	}, revenue)
+
+	a.emitAuditRecord(1, RoundStatusResolved, twoBids, common.Hash{1}, nil)
+	require.Equal(t, (*hexutil.Big)(big.NewInt(10)), (<-a.auditor.pending).Revenue)
+	a.emitAuditRecord(4, RoundStatusObserved, twoBids, common.Hash{}, nil)
+	require.Equal(t, (*hexutil.Big)(new(big.Int)), (<-a.auditor.pending).Revenue)
+}
@@ -0,0 +1,85 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/offchainlabs/nitro/util/arbmath"
+)
+
+// BlockRange is a range of blocks, both ends included.
+type BlockRange struct {
+	First uint64
+	Last  uint64
+}
+
+// RoundToBlockRange maps the wall-clock interval of a round onto the blocks
+// the sequencer produces during it, extrapolated from the reference block
+// assuming a block every blockTime. Rounds are timed by the clock, while the
+// express lane applies to blocks, so the range is only as accurate as the
+// sequencer is regular. Blocks before the reference one are assumed to have
+// been produced at the same pace.
+func (info *RoundTimingInfo) RoundToBlockRange(round uint64, reference *types.Header, blockTime time.Duration) (BlockRange, error) {
+	if blockTime <= 0 {
+		return BlockRange{}, fmt.Errorf("block time must be positive, got %v", blockTime)
+	}
+	roundStart := info.roundStartTime(round)
+	// #nosec G115
+	referenceTime := time.Unix(int64(reference.Time), 0)
+	referenceBlock := reference.Number.Int64()
+	// The first block is the first one on or after the start of the round,
+	// and the last one the last before the start of the next round.
+	first := referenceBlock + ceilDiv(roundStart.Sub(referenceTime), blockTime)
+	last := referenceBlock + ceilDiv(roundStart.Add(info.Round).Sub(referenceTime), blockTime) - 1
+	if last < 0 || last < first {
+		return BlockRange{}, fmt.Errorf("no block estimated to be produced in round %d", round)
+	}
+	return BlockRange{First: arbmath.SaturatingUCast[uint64](max(first, 0)), Last: arbmath.SaturatingUCast[uint64](last)}, nil
+}
+
+func ceilDiv(d, unit time.Duration) int64 {
+	blocks := int64(d / unit)
+	if d > 0 && d%unit != 0 {
+		blocks++
+	}
+	return blocks
+}
+
+// RoundToBlockRange estimates the blocks the given round covers from the
+// latest block of the sequencer and its configured block time.
+func (a *AuctioneerServer) RoundToBlockRange(ctx context.Context, round uint64) (BlockRange, error) {
+	sequencerRpc, _, err := a.endpointManager.GetSequencerRPC(ctx)
+	if err != nil {
+		return BlockRange{}, fmt.Errorf("failed to get sequencer RPC: %w", err)
+	}
+	return a.roundToBlockRangeWithClient(ctx, newSequencerClient(sequencerRpc), round)
+}
+
+func (a *AuctioneerServer) roundToBlockRangeWithClient(ctx context.Context, client AuctioneerClient, round uint64) (BlockRange, error) {
+	header, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return BlockRange{}, err
+	}
+	return a.getRoundTimingInfo().RoundToBlockRange(round, header, a.sequencerBlockTime)
+}
+
+// BlockRangeResult is the RPC representation of a BlockRange.
+type BlockRangeResult struct {
+	First hexutil.Uint64 `json:"first"`
+	Last  hexutil.Uint64 `json:"last"`
+}
+
+func (api *AuctioneerHistoryAPI) RoundToBlockRange(ctx context.Context, round hexutil.Uint64) (*BlockRangeResult, error) {
+	blocks, err := api.auctioneer.RoundToBlockRange(ctx, uint64(round))
+	if err != nil {
+		return nil, err
+	}
+	return &BlockRangeResult{First: hexutil.Uint64(blocks.First), Last: hexutil.Uint64(blocks.Last)}, nil
+}
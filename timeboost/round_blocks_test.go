@@ -0,0 +1,62 @@
+package timeboost
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestRoundToBlockRange(t *testing.T) {
+	t.Parallel()
+	info := &RoundTimingInfo{
+		Offset:            time.Unix(1_000, 0),
+		Round:             time.Minute,
+		AuctionClosing:    15 * time.Second,
+		ReserveSubmission: 15 * time.Second,
+	}
+	header := func(number, timestamp uint64) *types.Header {
+		return &types.Header{Number: new(big.Int).SetUint64(number), Time: timestamp}
+	}
+
+	tests := []struct {
+		name      string
+		round     uint64
+		reference *types.Header
+		blockTime time.Duration
+		expected  BlockRange
+		err       string
+	}{
+		{name: "current round", round: 0, reference: header(100, 1_000), blockTime: 250 * time.Millisecond, expected: BlockRange{100, 339}},
+		{name: "later round", round: 2, reference: header(100, 1_000), blockTime: 250 * time.Millisecond, expected: BlockRange{580, 819}},
+		{name: "one second blocks", round: 2, reference: header(100, 1_000), blockTime: time.Second, expected: BlockRange{220, 279}},
+		{name: "uneven block time", round: 1, reference: header(100, 1_000), blockTime: 7 * time.Second, expected: BlockRange{109, 117}},
+		{name: "reference mid round", round: 1, reference: header(100, 1_090), blockTime: time.Second, expected: BlockRange{70, 129}},
+		{name: "earlier round", round: 1, reference: header(1_000, 1_180), blockTime: time.Second, expected: BlockRange{880, 939}},
+		{name: "before first block", round: 0, reference: header(10, 1_180), blockTime: time.Second, err: "no block"},
+		{name: "blocks slower than rounds", round: 1, reference: header(0, 1_000), blockTime: 2 * time.Minute, err: "no block"},
+		{name: "zero block time", round: 1, reference: header(0, 1_000), blockTime: 0, err: "must be positive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocks, err := info.RoundToBlockRange(tt.round, tt.reference, tt.blockTime)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, blocks)
+		})
+	}
+
+	// The auctioneer extrapolates from the latest block of the sequencer.
+	a := &AuctioneerServer{roundTimingInfo: *info, sequencerBlockTime: time.Second}
+	client := &fakeAuctioneerClient{head: 100}
+	blocks, err := a.roundToBlockRangeWithClient(context.Background(), client, 0)
+	require.NoError(t, err)
+	require.Equal(t, BlockRange{First: 1_100, Last: 1_159}, blocks)
+}
@@ -0,0 +1,121 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// RoundReceiptVersion is the version of the RoundReceipt schema. Fields are
+// only ever added to a version, never changed or removed.
+const RoundReceiptVersion = 1
+
+// WithRoundReceipts writes a RoundReceipt for every round resolved on chain to
+// dir, as round-<round>.json, the authoritative local record of the round to
+// reconcile with the chain. Receipts are written to a temporary file first and
+// renamed into place, so that a crash never leaves a partial receipt behind.
+func WithRoundReceipts(dir string) AuctioneerServerOpt {
+	return func(a *AuctioneerServer) {
+		a.roundReceiptDir = dir
+	}
+}
+
+// RoundReceipt is the record of a round resolved on chain.
+type RoundReceipt struct {
+	Version     int              `json:"version"`
+	Round       uint64           `json:"round"`
+	Outcome     RoundStatus      `json:"outcome"`
+	FirstPlace  *RoundReceiptBid `json:"firstPlace"`
+	SecondPlace *RoundReceiptBid `json:"secondPlace"`
+	// ClearingPrice is null if unknown, see ClearingPrice.
+	ClearingPrice *hexutil.Big `json:"clearingPrice"`
+	TxHash        common.Hash  `json:"txHash"`
+	BlockNumber   uint64       `json:"blockNumber"`
+	GasUsed       uint64       `json:"gasUsed"`
+}
+
+// RoundReceiptBid is a bid a round was resolved with.
+type RoundReceiptBid struct {
+	Bidder                common.Address `json:"bidder"`
+	ExpressLaneController common.Address `json:"expressLaneController"`
+	Amount                *hexutil.Big   `json:"amount"`
+}
+
+func newRoundReceiptBid(bid *ValidatedBid) *RoundReceiptBid {
+	if bid == nil {
+		return nil
+	}
+	return &RoundReceiptBid{
+		Bidder:                bid.Bidder,
+		ExpressLaneController: bid.ExpressLaneController,
+		Amount:                (*hexutil.Big)(bid.Amount),
+	}
+}
+
+func newRoundReceipt(resolution *ResolutionResult) *RoundReceipt {
+	return &RoundReceipt{
+		Version:       RoundReceiptVersion,
+		Round:         resolution.Round,
+		Outcome:       resolution.Outcome,
+		FirstPlace:    newRoundReceiptBid(resolution.FirstPlace),
+		SecondPlace:   newRoundReceiptBid(resolution.SecondPlace),
+		ClearingPrice: (*hexutil.Big)(resolution.ClearingPrice),
+		TxHash:        resolution.TxHash,
+		BlockNumber:   resolution.BlockNumber,
+		GasUsed:       resolution.GasUsed,
+	}
+}
+
+func roundReceiptPath(dir string, round uint64) string {
+	return filepath.Join(dir, fmt.Sprintf("round-%d.json", round))
+}
+
+// writeRoundReceipt writes the receipt of a resolved round, if enabled. Failing
+// to write it doesn't affect the resolution.
+func (a *AuctioneerServer) writeRoundReceipt(resolution *ResolutionResult) {
+	if a.roundReceiptDir == "" {
+		return
+	}
+	if err := writeRoundReceiptFile(a.roundReceiptDir, newRoundReceipt(resolution)); err != nil {
+		log.Error("Could not write round receipt", "round", resolution.Round, "dir", a.roundReceiptDir, "err", err)
+	}
+}
+
+func writeRoundReceiptFile(dir string, receipt *RoundReceipt) error {
+	encoded, err := json.MarshalIndent(receipt, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	// Created next to the receipt, so that renaming it is atomic.
+	tmp, err := os.CreateTemp(dir, fmt.Sprintf(".round-%d-*.json.tmp", receipt.Round))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Only left behind if renaming it failed.
+		_ = os.Remove(tmp.Name())
+	}()
+	if _, err := tmp.Write(append(encoded, '\n')); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), roundReceiptPath(dir, receipt.Round))
+}
@@ -0,0 +1,95 @@
+package timeboost
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestRoundReceipts(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	chainId := big.NewInt(1)
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+	require.NoError(t, err)
+	txOpts.GasLimit = 1_000_000
+	a := &AuctioneerServer{
+		txOpts:   txOpts,
+		chainId:  chainId,
+		bidCache: newBidCache([32]byte{}),
+		roundTimingInfo: RoundTimingInfo{
+			Offset:            time.Now(),
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+	}
+	WithRoundReceipts(dir)(a)
+	for i, amount := range []int64{20, 10} {
+		bidder := common.BigToAddress(big.NewInt(int64(i + 1)))
+		a.bidCache.add(&ValidatedBid{
+			ChainId:               chainId,
+			Bidder:                bidder,
+			ExpressLaneController: bidder,
+			Round:                 1,
+			Amount:                big.NewInt(amount),
+			Signature:             make([]byte, 65),
+		})
+	}
+	resolution, err := a.resolveAuctionWithClient(context.Background(), &fakeAuctioneerClient{baseFee: big.NewInt(1)}, true)
+	require.NoError(t, err)
+
+	encoded, err := os.ReadFile(roundReceiptPath(dir, 1))
+	require.NoError(t, err)
+	var receipt RoundReceipt
+	require.NoError(t, json.Unmarshal(encoded, &receipt))
+	require.Equal(t, RoundReceipt{
+		Version: RoundReceiptVersion,
+		Round:   1,
+		Outcome: RoundStatusResolved,
+		FirstPlace: &RoundReceiptBid{
+			Bidder:                common.BigToAddress(big.NewInt(1)),
+			ExpressLaneController: common.BigToAddress(big.NewInt(1)),
+			Amount:                (*hexutil.Big)(big.NewInt(20)),
+		},
+		SecondPlace: &RoundReceiptBid{
+			Bidder:                common.BigToAddress(big.NewInt(2)),
+			ExpressLaneController: common.BigToAddress(big.NewInt(2)),
+			Amount:                (*hexutil.Big)(big.NewInt(10)),
+		},
+		ClearingPrice: (*hexutil.Big)(big.NewInt(10)),
+		TxHash:        resolution.TxHash,
+		BlockNumber:   1,
+		GasUsed:       resolution.GasUsed,
+	}, receipt)
+
+	// Rewriting a receipt replaces it whole, and leaves no temporary file.
+	require.NoError(t, writeRoundReceiptFile(dir, &RoundReceipt{Version: RoundReceiptVersion, Round: 1, Outcome: RoundStatusResolved}))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	encoded, err = os.ReadFile(roundReceiptPath(dir, 1))
+	require.NoError(t, err)
+	receipt = RoundReceipt{}
+	require.NoError(t, json.Unmarshal(encoded, &receipt))
+	require.Nil(t, receipt.FirstPlace)
+
+	// The schema is stable, every field is always present.
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(encoded, &fields))
+	for _, field := range []string{"version", "round", "outcome", "firstPlace", "secondPlace", "clearingPrice", "txHash", "blockNumber", "gasUsed"} {
+		require.Contains(t, fields, field)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var errInvalidRoundTransition = errors.New("invalid auction round state transition")
+
+// roundStateTransitions lists the statuses each status of the round being
+// auctioned may be followed by. A resolution leaves the round with the outcome
+// recorded for it, unless it failed.
+var roundStateTransitions = map[RoundStatus][]RoundStatus{
+	// A round may be resolved manually before the close ticker fires.
+	RoundStatusAcceptingBids: {RoundStatusClosing, RoundStatusResolving, RoundStatusClosed},
+	RoundStatusClosing:       {RoundStatusResolving, RoundStatusClosed},
+	RoundStatusResolving: {
+		RoundStatusClosing, RoundStatusCancelled, RoundStatusClosed,
+		RoundStatusResolved, RoundStatusSkipped, RoundStatusNoBids, RoundStatusObserved,
+	},
+	RoundStatusResolved:  {RoundStatusClosed},
+	RoundStatusSkipped:   {RoundStatusClosed},
+	RoundStatusNoBids:    {RoundStatusClosed},
+	RoundStatusObserved:  {RoundStatusClosed},
+	RoundStatusCancelled: {RoundStatusResolving, RoundStatusClosed},
+}
+
+// acceptsBids reports whether bids for a round in this status are cached.
+func (s RoundStatus) acceptsBids() bool {
+	return s == RoundStatusAcceptingBids || s == RoundStatusClosing
+}
+
+// roundStateMachine tracks the state of the round being auctioned. Earlier
+// rounds are closed, later rounds are accepting bids. The zero value is ready
+// to use.
+type roundStateMachine struct {
+	sync.Mutex
+	round uint64
+	state RoundStatus
+}
+
+func (m *roundStateMachine) stateOf(round uint64) RoundStatus {
+	m.Lock()
+	defer m.Unlock()
+	return m.stateOfLocked(round)
+}
+
+func (m *roundStateMachine) stateOfLocked(round uint64) RoundStatus {
+	switch {
+	case round < m.round:
+		return RoundStatusClosed
+	case round > m.round || m.state == "":
+		return RoundStatusAcceptingBids
+	default:
+		return m.state
+	}
+}
+
+// transition moves the round to the given state, if its current state may be
+// followed by it. Closing a round starts tracking the next one.
+func (m *roundStateMachine) transition(round uint64, to RoundStatus) error {
+	m.Lock()
+	defer m.Unlock()
+	from := m.stateOfLocked(round)
+	if !slices.Contains(roundStateTransitions[from], to) {
+		return fmt.Errorf("%w: round %d from %s to %s", errInvalidRoundTransition, round, from, to)
+	}
+	if to == RoundStatusClosed {
+		m.round, m.state = round+1, RoundStatusAcceptingBids
+	} else {
+		m.round, m.state = round, to
+	}
+	return nil
+}
+
+// setRoundState moves the round to the given state, logging rather than
+// failing on an invalid transition, which would be a bug in the auctioneer.
+func (a *AuctioneerServer) setRoundState(round uint64, state RoundStatus) {
+	if err := a.roundStates.transition(round, state); err != nil {
+		log.Error("Auction round state out of sync", "err", err)
+	}
+}
+
+// markRoundClosing marks the round as closing when bidding on it closes,
+// unless it was already resolved manually.
+func (a *AuctioneerServer) markRoundClosing(round uint64) {
+	if a.roundStates.stateOf(round) == RoundStatusAcceptingBids {
+		a.setRoundState(round, RoundStatusClosing)
+	}
+}
+
+// RoundState returns the upcoming round and where its auction stands.
+func (a *AuctioneerServer) RoundState() (uint64, RoundStatus) {
+	round := a.UpcomingRound()
+	return round, a.roundStates.stateOf(round)
+}
+
+// RoundStateResult is the RPC representation of RoundState.
+type RoundStateResult struct {
+	Round hexutil.Uint64 `json:"round"`
+	State RoundStatus    `json:"state"`
+}
+
+func (api *AuctioneerHistoryAPI) RoundState() *RoundStateResult {
+	round, state := api.auctioneer.RoundState()
+	return &RoundStateResult{Round: hexutil.Uint64(round), State: state}
+}
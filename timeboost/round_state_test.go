@@ -0,0 +1,94 @@
+package timeboost
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestRoundStateMachine(t *testing.T) {
+	t.Parallel()
+	var m roundStateMachine
+	require.Equal(t, RoundStatusAcceptingBids, m.stateOf(3))
+
+	require.NoError(t, m.transition(3, RoundStatusClosing))
+	require.ErrorIs(t, m.transition(3, RoundStatusAcceptingBids), errInvalidRoundTransition)
+	require.NoError(t, m.transition(3, RoundStatusResolving))
+	require.ErrorIs(t, m.transition(3, RoundStatusResolving), errInvalidRoundTransition)
+	require.NoError(t, m.transition(3, RoundStatusCancelled))
+	require.NoError(t, m.transition(3, RoundStatusResolving))
+	require.NoError(t, m.transition(3, RoundStatusResolved))
+	require.ErrorIs(t, m.transition(3, RoundStatusResolving), errInvalidRoundTransition)
+	require.Equal(t, RoundStatusResolved, m.stateOf(3))
+	require.Equal(t, RoundStatusAcceptingBids, m.stateOf(4))
+
+	require.NoError(t, m.transition(3, RoundStatusClosed))
+	require.Equal(t, RoundStatusClosed, m.stateOf(2))
+	require.Equal(t, RoundStatusClosed, m.stateOf(3))
+	require.Equal(t, RoundStatusAcceptingBids, m.stateOf(4))
+	require.ErrorIs(t, m.transition(3, RoundStatusClosed), errInvalidRoundTransition)
+}
+
+func TestRoundStateDuringResolution(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	a := &AuctioneerServer{
+		bidCache: newBidCache([32]byte{}),
+		roundTimingInfo: RoundTimingInfo{
+			Offset:            time.Now().Add(-50 * time.Second),
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+	}
+	round := a.UpcomingRound()
+	bid := func(controller string) *JsonValidatedBid {
+		return (&ValidatedBid{
+			ChainId:               big.NewInt(1),
+			Bidder:                common.HexToAddress(controller),
+			ExpressLaneController: common.HexToAddress(controller),
+			Round:                 round,
+			Amount:                big.NewInt(10),
+			Signature:             make([]byte, 65),
+		}).ToJson()
+	}
+
+	// Bids validated before the close are still cached while closing.
+	a.markRoundClosing(round)
+	a.receiveValidatedBid(bid("0x1"))
+	require.Equal(t, 1, a.bidCache.size())
+
+	// But not once the resolution started.
+	require.Error(t, a.resolveUpcomingRound(ctx, func(context.Context) error {
+		_, state := a.RoundState()
+		require.Equal(t, RoundStatusResolving, state)
+		a.receiveValidatedBid(bid("0x2"))
+		return errors.New("failed")
+	}))
+	require.Equal(t, 1, a.bidCache.size())
+
+	// A failed resolution leaves the round closing, to be retried.
+	_, state := a.RoundState()
+	require.Equal(t, RoundStatusClosing, state)
+	// The failure was recorded as skipping the round, which the retry resolving
+	// it overrides, and the round is left with the recorded outcome.
+	outcome, _ := a.RoundOutcome(round)
+	require.Equal(t, RoundStatusSkipped, outcome)
+	require.NoError(t, a.resolveUpcomingRound(ctx, func(context.Context) error {
+		a.setRoundStatus(round, RoundStatusResolved)
+		return nil
+	}))
+	_, state = a.RoundState()
+	require.Equal(t, RoundStatusResolved, state)
+	require.ErrorIs(t, a.resolveUpcomingRound(ctx, func(context.Context) error { return nil }), errRoundAlreadyResolved)
+
+	a.closeRound(round)
+	require.Equal(t, RoundStatusClosed, a.roundStates.stateOf(round))
+	require.Equal(t, RoundStatusAcceptingBids, a.roundStates.stateOf(round+1))
+}
@@ -0,0 +1,97 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// RoundStatus is where the auction for a round stands in the auctioneer. A
+// round accepts bids until bidding on it closes, is resolved, and is left with
+// one of the outcomes recorded for it, see RoundOutcome, until it is closed.
+type RoundStatus string
+
+const (
+	// RoundStatusAcceptingBids is a round whose bids are cached.
+	RoundStatusAcceptingBids RoundStatus = "accepting-bids"
+	// RoundStatusClosing is a round whose bidding closed. Bids validated before
+	// the close are still cached until the resolution starts.
+	RoundStatusClosing RoundStatus = "closing"
+	// RoundStatusResolving is a round being resolved, whose bids are no longer
+	// cached. A failed resolution goes back to closing, to be retried.
+	RoundStatusResolving RoundStatus = "resolving"
+	// RoundStatusClosed is a round whose bids were cleared from the cache,
+	// which nothing happens to anymore.
+	RoundStatusClosed RoundStatus = "closed"
+
+	// RoundStatusResolved is a round resolved on chain.
+	RoundStatusResolved RoundStatus = "resolved"
+	// RoundStatusCancelled is a round whose resolution was cancelled, see
+	// cancelInFlightResolution. It may be resolved again.
+	RoundStatusCancelled RoundStatus = "cancelled"
+	// RoundStatusSkipped is a round that had bids but wasn't resolved, e.g.
+	// because there were too few bids or the resolution failed or was too late.
+	RoundStatusSkipped RoundStatus = "skipped"
+	RoundStatusNoBids  RoundStatus = "no-bids"
+	// RoundStatusObserved is a round an auctioneer in observer mode would have
+	// resolved, had it been allowed to send transactions.
+	RoundStatusObserved RoundStatus = "observed"
+	// RoundStatusPaused is a round that closed while the auctioneer was paused.
+	RoundStatusPaused RoundStatus = "paused"
+)
+
+// handled reports whether the status is the outcome of a round that nothing
+// is left to resolve for.
+func (s RoundStatus) handled() bool {
+	switch s {
+	case RoundStatusResolved, RoundStatusSkipped, RoundStatusNoBids, RoundStatusObserved:
+		return true
+	default:
+		return false
+	}
+}
+
+// roundStatusHistory is the number of most recent rounds whose status is kept.
+const roundStatusHistory = 1024
+
+// setRoundStatus records the final disposition of a round, forgetting the
+// status of rounds too far in the past.
+func (a *AuctioneerServer) setRoundStatus(round uint64, status RoundStatus) {
+	a.roundStatusesLock.Lock()
+	defer a.roundStatusesLock.Unlock()
+	if a.roundStatuses == nil {
+		a.roundStatuses = make(map[uint64]RoundStatus)
+	}
+	a.roundStatuses[round] = status
+	// Rounds aren't necessarily set in order, nor every round, so anything at
+	// or below the cutoff is pruned rather than only the round falling out.
+	if round < roundStatusHistory {
+		return
+	}
+	for r := range a.roundStatuses {
+		if r <= round-roundStatusHistory {
+			delete(a.roundStatuses, r)
+		}
+	}
+}
+
+// RoundOutcome returns whether the given round was resolved, cancelled,
+// skipped, had no bids, was observed or closed while paused. It returns false
+// if the round was not handled yet, or too long ago.
+func (a *AuctioneerServer) RoundOutcome(round uint64) (RoundStatus, bool) {
+	a.roundStatusesLock.RLock()
+	defer a.roundStatusesLock.RUnlock()
+	status, ok := a.roundStatuses[round]
+	return status, ok
+}
+
+func (api *AuctioneerHistoryAPI) RoundOutcome(round hexutil.Uint64) (RoundStatus, error) {
+	status, ok := api.auctioneer.RoundOutcome(uint64(round))
+	if !ok {
+		return "", fmt.Errorf("no outcome known for round %d", round)
+	}
+	return status, nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// roundMismatchTolerance is how far the local clock and the timestamp of the
+// latest block may be apart before their rounds are expected to agree, so that
+// checks close to a round boundary don't raise false alarms.
+const roundMismatchTolerance = 2 * time.Second
+
+// refreshRoundTiming re-reads the round timing of the auction contract, which
+// governance may change while the auctioneer runs. A change is only staged
+// here; it takes effect at the next round boundary, see applyPendingRoundTiming,
+// so the round currently being auctioned is resolved with the timing it started with.
+// Every refresh also checks that the local round agrees with the contract's.
+func (a *AuctioneerServer) refreshRoundTiming(ctx context.Context) {
+	rawRoundTimingInfo, err := a.getAuctionContract().RoundTimingInfo(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		log.Warn("Could not refresh round timing info", "err", err)
+		return
+	}
+	roundTimingInfo, err := NewRoundTimingInfo(rawRoundTimingInfo)
+	if err == nil {
+		err = roundTimingInfo.ValidateResolutionWaitTime(a.auctionResolutionWaitTime)
+	}
+	if err != nil {
+		log.Error("Ignoring invalid round timing info from auction contract", "err", err)
+		return
+	}
+	a.checkContractRound(ctx, roundTimingInfo)
+	a.roundTimingLock.Lock()
+	defer a.roundTimingLock.Unlock()
+	if roundTimingInfo.equal(&a.roundTimingInfo) {
+		a.pendingRoundTiming = nil
+		return
+	}
+	if a.pendingRoundTiming == nil || !roundTimingInfo.equal(a.pendingRoundTiming) {
+		log.Info("Auction contract round timing changed, applying at the next round boundary", "old", a.roundTimingInfo, "new", *roundTimingInfo)
+	}
+	a.pendingRoundTiming = roundTimingInfo
+}
+
+// checkContractRound compares the current round derived locally from the round
+// timing against the current round of the auction contract, which is derived
+// from block timestamps instead. A mismatch means the local clock or the chain
+// drifted, and every round would be resolved at the wrong time.
+func (a *AuctioneerServer) checkContractRound(ctx context.Context, roundTimingInfo *RoundTimingInfo) {
+	contractRound, err := a.getAuctionContract().CurrentRound(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		log.Warn("Could not read current round of auction contract", "err", err)
+		return
+	}
+	now := time.Now()
+	if !roundTimingInfo.agreesWithRound(contractRound, now) {
+		a.getMetrics().roundMismatch.Inc(1)
+		log.Error("Locally derived round does not match the auction contract, check the system clock and the round timing", "localRound", roundTimingInfo.RoundNumberAt(now), "contractRound", contractRound, "offset", roundTimingInfo.Offset)
+	}
+}
+
+// agreesWithRound reports whether the given round is the current round at the
+// given time, up to roundMismatchTolerance.
+func (info *RoundTimingInfo) agreesWithRound(round uint64, now time.Time) bool {
+	return round >= info.RoundNumberAt(now.Add(-roundMismatchTolerance)) &&
+		round <= info.RoundNumberAt(now.Add(roundMismatchTolerance))
+}
+
+// applyPendingRoundTiming switches to a staged round timing, and reports
+// whether it did. It must only be called from the resolution thread, after
+// the upcoming round was resolved.
+func (a *AuctioneerServer) applyPendingRoundTiming() bool {
+	a.roundTimingLock.Lock()
+	defer a.roundTimingLock.Unlock()
+	if a.pendingRoundTiming == nil {
+		return false
+	}
+	log.Info("Applying new round timing", "old", a.roundTimingInfo, "new", *a.pendingRoundTiming)
+	a.roundTimingInfo = *a.pendingRoundTiming
+	a.pendingRoundTiming = nil
+	return true
+}
+
+func (a *AuctioneerServer) getRoundTimingInfo() RoundTimingInfo {
+	a.roundTimingLock.RLock()
+	defer a.roundTimingLock.RUnlock()
+	return a.roundTimingInfo
+}
+
+// RoundTimingInfoResult is the round timing the auctioneer currently resolves
+// rounds with, as read from the auction contract, along with where the
+// current round stands. Durations are in seconds, as on the contract, and the
+// time until bidding on the upcoming round closes is in milliseconds.
+type RoundTimingInfoResult struct {
+	RoundDuration             hexutil.Uint64 `json:"roundDuration"`
+	AuctionClosingDuration    hexutil.Uint64 `json:"auctionClosingDuration"`
+	ReserveSubmissionDuration hexutil.Uint64 `json:"reserveSubmissionDuration"`
+	InitialRoundTimestamp     int64          `json:"initialRoundTimestamp"`
+	CurrentRound              hexutil.Uint64 `json:"currentRound"`
+	TimeUntilClose            hexutil.Uint64 `json:"timeUntilClose"`
+}
+
+// RoundTimingInfo returns the round timing as of now, so that bidders can
+// schedule their bids without reading it from the auction contract. Once a
+// timing change takes effect, it is returned from the next round on.
+func (api *AuctioneerHistoryAPI) RoundTimingInfo() *RoundTimingInfoResult {
+	info := api.auctioneer.getRoundTimingInfo()
+	now := time.Now()
+	// #nosec G115
+	return &RoundTimingInfoResult{
+		RoundDuration:             hexutil.Uint64(info.Round / time.Second),
+		AuctionClosingDuration:    hexutil.Uint64(info.AuctionClosing / time.Second),
+		ReserveSubmissionDuration: hexutil.Uint64(info.ReserveSubmission / time.Second),
+		InitialRoundTimestamp:     info.Offset.Unix(),
+		CurrentRound:              hexutil.Uint64(info.RoundNumberAt(now)),
+		TimeUntilClose:            hexutil.Uint64(max(0, info.TimeTilNextRoundAt(now)-info.AuctionClosing).Milliseconds()),
+	}
+}
@@ -0,0 +1,126 @@
+package timeboost
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/offchainlabs/nitro/solgen/go/express_lane_auctiongen"
+)
+
+func TestRefreshRoundTiming(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	auctionAbi, err := express_lane_auctiongen.ExpressLaneAuctionMetaData.GetAbi()
+	require.NoError(t, err)
+	roundTimingInfoMethod := auctionAbi.Methods["roundTimingInfo"]
+	client := &fakeAuctioneerClient{callResults: map[[4]byte][]byte{}}
+	setContractTiming := func(timing express_lane_auctiongen.RoundTimingInfo) {
+		result, err := roundTimingInfoMethod.Outputs.Pack(timing.OffsetTimestamp, timing.RoundDurationSeconds, timing.AuctionClosingSeconds, timing.ReserveSubmissionSeconds)
+		require.NoError(t, err)
+		client.callResults[[4]byte(roundTimingInfoMethod.ID)] = result
+	}
+	binding, err := express_lane_auctiongen.NewExpressLaneAuction(common.HexToAddress("0x1234"), client)
+	require.NoError(t, err)
+
+	offset := time.Unix(time.Now().Add(-10*time.Minute).Unix(), 0)
+	oldTiming := express_lane_auctiongen.RoundTimingInfo{
+		OffsetTimestamp:          offset.Unix(),
+		RoundDurationSeconds:     60,
+		AuctionClosingSeconds:    15,
+		ReserveSubmissionSeconds: 15,
+	}
+	oldRoundTimingInfo, err := NewRoundTimingInfo(oldTiming)
+	require.NoError(t, err)
+	a := &AuctioneerServer{
+		auctionContract:           binding,
+		auctionResolutionWaitTime: 2 * time.Second,
+		roundTimingInfo:           *oldRoundTimingInfo,
+	}
+
+	// Unchanged timing stages nothing.
+	setContractTiming(oldTiming)
+	a.refreshRoundTiming(ctx)
+	require.False(t, a.applyPendingRoundTiming())
+
+	// Governance doubles the round duration.
+	newTiming := oldTiming
+	newTiming.RoundDurationSeconds = 120
+	setContractTiming(newTiming)
+	a.refreshRoundTiming(ctx)
+
+	// The current round keeps its timing until the round boundary.
+	require.Equal(t, uint64(10), a.CurrentRound())
+	current := a.getRoundTimingInfo()
+	require.True(t, current.equal(oldRoundTimingInfo))
+
+	require.True(t, a.applyPendingRoundTiming())
+	require.Equal(t, 2*time.Minute, a.getRoundTimingInfo().Round)
+	require.Equal(t, uint64(5), a.CurrentRound())
+	require.False(t, a.applyPendingRoundTiming())
+
+	// Timing the auctioneer can't work with is ignored.
+	invalidTiming := newTiming
+	invalidTiming.AuctionClosingSeconds = 3
+	setContractTiming(invalidTiming)
+	a.refreshRoundTiming(ctx)
+	require.False(t, a.applyPendingRoundTiming())
+}
+
+func TestRoundTimingAgreesWithRound(t *testing.T) {
+	t.Parallel()
+	offset := time.Unix(1_700_000_000, 0)
+	info := RoundTimingInfo{
+		Offset:            offset,
+		Round:             time.Minute,
+		AuctionClosing:    15 * time.Second,
+		ReserveSubmission: 15 * time.Second,
+	}
+	tests := []struct {
+		name  string
+		now   time.Duration
+		round uint64
+		agree bool
+	}{
+		{name: "same round", now: 10*time.Minute + 30*time.Second, round: 10, agree: true},
+		{name: "previous round", now: 10*time.Minute + 30*time.Second, round: 9},
+		{name: "next round", now: 10*time.Minute + 30*time.Second, round: 11},
+		{name: "block before the boundary", now: 10*time.Minute + time.Second, round: 9, agree: true},
+		{name: "block after the boundary", now: 10*time.Minute - time.Second, round: 10, agree: true},
+		{name: "offset drifted by a round", now: 10*time.Minute + 30*time.Second, round: 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.agree, info.agreesWithRound(tt.round, offset.Add(tt.now)))
+		})
+	}
+}
+
+func TestRoundTimingInfoAPI(t *testing.T) {
+	t.Parallel()
+	offset := time.Unix(time.Now().Add(-2*time.Minute-20*time.Second).Unix(), 0)
+	a := &AuctioneerServer{
+		roundTimingInfo: RoundTimingInfo{
+			Offset:            offset,
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+	}
+	result := (&AuctioneerHistoryAPI{a}).RoundTimingInfo()
+	require.Equal(t, uint64(60), uint64(result.RoundDuration))
+	require.Equal(t, uint64(15), uint64(result.AuctionClosingDuration))
+	require.Equal(t, uint64(15), uint64(result.ReserveSubmissionDuration))
+	require.Equal(t, offset.Unix(), result.InitialRoundTimestamp)
+	require.Equal(t, uint64(2), uint64(result.CurrentRound))
+	require.InDelta(t, (25 * time.Second).Milliseconds(), uint64(result.TimeUntilClose), 2000)
+
+	// Once bidding closed, there is no time left until the close.
+	a.roundTimingInfo.Offset = offset.Add(-30 * time.Second)
+	require.Zero(t, (&AuctioneerHistoryAPI{a}).RoundTimingInfo().TimeUntilClose)
+}
@@ -5,15 +5,34 @@ package timeboost
 
 import (
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/offchainlabs/nitro/solgen/go/express_lane_auctiongen"
 	"github.com/offchainlabs/nitro/util/arbmath"
 )
 
+// maxRoundTimingSeconds is the longest duration, in seconds, a time.Duration
+// can hold. Longer ones would overflow when converted.
+const maxRoundTimingSeconds = uint64(math.MaxInt64 / int64(time.Second))
+
 // Validate the express_lane_auctiongen.RoundTimingInfo fields.
 // Returns errors in terms of the solidity field names to ease debugging.
+// Misconfigured timing would otherwise go unnoticed until no round ever
+// resolves, with an auction that is never open or never closes.
 func validateRoundTimingInfo(c *express_lane_auctiongen.RoundTimingInfo) error {
+	for _, field := range []struct {
+		name    string
+		seconds uint64
+	}{
+		{"RoundDurationSeconds", c.RoundDurationSeconds},
+		{"AuctionClosingSeconds", c.AuctionClosingSeconds},
+		{"ReserveSubmissionSeconds", c.ReserveSubmissionSeconds},
+	} {
+		if field.seconds > maxRoundTimingSeconds {
+			return fmt.Errorf("%s (%d) must be at most %d seconds", field.name, field.seconds, maxRoundTimingSeconds)
+		}
+	}
 	roundDuration := arbmath.SaturatingCast[time.Duration](c.RoundDurationSeconds) * time.Second
 	auctionClosing := arbmath.SaturatingCast[time.Duration](c.AuctionClosingSeconds) * time.Second
 	reserveSubmission := arbmath.SaturatingCast[time.Duration](c.ReserveSubmissionSeconds) * time.Second
@@ -31,10 +50,21 @@ func validateRoundTimingInfo(c *express_lane_auctiongen.RoundTimingInfo) error {
 		return fmt.Errorf("ReserveSubmissionSeconds (%d) must be at least 1 second", c.ReserveSubmissionSeconds)
 	}
 
-	// Validate combined auction closing and reserve submission against round duration
+	// The auction of a round closing as it opens could never be bid on. The
+	// combined check below rejects this too, as the reserve submission window
+	// is at least a second, so this one must run first to report it as such.
+	if auctionClosing >= roundDuration {
+		return fmt.Errorf("AuctionClosingSeconds (%d) must be less than RoundDurationSeconds (%d), or the auction of a round is never open",
+			c.AuctionClosingSeconds,
+			c.RoundDurationSeconds)
+	}
+
+	// Validate combined auction closing and reserve submission against round
+	// duration, as the reserve submission window of a round must fall within
+	// its auction.
 	combinedClosingTime := auctionClosing + reserveSubmission
 	if roundDuration <= combinedClosingTime {
-		return fmt.Errorf("RoundDurationSeconds (%d) must be greater than AuctionClosingSeconds (%d) + ReserveSubmissionSeconds (%d) = %d",
+		return fmt.Errorf("RoundDurationSeconds (%d) must be greater than AuctionClosingSeconds (%d) + ReserveSubmissionSeconds (%d) = %d, or the reserve submission window starts before bidding on the round opens",
 			c.RoundDurationSeconds,
 			c.AuctionClosingSeconds,
 			c.ReserveSubmissionSeconds,
@@ -68,6 +98,14 @@ func NewRoundTimingInfo(c express_lane_auctiongen.RoundTimingInfo) (*RoundTiming
 	}, nil
 }
 
+// equal reports whether both describe the same round schedule.
+func (info *RoundTimingInfo) equal(other *RoundTimingInfo) bool {
+	return info.Offset.Equal(other.Offset) &&
+		info.Round == other.Round &&
+		info.AuctionClosing == other.AuctionClosing &&
+		info.ReserveSubmission == other.ReserveSubmission
+}
+
 // resolutionWaitTime is an additional parameter that the Auctioneer
 // needs to validate against other timing fields.
 func (info *RoundTimingInfo) ValidateResolutionWaitTime(resolutionWaitTime time.Duration) error {
@@ -110,6 +148,11 @@ func (info *RoundTimingInfo) TimeOfNextRoundAt(currentTime time.Time) time.Time
 	return info.Offset.Add(info.Round * arbmath.SaturatingCast[time.Duration](roundNum+1))
 }
 
+// roundStartTime returns the time the given round starts at.
+func (info *RoundTimingInfo) roundStartTime(round uint64) time.Time {
+	return info.Offset.Add(info.Round * arbmath.SaturatingCast[time.Duration](round))
+}
+
 func (info *RoundTimingInfo) durationIntoRound(timestamp time.Time) time.Duration {
 	secondsSinceOffset := uint64(timestamp.Sub(info.Offset).Seconds())
 	roundDurationSeconds := uint64(info.Round.Seconds())
@@ -131,3 +174,21 @@ func (info *RoundTimingInfo) isAuctionRoundClosedAt(currentTime time.Time) bool
 func (info *RoundTimingInfo) IsWithinAuctionCloseWindow(timestamp time.Time) bool {
 	return info.TimeTilNextRoundAt(timestamp) <= info.AuctionClosing
 }
+
+// InReserveSubmissionWindow reports whether the reserve submission deadline
+// of the upcoming round has passed as of now, but its auction is not yet closed.
+func (info *RoundTimingInfo) InReserveSubmissionWindow() bool {
+	return info.InReserveSubmissionWindowAt(time.Now())
+}
+
+// InReserveSubmissionWindowAt reports whether the timestamp falls in the
+// ReserveSubmission period right before the auction closes. The reserve price
+// of the upcoming round has to be submitted before this window and can no
+// longer change during it.
+func (info *RoundTimingInfo) InReserveSubmissionWindowAt(timestamp time.Time) bool {
+	if timestamp.Before(info.Offset) {
+		return false
+	}
+	timeTilNextRound := info.TimeTilNextRoundAt(timestamp)
+	return timeTilNextRound > info.AuctionClosing && timeTilNextRound <= info.AuctionClosing+info.ReserveSubmission
+}
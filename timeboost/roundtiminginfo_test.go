@@ -0,0 +1,46 @@
+package timeboost
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/offchainlabs/nitro/solgen/go/express_lane_auctiongen"
+)
+
+func TestValidateRoundTimingInfo(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name              string
+		round             uint64
+		auctionClosing    uint64
+		reserveSubmission uint64
+		errMsg            string
+	}{
+		{name: "valid", round: 60, auctionClosing: 15, reserveSubmission: 15},
+		{name: "round too short", round: 9, auctionClosing: 5, reserveSubmission: 1, errMsg: "RoundDurationSeconds (9) must be at least 10 seconds"},
+		{name: "closing too short", round: 60, auctionClosing: 4, reserveSubmission: 15, errMsg: "AuctionClosingSeconds (4) must be at least 5 seconds"},
+		{name: "no reserve submission", round: 60, auctionClosing: 15, reserveSubmission: 0, errMsg: "ReserveSubmissionSeconds (0) must be at least 1 second"},
+		{name: "closing as long as round", round: 60, auctionClosing: 60, reserveSubmission: 15, errMsg: "auction of a round is never open"},
+		{name: "closing longer than round", round: 60, auctionClosing: 90, reserveSubmission: 15, errMsg: "auction of a round is never open"},
+		{name: "reserve submission overlaps", round: 60, auctionClosing: 45, reserveSubmission: 15, errMsg: "reserve submission window starts before bidding on the round opens"},
+		{name: "overflowing round", round: math.MaxUint64, auctionClosing: 15, reserveSubmission: 15, errMsg: "RoundDurationSeconds (18446744073709551615) must be at most"},
+		{name: "overflowing closing", round: 60, auctionClosing: maxRoundTimingSeconds + 1, reserveSubmission: 15, errMsg: "AuctionClosingSeconds (9223372037) must be at most"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := NewRoundTimingInfo(express_lane_auctiongen.RoundTimingInfo{
+				RoundDurationSeconds:     tt.round,
+				AuctionClosingSeconds:    tt.auctionClosing,
+				ReserveSubmissionSeconds: tt.reserveSubmission,
+			})
+			if tt.errMsg == "" {
+				require.NoError(t, err)
+				require.Less(t, info.AuctionClosing+info.ReserveSubmission, info.Round)
+				return
+			}
+			require.ErrorContains(t, err, tt.errMsg)
+			require.Nil(t, info)
+		})
+	}
+}
@@ -21,5 +21,30 @@ CREATE TABLE IF NOT EXISTS Bids (
 );
 CREATE INDEX idx_bids_round ON Bids(Round);
 `
-	schemaList = []string{version1}
+	version2 = `
+CREATE TABLE IF NOT EXISTS AuctionHistory (
+    Id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    Round INTEGER NOT NULL,
+    Status TEXT NOT NULL,
+    Winner TEXT NOT NULL,
+    WinnerBidder TEXT NOT NULL,
+    FirstPrice TEXT NOT NULL,
+    SecondPrice TEXT NOT NULL,
+    NumBids INTEGER NOT NULL,
+    TxHash TEXT NOT NULL,
+    Time INTEGER NOT NULL
+);
+CREATE INDEX idx_auction_history_round ON AuctionHistory(Round);
+`
+	version3 = `
+ALTER TABLE AuctionHistory ADD COLUMN ClearingPrice TEXT NOT NULL DEFAULT '';
+`
+	version4 = `
+ALTER TABLE AuctionHistory ADD COLUMN BaseFee TEXT NOT NULL DEFAULT '';
+ALTER TABLE AuctionHistory ADD COLUMN EffectiveGasPrice TEXT NOT NULL DEFAULT '';
+`
+	version5 = `
+ALTER TABLE AuctionHistory ADD COLUMN Revenue TEXT NOT NULL DEFAULT '';
+`
+	schemaList = []string{version1, version2, version3, version4, version5}
 )
@@ -0,0 +1,70 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/offchainlabs/nitro/solgen/go/express_lane_auctiongen"
+)
+
+var _ AuctioneerClient = (*SequencerClient)(nil)
+
+// SequencerClient implements AuctioneerClient on top of a go-ethereum client
+// connected to a sequencer. Besides what auction resolution needs, it serves
+// everything else of the embedded client, such as SubscribeNewHead to follow
+// the sequencer's blocks over a websocket connection.
+type SequencerClient struct {
+	*ethclient.Client
+	rpc *rpc.Client
+}
+
+// NewSequencerClient adapts a client connected to a sequencer for resolving
+// auctions on it.
+func NewSequencerClient(client *ethclient.Client) *SequencerClient {
+	return &SequencerClient{
+		Client: client,
+		rpc:    client.Client(),
+	}
+}
+
+// DialSequencerClient connects to the sequencer at the given RPC URL.
+func DialSequencerClient(ctx context.Context, url string) (*SequencerClient, error) {
+	rpcClient, err := rpc.DialContext(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	return newSequencerClient(rpcClient), nil
+}
+
+func newSequencerClient(rpcClient *rpc.Client) *SequencerClient {
+	return NewSequencerClient(ethclient.NewClient(rpcClient))
+}
+
+func (c *SequencerClient) SubmitAuctionResolutionTransaction(ctx context.Context, tx *types.Transaction) error {
+	return c.rpc.CallContext(ctx, nil, "auctioneer_submitAuctionResolutionTransaction", tx)
+}
+
+// WaitMined waits for a transaction submitted through the sequencer to be
+// mined, and returns its receipt.
+func (c *SequencerClient) WaitMined(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+	return bind.WaitMined(ctx, c, tx)
+}
+
+// DepositOf returns the balance the account deposited into the auction
+// contract at the given address, which is what its bids are checked against.
+func (c *SequencerClient) DepositOf(ctx context.Context, auctionContractAddr, account common.Address) (*big.Int, error) {
+	auctionContract, err := express_lane_auctiongen.NewExpressLaneAuctionCaller(auctionContractAddr, c)
+	if err != nil {
+		return nil, err
+	}
+	return auctionContract.BalanceOf(&bind.CallOpts{Context: ctx}, account)
+}
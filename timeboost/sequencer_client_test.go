@@ -0,0 +1,59 @@
+package timeboost
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// TestSequencerClientLive exercises SequencerClient against a live sequencer,
+// given by TIMEBOOST_SEQUENCER_URL. If TIMEBOOST_AUCTION_CONTRACT is set too,
+// deposits are read from that auction contract.
+func TestSequencerClientLive(t *testing.T) {
+	url := os.Getenv("TIMEBOOST_SEQUENCER_URL")
+	if url == "" {
+		t.Skip("use TIMEBOOST_SEQUENCER_URL to run against a live sequencer")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	client, err := DialSequencerClient(ctx, url)
+	require.NoError(t, err)
+	defer client.Close()
+
+	head, err := client.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	balance, err := client.BalanceAt(ctx, common.Address{}, head.Number)
+	require.NoError(t, err)
+	require.NotNil(t, balance)
+	_, err = client.TransactionReceipt(ctx, common.Hash{})
+	require.Error(t, err)
+
+	if addr := os.Getenv("TIMEBOOST_AUCTION_CONTRACT"); addr != "" {
+		deposit, err := client.DepositOf(ctx, common.HexToAddress(addr), common.Address{})
+		require.NoError(t, err)
+		require.NotNil(t, deposit)
+	}
+
+	// Blocks can only be subscribed to over a websocket connection.
+	if strings.HasPrefix(url, "ws") {
+		heads := make(chan *types.Header, 1)
+		sub, err := client.SubscribeNewHead(ctx, heads)
+		require.NoError(t, err)
+		defer sub.Unsubscribe()
+		select {
+		case header := <-heads:
+			require.GreaterOrEqual(t, header.Number.Uint64(), head.Number.Uint64())
+		case err := <-sub.Err():
+			t.Fatal(err)
+		case <-ctx.Done():
+			t.Fatal("no block received from the sequencer")
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var errSignerOutOfFunds = errors.New("auctioneer signer cannot pay for auction resolution")
+
+// isInsufficientFundsError reports whether err, possibly relayed as a string by
+// the sequencer's RPC, is caused by the sender not being able to pay for a tx.
+func isInsufficientFundsError(err error) bool {
+	return err != nil && (errors.Is(err, core.ErrInsufficientFunds) || strings.Contains(err.Error(), core.ErrInsufficientFunds.Error()))
+}
+
+// checkSignerFunds makes sure the signer can pay for the resolution transaction
+// before it is submitted. Rounds are skipped rather than attempted while it
+// can't, until the signer is topped up again.
+func (a *AuctioneerServer) checkSignerFunds(ctx context.Context, client AuctioneerClient, signer common.Address, tx *types.Transaction) error {
+	balance, err := client.BalanceAt(ctx, signer, nil)
+	if err != nil {
+		// Don't hold up the resolution, the sequencer will reject it if need be.
+		log.Warn("Could not check auctioneer signer balance", "signer", signer, "err", err)
+		return nil
+	}
+	a.signerBalancesLock.Lock()
+	if a.signerBalances == nil {
+		a.signerBalances = make(map[common.Address]*big.Int)
+	}
+	a.signerBalances[signer] = balance
+	a.signerBalancesLock.Unlock()
+	a.getMetrics().signerBalance.Update(new(big.Int).Div(balance, big.NewInt(params.GWei)).Int64())
+	if cost := tx.Cost(); balance.Cmp(cost) < 0 {
+		return a.signerOutOfFunds(signer, fmt.Errorf("%w: balance %s, cost %s", errSignerOutOfFunds, balance.String(), cost.String()))
+	}
+	return nil
+}
+
+func (a *AuctioneerServer) signerOutOfFunds(signer common.Address, err error) error {
+	a.getMetrics().signerOutOfFunds.Inc(1)
+	log.Error("Auctioneer signer is out of funds, its auction resolutions are paused until it is topped up", "signer", signer, "err", err)
+	return err
+}
+
+// checkSignerBalance fails if any signer's balance, as of its last resolution,
+// is below the configured minimum.
+func (a *AuctioneerServer) checkSignerBalance() error {
+	if a.minSignerBalance == nil {
+		return nil
+	}
+	a.signerBalancesLock.Lock()
+	defer a.signerBalancesLock.Unlock()
+	for signer, balance := range a.signerBalances {
+		if balance.Cmp(a.minSignerBalance) < 0 {
+			return fmt.Errorf("signer %s balance %s is below minimum %s", signer, balance.String(), a.minSignerBalance.String())
+		}
+	}
+	return nil
+}
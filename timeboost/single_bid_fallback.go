@@ -0,0 +1,48 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"fmt"
+)
+
+// SingleBidFallback selects how a round is resolved when it received several
+// bids, but a single one is left once unfunded and duplicate bidders are
+// dropped. Rounds that received a single bid to begin with are governed by
+// MinBidsToResolve instead.
+type SingleBidFallback uint8
+
+const (
+	// ResolveSingleBid is the default fallback. The remaining bid is passed to
+	// resolveSingleBidAuction and the winner pays the reserve price.
+	ResolveSingleBid SingleBidFallback = iota
+	// SkipSingleBid leaves the round unresolved, for operators who require
+	// competition for the express lane to be sold.
+	SkipSingleBid
+)
+
+func (f SingleBidFallback) String() string {
+	switch f {
+	case ResolveSingleBid:
+		return "resolve"
+	case SkipSingleBid:
+		return "skip"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(f))
+	}
+}
+
+// WithSingleBidFallback sets how a round left with a single eligible bid out
+// of several is resolved. By default, it is resolved as a single bid auction.
+func WithSingleBidFallback(fallback SingleBidFallback) AuctioneerServerOpt {
+	return func(a *AuctioneerServer) {
+		a.singleBidFallback = fallback
+	}
+}
+
+// skipCollapsedRound reports whether the round must be left unresolved,
+// because it received numBids bids but only one of them is still eligible.
+func (a *AuctioneerServer) skipCollapsedRound(result *auctionResult, numBids uint64) bool {
+	return a.singleBidFallback == SkipSingleBid && numBids > 1 && result.firstPlace != nil && result.secondPlace == nil
+}
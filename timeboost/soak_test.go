@@ -0,0 +1,205 @@
+package timeboost
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"math/rand"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// soakRounds is the number of rounds the soak tests run through, enough for
+// a leak of anything per round to stand out.
+const soakRounds = 2000
+
+// requireGoroutinesSettle waits for goroutines exiting asynchronously, such as
+// those of timers, before checking none were leaked since the baseline.
+func requireGoroutinesSettle(t *testing.T, baseline int) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		return runtime.NumGoroutine() <= baseline
+	}, 5*time.Second, 10*time.Millisecond, "goroutines leaked: %d running, %d at baseline", runtime.NumGoroutine(), baseline)
+}
+
+// TestAuctioneerSoak drives the auctioneer through thousands of rounds on a
+// simulated clock, with a random number of bids every round, and checks that
+// every round leaves nothing behind: no goroutine, no cached bid and no
+// unbounded round history.
+func TestAuctioneerSoak(t *testing.T) {
+	if testing.Short() {
+		t.Skip("soak test")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// #nosec G404
+	rng := rand.New(rand.NewSource(1))
+	chainId := big.NewInt(1)
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	txOpts, err := bind.NewKeyedTransactorWithChainID(privKey, chainId)
+	require.NoError(t, err)
+	txOpts.GasLimit = 1_000_000
+	a := &AuctioneerServer{
+		txOpts:              txOpts,
+		chainId:             chainId,
+		auctionContractAddr: common.HexToAddress("0x1234"),
+		bidCache:            newBidCache([32]byte{}),
+		roundTimingInfo: RoundTimingInfo{
+			// Bidding on round 1 is closed, and round 1 starts in 10 seconds.
+			Offset:            time.Now().Add(-50 * time.Second),
+			Round:             time.Minute,
+			AuctionClosing:    15 * time.Second,
+			ReserveSubmission: 15 * time.Second,
+		},
+	}
+	// The simulated clock runs ahead of the local one by whole rounds, which
+	// moves every round forward while keeping the auction of each closed. The
+	// auctioneer follows it through its round timing, and tickers through
+	// their clock.
+	initialTiming := a.roundTimingInfo
+	var elapsed time.Duration
+	advance := func() {
+		elapsed += a.roundTimingInfo.Round
+		a.roundTimingLock.Lock()
+		a.roundTimingInfo.Offset = a.roundTimingInfo.Offset.Add(-a.roundTimingInfo.Round)
+		a.roundTimingLock.Unlock()
+	}
+	now := func() time.Time { return time.Now().Add(elapsed) }
+	fired := make(chan time.Time)
+	close(fired)
+
+	runtime.GC()
+	baseline := runtime.NumGoroutine()
+	for i := 0; i < soakRounds; i++ {
+		round := a.UpcomingRound()
+		require.Equal(t, uint64(i+1), round)
+
+		// A ticker running on the simulated clock ticks once and stops.
+		ticker := newRoundTicker(initialTiming)
+		ticker.now = now
+		ticker.after = func(time.Duration) <-chan time.Time { return fired }
+		go ticker.tickAtAuctionClose(ctx)
+		require.Equal(t, round-1, initialTiming.RoundNumberAt(<-ticker.c))
+		close(ticker.done)
+		for range ticker.c {
+		}
+
+		bids := rng.Intn(20)
+		for j := 0; j < bids; j++ {
+			controller := common.Address{byte(j), 0xc}
+			a.receiveValidatedBid(&JsonValidatedBid{
+				ChainId:                (*hexutil.Big)(chainId),
+				AuctionContractAddress: a.auctionContractAddr,
+				Bidder:                 controller,
+				ExpressLaneController:  controller,
+				Round:                  hexutil.Uint64(round),
+				Amount:                 (*hexutil.Big)(big.NewInt(rng.Int63n(1000) + 1)),
+				Signature:              make([]byte, 65),
+				// Some bids are dropped on arrival.
+				BelowReservePrice: rng.Intn(10) == 0,
+			})
+		}
+		client := &fakeAuctioneerClient{baseFee: big.NewInt(1)}
+		// The outcome doesn't matter, the round is closed either way.
+		_ = a.resolveUpcomingRound(ctx, func(ctx context.Context) error {
+			_, err := a.resolveAuctionWithClient(ctx, client, true)
+			return err
+		})
+		a.closeRound(round)
+
+		require.Zero(t, a.bidCache.size(), "round %d", round)
+		require.Empty(t, a.bidCache.ranking.heap, "round %d", round)
+		require.Empty(t, a.bidCache.ranking.byController, "round %d", round)
+		a.roundStatusesLock.RLock()
+		require.LessOrEqual(t, len(a.roundStatuses), roundStatusHistory)
+		a.roundStatusesLock.RUnlock()
+		advance()
+	}
+	_, ok := a.RoundOutcome(1)
+	require.False(t, ok, "status of rounds beyond the history is kept")
+	requireGoroutinesSettle(t, baseline)
+}
+
+// TestBidValidatorSoak validates random bids for thousands of rounds on the
+// bid validator's clock, advanced through its drift correction, with reserve
+// overrides set along the way, and checks that the per-round state of the
+// validator doesn't outlive its round.
+func TestBidValidatorSoak(t *testing.T) {
+	if testing.Short() {
+		t.Skip("soak test")
+	}
+	// #nosec G404
+	rng := rand.New(rand.NewSource(1))
+	chainId := big.NewInt(1)
+	auctionContractAddr := common.Address{'a'}
+	bv := &BidValidator{
+		chainId: chainId,
+		roundTimingInfo: RoundTimingInfo{
+			Offset:         time.Now().Add(-time.Second),
+			Round:          time.Minute,
+			AuctionClosing: 15 * time.Second,
+		},
+		reservePrice:                   big.NewInt(1),
+		bidsPerSenderInRound:           make(map[common.Address]uint8),
+		maxBidsPerSenderInRound:        5,
+		seenBidSignatures:              make(map[string]struct{}),
+		validatingBidSignatures:        make(map[string]struct{}),
+		auctionContractAddr:            auctionContractAddr,
+		auctionContractDomainSeparator: ComputeDomainSeparator(chainId, auctionContractAddr),
+		clock:                          clockSkewMonitor{config: ClockSkewConfig{Reconcile: true}},
+	}
+	keys := make([]*ecdsa.PrivateKey, 8)
+	for i := range keys {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		keys[i] = key
+	}
+	funded := func(*bind.CallOpts, common.Address) (*big.Int, error) {
+		return big.NewInt(1_000_000), nil
+	}
+
+	runtime.GC()
+	baseline := runtime.NumGoroutine()
+	for i := 0; i < soakRounds; i++ {
+		round := bv.upcomingRound()
+		require.Equal(t, uint64(i+1), round)
+		if rng.Intn(4) == 0 {
+			require.NoError(t, bv.SetRoundReserveOverride(round+uint64(rng.Intn(3)), big.NewInt(rng.Int63n(100)+1)))
+		}
+		bids := rng.Intn(10)
+		for j := 0; j < bids; j++ {
+			bid, err := NewSignedBid(keys[rng.Intn(len(keys))], round, big.NewInt(rng.Int63n(1000)+1), common.Address{byte(j)}, chainId, auctionContractAddr)
+			require.NoError(t, err)
+			// Rejections, of bids below an override or over the limit of
+			// their bidder, are part of the load.
+			if _, err := bv.validateBid(bid, funded); err == nil {
+				bv.markBidSeen(bid.Signature)
+			}
+		}
+
+		// As when the auction of the round closes.
+		bv.clearReserveOverrides(round)
+		bv.resetRound()
+		bv.RLock()
+		require.Empty(t, bv.bidsPerSenderInRound, "round %d", round)
+		require.Empty(t, bv.seenBidSignatures, "round %d", round)
+		require.Empty(t, bv.validatingBidSignatures, "round %d", round)
+		bv.RUnlock()
+		bv.reservePriceLock.RLock()
+		// Only overrides of rounds still to come are kept.
+		require.LessOrEqual(t, len(bv.reserveOverrides), 2, "round %d", round)
+		bv.reservePriceLock.RUnlock()
+
+		bv.clock.drift.Add(-int64(bv.roundTimingInfo.Round))
+	}
+	requireGoroutinesSettle(t, baseline)
+}
@@ -0,0 +1,298 @@
+#!/usr/bin/env python3
+# Copyright 2024-2025, Offchain Labs, Inc.
+# For license information, see https://github.com/nitro/blob/master/LICENSE
+"""Reference signer for express lane auction bids.
+
+Generates reference_bids.json, the fixtures of TestReferenceSignerBids. It
+implements keccak256, secp256k1 signing with RFC 6979 nonces and the EIP-712
+hashing of bids from scratch, sharing no code with go-ethereum, so that the
+fixtures catch the bid validator diverging from the signature scheme bidders
+implement. Run it from this directory to regenerate the fixtures:
+
+    python3 reference_signer.py > reference_bids.json
+"""
+
+import hashlib
+import hmac
+import json
+
+# Keccak-f[1600], as used by Ethereum, which pads unlike the final SHA-3.
+
+_RC = [
+    0x0000000000000001, 0x0000000000008082, 0x800000000000808A, 0x8000000080008000,
+    0x000000000000808B, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+    0x000000000000008A, 0x0000000000000088, 0x0000000080008009, 0x000000008000000A,
+    0x000000008000808B, 0x800000000000008B, 0x8000000000008089, 0x8000000000008003,
+    0x8000000000008002, 0x8000000000000080, 0x000000000000800A, 0x800000008000000A,
+    0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+]
+_ROTATIONS = [
+    [0, 36, 3, 41, 18],
+    [1, 44, 10, 45, 2],
+    [62, 6, 43, 15, 61],
+    [28, 55, 25, 21, 56],
+    [27, 20, 39, 8, 14],
+]
+_MASK = (1 << 64) - 1
+
+
+def _rotl(x, n):
+    return ((x << n) | (x >> (64 - n))) & _MASK if n else x
+
+
+def _keccak_f(a):
+    for rc in _RC:
+        c = [a[x][0] ^ a[x][1] ^ a[x][2] ^ a[x][3] ^ a[x][4] for x in range(5)]
+        d = [c[(x - 1) % 5] ^ _rotl(c[(x + 1) % 5], 1) for x in range(5)]
+        a = [[a[x][y] ^ d[x] for y in range(5)] for x in range(5)]
+        b = [[0] * 5 for _ in range(5)]
+        for x in range(5):
+            for y in range(5):
+                b[y][(2 * x + 3 * y) % 5] = _rotl(a[x][y], _ROTATIONS[x][y])
+        a = [[b[x][y] ^ (~b[(x + 1) % 5][y] & b[(x + 2) % 5][y]) for y in range(5)] for x in range(5)]
+        a[0][0] ^= rc
+    return a
+
+
+def keccak256(data):
+    rate = 136
+    padded = bytearray(data) + b"\x01" + b"\x00" * ((-len(data) - 2) % rate) + b"\x80"
+    if len(padded) % rate:
+        raise AssertionError("bad padding")
+    state = [[0] * 5 for _ in range(5)]
+    for offset in range(0, len(padded), rate):
+        block = padded[offset:offset + rate]
+        for i in range(rate // 8):
+            state[i % 5][i // 5] ^= int.from_bytes(block[8 * i:8 * i + 8], "little")
+        state = _keccak_f(state)
+    out = b"".join(state[i % 5][i // 5].to_bytes(8, "little") for i in range(4))
+    return out
+
+
+# secp256k1.
+
+_P = 2**256 - 2**32 - 977
+_N = 0xFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141
+_G = (
+    0x79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798,
+    0x483ADA7726A3C4655DA4FBFC0E1108A8FD17B448A68554199C47D08FFB10D4B8,
+)
+
+
+def _point_add(p, q):
+    if p is None:
+        return q
+    if q is None:
+        return p
+    if p[0] == q[0] and (p[1] + q[1]) % _P == 0:
+        return None
+    if p == q:
+        lam = 3 * p[0] * p[0] * pow(2 * p[1], -1, _P) % _P
+    else:
+        lam = (q[1] - p[1]) * pow(q[0] - p[0], -1, _P) % _P
+    x = (lam * lam - p[0] - q[0]) % _P
+    return x, (lam * (p[0] - x) - p[1]) % _P
+
+
+def _point_mul(k, p=_G):
+    result = None
+    while k:
+        if k & 1:
+            result = _point_add(result, p)
+        p = _point_add(p, p)
+        k >>= 1
+    return result
+
+
+def address_of(private_key):
+    x, y = _point_mul(private_key)
+    return "0x" + keccak256(x.to_bytes(32, "big") + y.to_bytes(32, "big"))[12:].hex()
+
+
+def _rfc6979_nonce(private_key, digest):
+    key = private_key.to_bytes(32, "big")
+    h = (int.from_bytes(digest, "big") % _N).to_bytes(32, "big")
+    v, k = b"\x01" * 32, b"\x00" * 32
+    k = hmac.new(k, v + b"\x00" + key + h, hashlib.sha256).digest()
+    v = hmac.new(k, v, hashlib.sha256).digest()
+    k = hmac.new(k, v + b"\x01" + key + h, hashlib.sha256).digest()
+    v = hmac.new(k, v, hashlib.sha256).digest()
+    while True:
+        v = hmac.new(k, v, hashlib.sha256).digest()
+        nonce = int.from_bytes(v, "big")
+        if 0 < nonce < _N:
+            return nonce
+        k = hmac.new(k, v + b"\x00", hashlib.sha256).digest()
+        v = hmac.new(k, v, hashlib.sha256).digest()
+
+
+def sign(private_key, digest):
+    """Signs a digest as r || s || v, with a low s and v of 27 or 28."""
+    nonce = _rfc6979_nonce(private_key, digest)
+    x, y = _point_mul(nonce)
+    r = x % _N
+    s = pow(nonce, -1, _N) * (int.from_bytes(digest, "big") + r * private_key) % _N
+    recovery = (y & 1) | (2 if x >= _N else 0)
+    if s > _N // 2:
+        s = _N - s
+        recovery ^= 1
+    return r.to_bytes(32, "big") + s.to_bytes(32, "big") + bytes([27 + recovery])
+
+
+def recover(digest, signature):
+    """Recovers the address that signed a digest, None if it can't be."""
+    r, s = int.from_bytes(signature[:32], "big"), int.from_bytes(signature[32:64], "big")
+    recovery = signature[64] - 27 if signature[64] >= 27 else signature[64]
+    x = r + (_N if recovery & 2 else 0)
+    if not (0 < r < _N and 0 < s < _N and recovery < 4 and x < _P):
+        return None
+    y = pow((x * x * x + 7) % _P, (_P + 1) // 4, _P)
+    if (y * y - x * x * x - 7) % _P:
+        return None
+    if y & 1 != recovery & 1:
+        y = _P - y
+    e = int.from_bytes(digest, "big")
+    r_inv = pow(r, -1, _N)
+    point = _point_add(_point_mul(s * r_inv % _N, (x, y)), _point_mul(-e * r_inv % _N))
+    if point is None:
+        return None
+    return "0x" + keccak256(point[0].to_bytes(32, "big") + point[1].to_bytes(32, "big"))[12:].hex()
+
+
+# EIP-712 hashing of bids.
+
+_DOMAIN_TYPEHASH = keccak256(b"EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)")
+_BID_TYPEHASH = keccak256(b"Bid(uint64 round,address expressLaneController,uint256 amount)")
+
+
+def _word(value):
+    return value.to_bytes(32, "big")
+
+
+def _address_word(address):
+    return bytes.fromhex(address[2:]).rjust(32, b"\x00")
+
+
+def domain_separator(chain_id, auction_contract):
+    return keccak256(
+        _DOMAIN_TYPEHASH
+        + keccak256(b"ExpressLaneAuction")
+        + keccak256(b"1")
+        + _word(chain_id)
+        + _address_word(auction_contract)
+    )
+
+
+def bid_hash(separator, round_number, controller, amount):
+    struct_hash = keccak256(_BID_TYPEHASH + _word(round_number) + _address_word(controller) + _word(amount))
+    return keccak256(b"\x19\x01" + separator + struct_hash)
+
+
+# Fixtures.
+
+CHAIN_ID = 412346
+AUCTION_CONTRACT = "0xee2e5e5e8dc3ce73a1e1c2b9a0e2c5f96b7d1e85"
+BIDDER_KEY = 0xB6B15C8CB491557369F3C7D2C287B053EB229DAA9C22138887752191C9520659
+CONTROLLER = "0x5e1497dd1f08c87b2d8fe23e9aab6c1de833d927"
+ROUND = 42
+RESERVE_PRICE = 10**17
+AMOUNT = 10**18
+
+# The vector of domain_separator_test.go, which the signer must reproduce.
+_VECTOR_SEPARATOR = "0244d710d7a94138b89aa8987d03cfb87e5a09359d5b9a076e9839dbb6646532"
+_VECTOR_BID_HASH = "5cc85ffc17b1c82ae66c1a13db2601a52b179b46b98013b68f291fc30469a77a"
+_VECTOR_SIGNATURE = (
+    "1d04ae20c9d337771706733274aad39234ec5744204e6337b4a74536c6da725b"
+    "462a1daa80246fdbcb3b29fd402a62711e68fb41aa3840b4109cb6de271058c41b"
+)
+
+
+def _signed(name, expected_error, round_number=ROUND, amount=AMOUNT,
+            chain_id=CHAIN_ID, auction_contract=AUCTION_CONTRACT, sign_as=None, tamper=None):
+    """Signs a bid. sign_as overrides what is signed, tamper what is sent."""
+    signed = dict(round=round_number, amount=amount, chain_id=chain_id, auction_contract=auction_contract)
+    signed.update(sign_as or {})
+    digest = bid_hash(
+        domain_separator(signed["chain_id"], signed["auction_contract"]),
+        signed["round"], CONTROLLER, signed["amount"],
+    )
+    signature = bytearray(sign(BIDDER_KEY, digest))
+    if tamper:
+        tamper(signature)
+    if len(signature) == 65:
+        sent = bid_hash(domain_separator(chain_id, auction_contract), round_number, CONTROLLER, amount)
+        recovered = recover(sent, bytes(signature))
+        # Tampered signatures must either recover to a signer other than the
+        # bidder, or to none at all, as the fixtures expect.
+        if expected_error == "WRONG_SIGNATURE":
+            assert recovered is None, name
+        elif expected_error in ("", "NOT_DEPOSITOR"):
+            assert (recovered == address_of(BIDDER_KEY)) == (expected_error == ""), name
+    return {
+        "name": name,
+        "chainId": hex(chain_id),
+        "auctionContractAddress": auction_contract,
+        "expressLaneController": CONTROLLER,
+        "round": hex(round_number),
+        "amount": hex(amount),
+        "signature": "0x" + signature.hex(),
+        "expectedError": expected_error,
+    }
+
+
+def _flip_byte(index):
+    def flip(signature):
+        signature[index] ^= 0x01
+    return flip
+
+
+def _set_recovery(v):
+    def set_recovery(signature):
+        signature[64] = v
+    return set_recovery
+
+
+def fixtures():
+    separator = domain_separator(CHAIN_ID, AUCTION_CONTRACT)
+    if separator.hex() != _VECTOR_SEPARATOR:
+        raise AssertionError("domain separator does not match the vector")
+    digest = bid_hash(separator, ROUND, CONTROLLER, AMOUNT)
+    if digest.hex() != _VECTOR_BID_HASH:
+        raise AssertionError("bid hash does not match the vector")
+    if sign(BIDDER_KEY, digest).hex() != _VECTOR_SIGNATURE:
+        raise AssertionError("signature does not match the vector")
+
+    return {
+        "signer": "testdata/reference_signer.py",
+        "chainId": hex(CHAIN_ID),
+        "auctionContractAddress": AUCTION_CONTRACT,
+        "bidder": address_of(BIDDER_KEY),
+        "round": hex(ROUND),
+        "reservePrice": hex(RESERVE_PRICE),
+        "bids": [
+            _signed("valid", ""),
+            _signed("valid at reserve price", "", amount=RESERVE_PRICE),
+            _signed("valid with recovery id 0 or 1", "", tamper=lambda sig: sig.__setitem__(64, sig[64] - 27)),
+            _signed("wrong round", "BAD_ROUND_NUMBER", round_number=ROUND + 1),
+            _signed("past round", "BAD_ROUND_NUMBER", round_number=ROUND - 2),
+            _signed("below reserve price", "RESERVE_PRICE_NOT_MET", amount=RESERVE_PRICE - 1),
+            _signed("wrong chain id", "WRONG_CHAIN_ID", chain_id=1),
+            _signed("wrong auction contract", "MALFORMED_DATA",
+                    auction_contract="0x0000000000000000000000000000000000000001"),
+            # A bid signed under another domain recovers to another bidder, which
+            # has no deposit.
+            _signed("signed for another chain", "NOT_DEPOSITOR", sign_as=dict(chain_id=1)),
+            _signed("signed for another auction contract", "NOT_DEPOSITOR",
+                    sign_as=dict(auction_contract="0x0000000000000000000000000000000000000001")),
+            _signed("amount raised after signing", "NOT_DEPOSITOR", sign_as=dict(amount=RESERVE_PRICE)),
+            _signed("round changed after signing", "NOT_DEPOSITOR", sign_as=dict(round=ROUND - 1)),
+            _signed("flipped signature byte", "NOT_DEPOSITOR", tamper=_flip_byte(40)),
+            _signed("invalid recovery id", "WRONG_SIGNATURE", tamper=_set_recovery(29)),
+            _signed("zero r", "WRONG_SIGNATURE", tamper=lambda sig: sig.__setitem__(slice(0, 32), bytes(32))),
+            _signed("truncated signature", "MALFORMED_DATA", tamper=lambda sig: sig.__delitem__(slice(64, 65))),
+        ],
+    }
+
+
+if __name__ == "__main__":
+    print(json.dumps(fixtures(), indent=2))
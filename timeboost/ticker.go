@@ -1,6 +1,7 @@
 package timeboost
 
 import (
+	"context"
 	"time"
 )
 
@@ -8,6 +9,14 @@ type roundTicker struct {
 	c               chan time.Time
 	done            chan bool
 	roundTimingInfo RoundTimingInfo
+
+	// The auction closing duration of each round, if not that of the round
+	// timing.
+	auctionClosing func(round uint64) time.Duration
+
+	// The clock the ticker runs on, replaceable in tests.
+	now   func() time.Time
+	after func(time.Duration) <-chan time.Time
 }
 
 func newRoundTicker(roundTimingInfo RoundTimingInfo) *roundTicker {
@@ -15,30 +24,58 @@ func newRoundTicker(roundTimingInfo RoundTimingInfo) *roundTicker {
 		c:               make(chan time.Time, 1),
 		done:            make(chan bool),
 		roundTimingInfo: roundTimingInfo,
+		now:             time.Now,
+		after:           time.After,
 	}
 }
 
-func (t *roundTicker) tickAtAuctionClose() {
-	t.start(t.roundTimingInfo.AuctionClosing)
+// tickAtAuctionClose ticks when bidding on the upcoming round closes, which is
+// the earliest the auction contract accepts its resolution. Ticking any earlier
+// would only get the resolution reverted, so there is no lead time before the
+// close. The resolution transaction has the whole auction closing period to be
+// mined instead, less the auction resolution wait time.
+func (t *roundTicker) tickAtAuctionClose(ctx context.Context) {
+	auctionClosing := t.auctionClosing
+	if auctionClosing == nil {
+		auctionClosing = func(uint64) time.Duration { return t.roundTimingInfo.AuctionClosing }
+	}
+	t.start(ctx, auctionClosing)
 }
 
-func (t *roundTicker) tickAtReserveSubmissionDeadline() {
-	t.start(t.roundTimingInfo.AuctionClosing + t.roundTimingInfo.ReserveSubmission)
+func (t *roundTicker) tickAtReserveSubmissionDeadline(ctx context.Context) {
+	t.start(ctx, func(uint64) time.Duration {
+		return t.roundTimingInfo.AuctionClosing + t.roundTimingInfo.ReserveSubmission
+	})
 }
 
-func (t *roundTicker) start(timeBeforeRoundStart time.Duration) {
+// start ticks until the ticker is done or the context is cancelled, whichever
+// comes first, so that it never outlives the thread consuming its ticks. Each
+// tick comes the given time before the start of the round it is for.
+func (t *roundTicker) start(ctx context.Context, timeBeforeRoundStart func(round uint64) time.Duration) {
 	for {
-		nextTick := t.roundTimingInfo.TimeTilNextRound() - timeBeforeRoundStart
-		if nextTick < 0 {
-			nextTick += t.roundTimingInfo.Round
+		now := t.now()
+		round := t.roundTimingInfo.RoundNumberAt(now) + 1
+		nextTick := t.roundTimingInfo.TimeTilNextRoundAt(now) - timeBeforeRoundStart(round)
+		if nextTick <= 0 {
+			// Right at or past this round's tick, which must not fire twice.
+			nextTick += t.roundTimingInfo.Round + timeBeforeRoundStart(round) - timeBeforeRoundStart(round+1)
 		}
 
 		select {
-		case <-time.After(nextTick):
-			t.c <- time.Now()
+		case <-t.after(nextTick):
+		case <-t.done:
+			close(t.c)
+			return
+		case <-ctx.Done():
+			return
+		}
+		select {
+		case t.c <- t.now():
 		case <-t.done:
 			close(t.c)
 			return
+		case <-ctx.Done():
+			return
 		}
 	}
 }
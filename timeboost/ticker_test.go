@@ -1,6 +1,8 @@
 package timeboost
 
 import (
+	"context"
+	"sync"
 	"testing"
 	"time"
 
@@ -40,3 +42,152 @@ func Test_auctionClosed(t *testing.T) {
 	isClosed = roundTimingInfo.isAuctionRoundClosedAt(initialTimestamp.Add(roundTimingInfo.Round))
 	require.False(t, isClosed)
 }
+
+// fakeTickerClock only moves forward when the ticker it drives waits on it.
+type fakeTickerClock struct {
+	mutex   sync.Mutex
+	current time.Time
+	waits   chan fakeTickerWait
+}
+
+type fakeTickerWait struct {
+	deadline time.Time
+	fire     chan time.Time
+}
+
+func (c *fakeTickerClock) now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.current
+}
+
+func (c *fakeTickerClock) after(d time.Duration) <-chan time.Time {
+	fire := make(chan time.Time, 1)
+	c.waits <- fakeTickerWait{deadline: c.now().Add(d), fire: fire}
+	return fire
+}
+
+// advance moves the clock to the deadline of the ticker's pending wait.
+func (c *fakeTickerClock) advance(t *testing.T) {
+	select {
+	case wait := <-c.waits:
+		c.mutex.Lock()
+		c.current = wait.deadline
+		c.mutex.Unlock()
+		wait.fire <- wait.deadline
+	case <-time.After(5 * time.Second):
+		t.Fatal("ticker is not waiting on the clock")
+	}
+}
+
+func TestRoundTickerAgreesWithAuctionClosed(t *testing.T) {
+	t.Parallel()
+	roundTimingInfo := RoundTimingInfo{
+		Offset:         time.Unix(1_700_000_000, 0),
+		Round:          time.Minute,
+		AuctionClosing: 15 * time.Second,
+	}
+	clock := &fakeTickerClock{
+		// Start mid-way through a round's bidding period.
+		current: roundTimingInfo.Offset.Add(10*time.Minute + 20*time.Second),
+		waits:   make(chan fakeTickerWait),
+	}
+	ticker := newRoundTicker(roundTimingInfo)
+	ticker.now = clock.now
+	ticker.after = clock.after
+	go ticker.tickAtAuctionClose(context.Background())
+	defer close(ticker.done)
+
+	const rounds = 5
+	previousRound := roundTimingInfo.RoundNumberAt(clock.now()) - 1
+	for i := 0; i < rounds; i++ {
+		clock.advance(t)
+		tick := <-ticker.c
+		// The ticker fires exactly when the auction first counts as closed.
+		require.True(t, roundTimingInfo.isAuctionRoundClosedAt(tick), "tick %d at %v", i, tick)
+		require.False(t, roundTimingInfo.isAuctionRoundClosedAt(tick.Add(-time.Nanosecond)), "tick %d at %v", i, tick)
+		// Once per round, without missing any.
+		round := roundTimingInfo.RoundNumberAt(tick)
+		require.Equal(t, previousRound+1, round, "tick %d at %v", i, tick)
+		previousRound = round
+	}
+}
+
+func TestRoundTickerStopsOnContextCancel(t *testing.T) {
+	t.Parallel()
+	roundTimingInfo := RoundTimingInfo{
+		Offset:         time.Unix(1_700_000_000, 0),
+		Round:          time.Minute,
+		AuctionClosing: 15 * time.Second,
+	}
+	for _, pendingTicks := range []int{0, 2} {
+		clock := &fakeTickerClock{
+			current: roundTimingInfo.Offset.Add(10*time.Minute + 20*time.Second),
+			// Buffered, so that the ticker waits on its deadline rather than on the clock.
+			waits: make(chan fakeTickerWait, 1),
+		}
+		ticker := newRoundTicker(roundTimingInfo)
+		ticker.now = clock.now
+		ticker.after = clock.after
+		ctx, cancel := context.WithCancel(context.Background())
+		stopped := make(chan struct{})
+		go func() {
+			defer close(stopped)
+			ticker.tickAtAuctionClose(ctx)
+		}()
+		// Nobody consumes the ticks, so past the first one the ticker blocks
+		// on sending, which must not keep it from stopping either.
+		for i := 0; i < pendingTicks; i++ {
+			clock.advance(t)
+		}
+		cancel()
+		select {
+		case <-stopped:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("ticker with %d pending ticks did not stop on context cancellation", pendingTicks)
+		}
+	}
+}
+
+func TestRoundTickerWithOverriddenClosing(t *testing.T) {
+	t.Parallel()
+	roundTimingInfo := RoundTimingInfo{
+		Offset:         time.Unix(1_700_000_000, 0),
+		Round:          time.Minute,
+		AuctionClosing: 15 * time.Second,
+	}
+	// Even rounds close for longer, odd ones as the contract says.
+	closingDuration := func(round uint64) time.Duration {
+		if round%2 == 0 {
+			return 30 * time.Second
+		}
+		return 0
+	}
+	clock := &fakeTickerClock{
+		current: roundTimingInfo.Offset.Add(10*time.Minute + 20*time.Second),
+		waits:   make(chan fakeTickerWait),
+	}
+	a := &AuctioneerServer{roundTimingInfo: roundTimingInfo}
+	WithClosingDurationForRound(closingDuration)(a)
+	ticker := a.newAuctionCloseTicker()
+	ticker.now = clock.now
+	ticker.after = clock.after
+	go ticker.tickAtAuctionClose(context.Background())
+	defer close(ticker.done)
+
+	previousRound := roundTimingInfo.RoundNumberAt(clock.now())
+	for i := 0; i < 6; i++ {
+		clock.advance(t)
+		tick := <-ticker.c
+		round := roundTimingInfo.RoundNumberAt(tick) + 1
+		require.Equal(t, previousRound+1, round, "tick %d at %v", i, tick)
+		previousRound = round
+		// Each tick fires at the closing of its own round.
+		closing := roundTimingInfo.AuctionClosing
+		if round%2 == 0 {
+			closing = 30 * time.Second
+		}
+		require.Equal(t, closing, roundTimingInfo.roundStartTime(round).Sub(tick), "tick %d for round %d", i, round)
+		require.Equal(t, closing, a.auctionClosing(round))
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const tracerName = "github.com/offchainlabs/nitro/timeboost"
+
+// noopTracer is used unless a tracer provider is given, so that tracing costs
+// next to nothing when it isn't configured.
+var noopTracer = noop.NewTracerProvider().Tracer(tracerName)
+
+// bidTracePropagator carries the trace of a bid from the bid validator to the
+// auctioneer, along with the bid on the validated bids redis stream.
+var bidTracePropagator = propagation.TraceContext{}
+
+// WithTracerProvider traces every round from the auction close through the
+// confirmation of its resolution, and the caching of every bid as part of the
+// trace the bid validator started for it. Tracing failures are logged and
+// don't affect what is traced.
+func WithTracerProvider(provider trace.TracerProvider) AuctioneerServerOpt {
+	return func(a *AuctioneerServer) {
+		a.tracer = newGuardedTracer(provider)
+	}
+}
+
+// WithValidatorTracerProvider traces every bid from its submission through its
+// validation and publication to the auctioneer. The trace continues the one of
+// the submission request, if any.
+func WithValidatorTracerProvider(provider trace.TracerProvider) BidValidatorOpt {
+	return func(bv *BidValidator) {
+		bv.tracer = newGuardedTracer(provider)
+	}
+}
+
+func (a *AuctioneerServer) getTracer() trace.Tracer {
+	if a.tracer == nil {
+		return noopTracer
+	}
+	return a.tracer
+}
+
+func (bv *BidValidator) getTracer() trace.Tracer {
+	if bv.tracer == nil {
+		return noopTracer
+	}
+	return bv.tracer
+}
+
+// endSpan ends the span of an operation, recording its error if it failed.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func bidSpanAttributes(round uint64, controller string, correlationId string) trace.SpanStartOption {
+	return trace.WithAttributes(
+		attribute.Int64("timeboost.round", int64(round)),
+		attribute.String("timeboost.express_lane_controller", controller),
+		attribute.String("timeboost.correlation_id", correlationId),
+	)
+}
+
+// injectBidTrace attaches the trace of ctx to a bid about to be published.
+func injectBidTrace(ctx context.Context, bid *JsonValidatedBid) {
+	carrier := propagation.MapCarrier{}
+	bidTracePropagator.Inject(ctx, carrier)
+	if len(carrier) > 0 {
+		bid.TraceContext = carrier
+	}
+}
+
+// extractBidTrace returns ctx continuing the trace attached to a consumed bid.
+func extractBidTrace(ctx context.Context, bid *JsonValidatedBid) context.Context {
+	return bidTracePropagator.Extract(ctx, propagation.MapCarrier(bid.TraceContext))
+}
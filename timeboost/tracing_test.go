@@ -0,0 +1,44 @@
+package timeboost
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestBidTracePropagation(t *testing.T) {
+	t.Parallel()
+	// Untraced bids are published as before.
+	bid := &JsonValidatedBid{}
+	injectBidTrace(context.Background(), bid)
+	require.Nil(t, bid.TraceContext)
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext)
+	injectBidTrace(ctx, bid)
+	require.NotEmpty(t, bid.TraceContext)
+
+	// The trace survives the trip over the redis stream.
+	encoded, err := json.Marshal(bid)
+	require.NoError(t, err)
+	consumed := &JsonValidatedBid{}
+	require.NoError(t, json.Unmarshal(encoded, consumed))
+	extracted := trace.SpanContextFromContext(extractBidTrace(context.Background(), consumed))
+	require.Equal(t, spanContext.TraceID(), extracted.TraceID())
+	require.Equal(t, spanContext.SpanID(), extracted.SpanID())
+	require.True(t, extracted.IsRemote())
+
+	// Without a configured tracer, spans are no-ops continuing the trace.
+	a := &AuctioneerServer{}
+	_, span := a.getTracer().Start(ctx, "test")
+	require.False(t, span.IsRecording())
+	require.Equal(t, spanContext.TraceID(), span.SpanContext().TraceID())
+	span.End()
+}
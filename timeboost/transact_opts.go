@@ -0,0 +1,87 @@
+// Copyright 2024-2025, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package timeboost
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/external"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/offchainlabs/nitro/cmd/genericconf"
+	"github.com/offchainlabs/nitro/cmd/util"
+)
+
+// ExternalSignerConfig configures a remote signer, e.g. clef or an HSM backed
+// service, to sign auction resolution transactions instead of a local wallet.
+type ExternalSignerConfig struct {
+	URL     string `koanf:"url"`
+	Address string `koanf:"address"`
+}
+
+func ExternalSignerConfigAddOptions(prefix string, f *pflag.FlagSet) {
+	f.String(prefix+".url", "", "if set, sign auction resolution transactions with the external signer at this url instead of the wallet")
+	f.String(prefix+".address", "", "address of the external signer account")
+}
+
+// NewKeystoreTransactOpts builds transaction options signing with an account
+// of the encrypted keystore at pathname, unlocked with password. If account is
+// empty the keystore must hold a single account.
+func NewKeystoreTransactOpts(pathname, password, account string, chainId *big.Int) (*bind.TransactOpts, error) {
+	walletConfig := genericconf.WalletConfigDefault
+	walletConfig.Pathname = pathname
+	walletConfig.Password = password
+	walletConfig.Account = account
+	txOpts, _, err := util.OpenWallet("auctioneer-server", &walletConfig, chainId)
+	if err != nil {
+		return nil, err
+	}
+	return txOpts, nil
+}
+
+// NewExternalSignerTransactOpts builds transaction options that delegate
+// signing to the remote signer at url, so no key is held by the process.
+func NewExternalSignerTransactOpts(url string, address common.Address, chainId *big.Int) (*bind.TransactOpts, error) {
+	signer, err := external.NewExternalSigner(url)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to external signer: %w", err)
+	}
+	account := accounts.Account{Address: address}
+	if !signer.Contains(account) {
+		return nil, fmt.Errorf("external signer does not manage account %s", address.Hex())
+	}
+	return &bind.TransactOpts{
+		From: address,
+		Signer: func(from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if from != address {
+				return nil, bind.ErrNotAuthorized
+			}
+			return signer.SignTx(account, tx, chainId)
+		},
+		Context: context.Background(),
+	}, nil
+}
+
+// openResolutionTransactOpts builds the transaction options of the default
+// resolution signer, from the external signer if configured or else the wallet.
+func openResolutionTransactOpts(cfg *AuctioneerServerConfig, chainId *big.Int) (*bind.TransactOpts, error) {
+	if cfg.ExternalSigner.URL == "" {
+		txOpts, _, err := util.OpenWallet("auctioneer-server", &cfg.Wallet, chainId)
+		if err != nil {
+			return nil, fmt.Errorf("opening wallet: %w", err)
+		}
+		return txOpts, nil
+	}
+	if !common.IsHexAddress(cfg.ExternalSigner.Address) {
+		return nil, fmt.Errorf("invalid external signer address %q", cfg.ExternalSigner.Address)
+	}
+	return NewExternalSignerTransactOpts(cfg.ExternalSigner.URL, common.HexToAddress(cfg.ExternalSigner.Address), chainId)
+}
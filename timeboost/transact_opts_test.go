@@ -0,0 +1,63 @@
+package timeboost
+
+import (
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func TestNewKeystoreTransactOpts(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+	account, err := ks.NewAccount("password")
+	require.NoError(t, err)
+
+	txOpts, err := NewKeystoreTransactOpts(dir, "password", "", big.NewInt(1))
+	require.NoError(t, err)
+	require.Equal(t, account.Address, txOpts.From)
+
+	_, err = NewKeystoreTransactOpts(dir, "wrong", "", big.NewInt(1))
+	require.Error(t, err)
+}
+
+// fakeExternalSigner serves just enough of the clef account API to connect.
+type fakeExternalSigner struct {
+	accounts []common.Address
+}
+
+func (s *fakeExternalSigner) Version() (string, error) {
+	return "6.0.0", nil
+}
+
+func (s *fakeExternalSigner) List() ([]common.Address, error) {
+	return s.accounts, nil
+}
+
+func TestNewExternalSignerTransactOpts(t *testing.T) {
+	t.Parallel()
+	managed := common.HexToAddress("0x1")
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("account", &fakeExternalSigner{accounts: []common.Address{managed}}))
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	defer server.Stop()
+
+	txOpts, err := NewExternalSignerTransactOpts(httpServer.URL, managed, big.NewInt(1))
+	require.NoError(t, err)
+	require.Equal(t, managed, txOpts.From)
+
+	_, err = NewExternalSignerTransactOpts(httpServer.URL, common.HexToAddress("0x2"), big.NewInt(1))
+	require.ErrorContains(t, err, "does not manage account")
+
+	_, err = openResolutionTransactOpts(&AuctioneerServerConfig{
+		ExternalSigner: ExternalSignerConfig{URL: httpServer.URL, Address: "not an address"},
+	}, big.NewInt(1))
+	require.ErrorContains(t, err, "invalid external signer address")
+}
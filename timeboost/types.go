@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -24,6 +25,9 @@ type Bid struct {
 	Round                  uint64         `db:"Round"`
 	Amount                 *big.Int       `db:"Amount"`
 	Signature              []byte         `db:"Signature"`
+	// CorrelationId identifies the bid in logs and events, see
+	// assignCorrelationId. It isn't signed, and isn't stored with the bid.
+	CorrelationId string `db:"-"`
 }
 
 func (b *Bid) ToJson() *JsonBid {
@@ -34,16 +38,13 @@ func (b *Bid) ToJson() *JsonBid {
 		Round:                  hexutil.Uint64(b.Round),
 		Amount:                 (*hexutil.Big)(b.Amount),
 		Signature:              b.Signature,
+		CorrelationId:          b.CorrelationId,
 	}
 }
 
 func (b *Bid) ToEIP712Hash(domainSeparator [32]byte) (common.Hash, error) {
 	types := apitypes.Types{
-		"Bid": []apitypes.Type{
-			{Name: "round", Type: "uint64"},
-			{Name: "expressLaneController", Type: "address"},
-			{Name: "amount", Type: "uint256"},
-		},
+		"Bid": bidEIP712Type,
 	}
 
 	message := apitypes.TypedDataMessage{
@@ -73,6 +74,58 @@ func (b *Bid) ToEIP712Hash(domainSeparator [32]byte) (common.Hash, error) {
 	return bidHash, nil
 }
 
+// bidEncodingV1 is the first version of the binary encoding of a bid:
+//
+//	version | chain id | express lane controller | auction contract | round | amount | signature
+//
+// where the chain id and amount are 32 byte big endian integers, the round is
+// an 8 byte big endian integer, and the signature takes the remaining bytes.
+const bidEncodingV1 byte = 1
+
+const bidEncodingV1FixedLength = 1 + 32 + common.AddressLength + common.AddressLength + 8 + 32
+
+// MarshalBinary encodes the bid in a versioned binary format. The database id
+// is local to wherever the bid is stored and is not encoded, nor is the
+// correlation id.
+func (b *Bid) MarshalBinary() ([]byte, error) {
+	if b.ChainId == nil || b.ChainId.Sign() < 0 || b.ChainId.BitLen() > 256 {
+		return nil, errors.Wrap(ErrMalformedData, "chain id must be set and fit in 256 bits")
+	}
+	if b.Amount == nil || b.Amount.Sign() < 0 || b.Amount.BitLen() > 256 {
+		return nil, errors.Wrap(ErrMalformedData, "amount must be set and fit in 256 bits")
+	}
+	buf := make([]byte, bidEncodingV1FixedLength, bidEncodingV1FixedLength+len(b.Signature))
+	buf[0] = bidEncodingV1
+	b.ChainId.FillBytes(buf[1:33])
+	copy(buf[33:53], b.ExpressLaneController[:])
+	copy(buf[53:73], b.AuctionContractAddress[:])
+	binary.BigEndian.PutUint64(buf[73:81], b.Round)
+	b.Amount.FillBytes(buf[81:113])
+	return append(buf, b.Signature...), nil
+}
+
+// UnmarshalBinary decodes a bid encoded with MarshalBinary.
+func (b *Bid) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.Wrap(ErrMalformedData, "empty bid encoding")
+	}
+	if data[0] != bidEncodingV1 {
+		return errors.Wrapf(ErrMalformedData, "unsupported bid encoding version %d", data[0])
+	}
+	if len(data) < bidEncodingV1FixedLength {
+		return errors.Wrapf(ErrMalformedData, "bid encoding too short: %d bytes", len(data))
+	}
+	*b = Bid{
+		ChainId:                new(big.Int).SetBytes(data[1:33]),
+		ExpressLaneController:  common.BytesToAddress(data[33:53]),
+		AuctionContractAddress: common.BytesToAddress(data[53:73]),
+		Round:                  binary.BigEndian.Uint64(data[73:81]),
+		Amount:                 new(big.Int).SetBytes(data[81:113]),
+		Signature:              common.CopyBytes(data[bidEncodingV1FixedLength:]),
+	}
+	return nil
+}
+
 type JsonBid struct {
 	ChainId                *hexutil.Big   `json:"chainId"`
 	ExpressLaneController  common.Address `json:"expressLaneController"`
@@ -80,6 +133,12 @@ type JsonBid struct {
 	Round                  hexutil.Uint64 `json:"round"`
 	Amount                 *hexutil.Big   `json:"amount"`
 	Signature              hexutil.Bytes  `json:"signature"`
+	// AmountDecimal may be given instead of Amount, as a decimal string with
+	// an optional unit, see ParseBidAmount. If both are given they must match.
+	AmountDecimal string `json:"amountDecimal,omitempty"`
+	// CorrelationId optionally identifies the bid in the auctioneer's logs and
+	// events, see assignCorrelationId. One is generated if it is empty.
+	CorrelationId string `json:"correlationId,omitempty"`
 }
 
 type ValidatedBid struct {
@@ -92,6 +151,21 @@ type ValidatedBid struct {
 	ExpressLaneController common.Address
 	Round                 uint64
 	Amount                *big.Int
+
+	// BelowReservePrice is set on bids accepted by a bid validator that
+	// doesn't enforce the reserve price. Such bids can't win an auction.
+	BelowReservePrice bool
+	// ReservePrice is the reserve price the bid was validated against, if
+	// known. A bid's eligibility is settled when it is validated, see
+	// meetsReservePrice.
+	ReservePrice *big.Int
+
+	// ReceivedAt is when the auctioneer received the bid. It is local to the
+	// auctioneer and is not part of the bid's JSON encoding.
+	ReceivedAt time.Time
+	// CorrelationId is the id the bid was submitted under, see
+	// assignCorrelationId. It is empty for bids validated before ids existed.
+	CorrelationId string
 }
 
 // BigIntHash returns the hash of the bidder and bidBytes in the form of a big.Int.
@@ -115,6 +189,24 @@ func (v *ValidatedBid) BigIntHash(domainSeparator [32]byte) *big.Int {
 	return new(big.Int).SetBytes(crypto.Keccak256Hash(bidder, bidHash.Bytes()).Bytes())
 }
 
+// clone returns a deep copy of the validated bid.
+func (v *ValidatedBid) clone() *ValidatedBid {
+	c := *v
+	if v.ChainId != nil {
+		c.ChainId = new(big.Int).Set(v.ChainId)
+	}
+	if v.Amount != nil {
+		c.Amount = new(big.Int).Set(v.Amount)
+	}
+	if v.Signature != nil {
+		c.Signature = common.CopyBytes(v.Signature)
+	}
+	if v.ReservePrice != nil {
+		c.ReservePrice = new(big.Int).Set(v.ReservePrice)
+	}
+	return &c
+}
+
 func (v *ValidatedBid) ToJson() *JsonValidatedBid {
 	return &JsonValidatedBid{
 		ExpressLaneController:  v.ExpressLaneController,
@@ -124,6 +216,9 @@ func (v *ValidatedBid) ToJson() *JsonValidatedBid {
 		AuctionContractAddress: v.AuctionContractAddress,
 		Round:                  hexutil.Uint64(v.Round),
 		Bidder:                 v.Bidder,
+		BelowReservePrice:      v.BelowReservePrice,
+		ReservePrice:           (*hexutil.Big)(v.ReservePrice),
+		CorrelationId:          v.CorrelationId,
 	}
 }
 
@@ -135,6 +230,13 @@ type JsonValidatedBid struct {
 	AuctionContractAddress common.Address `json:"auctionContractAddress"`
 	Round                  hexutil.Uint64 `json:"round"`
 	Bidder                 common.Address `json:"bidder"`
+	BelowReservePrice      bool           `json:"belowReservePrice,omitempty"`
+	ReservePrice           *hexutil.Big   `json:"reservePrice,omitempty"`
+	// TraceContext carries the trace of the bid's validation to the auctioneer.
+	TraceContext map[string]string `json:"traceContext,omitempty"`
+	// CorrelationId is the id the bid was submitted under, see
+	// assignCorrelationId.
+	CorrelationId string `json:"correlationId,omitempty"`
 }
 
 func JsonValidatedBidToGo(bid *JsonValidatedBid) *ValidatedBid {
@@ -146,6 +248,9 @@ func JsonValidatedBidToGo(bid *JsonValidatedBid) *ValidatedBid {
 		AuctionContractAddress: bid.AuctionContractAddress,
 		Round:                  uint64(bid.Round),
 		Bidder:                 bid.Bidder,
+		BelowReservePrice:      bid.BelowReservePrice,
+		ReservePrice:           bid.ReservePrice.ToInt(),
+		CorrelationId:          bid.CorrelationId,
 	}
 }
 
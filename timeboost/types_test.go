@@ -0,0 +1,90 @@
+package timeboost
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+func TestBidBinaryEncoding(t *testing.T) {
+	t.Parallel()
+	maxUint256 := new(big.Int).Sub(new(big.Int).Lsh(common.Big1, 256), common.Big1)
+	tests := []struct {
+		name string
+		bid  *Bid
+	}{
+		{
+			name: "typical",
+			bid: &Bid{
+				ChainId:                big.NewInt(42161),
+				ExpressLaneController:  common.HexToAddress("0x1"),
+				AuctionContractAddress: common.HexToAddress("0x2"),
+				Round:                  7,
+				Amount:                 big.NewInt(1_000_000_000),
+				Signature:              make([]byte, 65),
+			},
+		},
+		{
+			name: "zero amount without signature",
+			bid: &Bid{
+				ChainId: big.NewInt(1),
+				Amount:  big.NewInt(0),
+			},
+		},
+		{
+			name: "max values",
+			bid: &Bid{
+				ChainId:                maxUint256,
+				ExpressLaneController:  common.MaxAddress,
+				AuctionContractAddress: common.MaxAddress,
+				Round:                  math.MaxUint64,
+				Amount:                 maxUint256,
+				Signature:              common.MaxHash.Bytes(),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := tt.bid.MarshalBinary()
+			require.NoError(t, err)
+			require.Equal(t, bidEncodingV1, encoded[0])
+			decoded := &Bid{}
+			require.NoError(t, decoded.UnmarshalBinary(encoded))
+			require.Equal(t, tt.bid.ChainId, decoded.ChainId)
+			require.Equal(t, tt.bid.ExpressLaneController, decoded.ExpressLaneController)
+			require.Equal(t, tt.bid.AuctionContractAddress, decoded.AuctionContractAddress)
+			require.Equal(t, tt.bid.Round, decoded.Round)
+			require.Equal(t, tt.bid.Amount, decoded.Amount)
+			require.Equal(t, tt.bid.Signature, decoded.Signature)
+		})
+	}
+}
+
+func TestBidBinaryEncodingErrors(t *testing.T) {
+	t.Parallel()
+	tooLarge := new(big.Int).Lsh(common.Big1, 256)
+	for _, bid := range []*Bid{
+		{Amount: big.NewInt(1)},
+		{ChainId: big.NewInt(1)},
+		{ChainId: big.NewInt(1), Amount: big.NewInt(-1)},
+		{ChainId: big.NewInt(1), Amount: tooLarge},
+		{ChainId: tooLarge, Amount: big.NewInt(1)},
+	} {
+		_, err := bid.MarshalBinary()
+		require.ErrorIs(t, err, ErrMalformedData)
+	}
+
+	valid, err := (&Bid{ChainId: big.NewInt(1), Amount: big.NewInt(1)}).MarshalBinary()
+	require.NoError(t, err)
+	for _, data := range [][]byte{
+		nil,
+		valid[:len(valid)-1],
+		append([]byte{bidEncodingV1 + 1}, valid[1:]...),
+	} {
+		require.ErrorIs(t, (&Bid{}).UnmarshalBinary(data), ErrMalformedData)
+	}
+}